@@ -6,6 +6,8 @@ toolchain go1.24.4
 
 require github.com/joho/godotenv v1.5.1
 
+require gopkg.in/yaml.v3 v3.0.1
+
 require (
 	github.com/google/jsonschema-go v0.2.0
 	github.com/modelcontextprotocol/go-sdk v0.3.0
@@ -0,0 +1,270 @@
+package advanced_tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/raja-aiml/webex-mcp-server/internal/cardresponse"
+	"github.com/raja-aiml/webex-mcp-server/internal/cards"
+	"github.com/raja-aiml/webex-mcp-server/internal/config"
+	"github.com/raja-aiml/webex-mcp-server/internal/tools"
+	"github.com/raja-aiml/webex-mcp-server/internal/webex"
+)
+
+// defaultCardFallback is the message text shown by clients that cannot render cards
+const defaultCardFallback = "This message contains a card. Open it in a Webex app to view it."
+
+// CardParams describes a card given whole, by template, or as a title, text, facts, inputs and actions
+type CardParams struct {
+	Card     map[string]interface{} `json:"card,omitempty"`
+	Template string                 `json:"template,omitempty"`
+	Data     map[string]interface{} `json:"data,omitempty"`
+	cards.Spec
+}
+
+// SendCardParams defines the parameters for sending a card
+type SendCardParams struct {
+	RoomId        string `json:"roomId,omitempty"`
+	ToPersonId    string `json:"toPersonId,omitempty"`
+	ToPersonEmail string `json:"toPersonEmail,omitempty"`
+	ParentId      string `json:"parentId,omitempty"`
+	FallbackText  string `json:"fallbackText,omitempty"`
+	CardParams
+}
+
+// cardProperties are the schema of CardParams
+func cardProperties() map[string]*jsonschema.Schema {
+	pair := func(description string) *jsonschema.Schema {
+		return tools.ObjectProperty(description, map[string]*jsonschema.Schema{
+			"title": tools.StringProperty(""),
+			"value": tools.StringProperty(""),
+		})
+	}
+	return map[string]*jsonschema.Schema{
+		"card":     tools.ObjectProperty(fmt.Sprintf("A complete Adaptive Card (type AdaptiveCard, version up to %s). Use instead of template or the title/text/facts/inputs/actions fields.", cards.Version), map[string]*jsonschema.Schema{}),
+		"template": tools.StringProperty("Name of a card template in the server's card template directory; see list_card_templates."),
+		"data":     tools.ObjectProperty("Values for the template's ${name} variables.", map[string]*jsonschema.Schema{}),
+		"title":    tools.StringProperty("Card heading."),
+		"text":     tools.StringProperty("Card text below the heading."),
+		"facts":    tools.ArrayProperty("Title and value pairs shown as a table.", pair("A fact.")),
+		"inputs": tools.ArrayProperty("Fields for the reader to fill in. A card with inputs and no actions gets a Submit button.",
+			tools.ObjectProperty("An input.", map[string]*jsonschema.Schema{
+				"id":          tools.StringProperty("Key of the value in the submitted inputs."),
+				"type":        tools.EnumProperty("Kind of input (default text).", cards.InputTypes...),
+				"label":       tools.StringProperty("Label shown above the input."),
+				"placeholder": tools.StringProperty("Hint shown in an empty input."),
+				"value":       tools.StringProperty("Initial value."),
+				"required":    tools.BooleanProperty("Whether the card can be submitted without it."),
+				"multiline":   tools.BooleanProperty("Allow several lines of text."),
+				"multiSelect": tools.BooleanProperty("Allow choosing several choices."),
+				"choices":     tools.ArrayProperty("Options of a choice input.", pair("A choice.")),
+			})),
+		"actions": tools.ArrayProperty("Buttons at the bottom of the card.",
+			tools.ObjectProperty("A button.", map[string]*jsonschema.Schema{
+				"type":  tools.EnumProperty("What the button does (default submit).", cards.ActionTypes...),
+				"title": tools.StringProperty("Button label."),
+				"url":   tools.StringProperty("Address opened by an openUrl button."),
+				"data":  tools.ObjectProperty("Values sent with the inputs when a submit button is pressed.", map[string]*jsonschema.Schema{}),
+			})),
+	}
+}
+
+// build returns the validated card described by the parameters
+func (p *CardParams) build() (interface{}, error) {
+	sources := 0
+	for _, set := range []bool{p.Card != nil, p.Template != "", !p.Spec.IsZero()} {
+		if set {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return nil, fmt.Errorf("give exactly one of card, template, or the title, text, facts, inputs and actions fields")
+	}
+
+	switch {
+	case p.Card != nil:
+		if err := cards.Validate(p.Card); err != nil {
+			return nil, err
+		}
+		return p.Card, nil
+	case p.Template != "":
+		cfg, err := config.Load()
+		if err != nil {
+			return nil, err
+		}
+		if cfg.Cards.Dir == "" {
+			return nil, fmt.Errorf("card templates are not configured: set cards.dir or WEBEX_CARDS_DIR")
+		}
+		return cards.FromTemplate(cfg.Cards.Dir, p.Template, p.Data)
+	default:
+		return p.Spec.Build()
+	}
+}
+
+// recipient returns the message fields addressing exactly one of a room, person ID or email
+func recipient(roomID, toPersonID, toPersonEmail string) (map[string]interface{}, error) {
+	message := map[string]interface{}{}
+	for key, value := range map[string]string{"roomId": roomID, "toPersonId": toPersonID, "toPersonEmail": toPersonEmail} {
+		if value != "" {
+			message[key] = value
+		}
+	}
+	switch len(message) {
+	case 0:
+		return nil, fmt.Errorf("exactly one of roomId, toPersonId, or toPersonEmail is required")
+	case 1:
+		return message, nil
+	default:
+		return nil, fmt.Errorf("only one of roomId, toPersonId, or toPersonEmail should be specified")
+	}
+}
+
+// NewSendCardTool posts a validated Adaptive Card
+func NewSendCardTool() tools.Tool {
+	properties := cardProperties()
+	properties["roomId"] = tools.StringProperty("The room to post the card in, by ID.")
+	properties["toPersonId"] = tools.StringProperty("The person to send the card to directly, by ID.")
+	properties["toPersonEmail"] = tools.StringProperty("The person to send the card to directly, by email.")
+	properties["parentId"] = tools.StringProperty("The message to reply to, by ID.")
+	properties["fallbackText"] = tools.StringProperty("Markdown shown by clients that cannot render cards (default the card's fallbackText or title).")
+
+	return tools.NewGenericTool("send_card",
+		"Post an Adaptive Card to a room or person. Describe the card with title, text, facts, inputs and actions, "+
+			"name a template with data, or pass a complete card. The card is checked against the Adaptive Card "+
+			cards.Version+" schema Webex supports and every problem is reported before anything is sent.",
+		tools.SimpleSchema("Send an Adaptive Card. Specify either roomId, toPersonId, or toPersonEmail.", properties, nil),
+		func(params *SendCardParams, client webex.HTTPClient) (interface{}, error) {
+			message, err := recipient(params.RoomId, params.ToPersonId, params.ToPersonEmail)
+			if err != nil {
+				return nil, err
+			}
+			card, err := params.build()
+			if err != nil {
+				return nil, err
+			}
+
+			fallback := params.FallbackText
+			if raw, ok := card.(map[string]interface{}); ok && fallback == "" {
+				fallback, _ = raw["fallbackText"].(string)
+			}
+			if fallback == "" && params.Title != "" {
+				fallback = params.Title
+			}
+			if fallback == "" {
+				fallback = defaultCardFallback
+			}
+			message["markdown"] = fallback
+			message["attachments"] = []interface{}{cards.Attachment(card)}
+			if params.ParentId != "" {
+				message["parentId"] = params.ParentId
+			}
+			return client.Post("/messages", message)
+		})
+}
+
+// ValidateCardParams defines the parameters for validating a card
+type ValidateCardParams struct {
+	CardParams
+}
+
+// NewValidateCardTool checks a card without sending it
+func NewValidateCardTool() tools.Tool {
+	return tools.NewGenericTool("validate_card",
+		"Check an Adaptive Card against the Adaptive Card "+cards.Version+" schema Webex supports without sending it. "+
+			"Accepts the same card, template and title/text/facts/inputs/actions fields as send_card and returns the "+
+			"finished card with a list of problems.",
+		tools.SimpleSchema("Validate an Adaptive Card.", cardProperties(), nil),
+		func(params *ValidateCardParams, client webex.HTTPClient) (interface{}, error) {
+			card, err := params.build()
+			var invalid *cards.ValidationError
+			if errors.As(err, &invalid) {
+				return map[string]interface{}{"valid": false, "problems": invalid.Problems}, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			return map[string]interface{}{"valid": true, "problems": []string{}, "card": card}, nil
+		})
+}
+
+// NewListCardTemplatesTool lists the card templates and their variables
+func NewListCardTemplatesTool() tools.Tool {
+	return tools.NewGenericTool("list_card_templates",
+		"List the Adaptive Card templates in the server's card template directory with the ${name} variables each one needs.",
+		tools.SimpleSchema("List card templates.", map[string]*jsonschema.Schema{}, nil),
+		func(params *struct{}, client webex.HTTPClient) (interface{}, error) {
+			cfg, err := config.Load()
+			if err != nil {
+				return nil, err
+			}
+			if cfg.Cards.Dir == "" {
+				return nil, fmt.Errorf("card templates are not configured: set cards.dir or WEBEX_CARDS_DIR")
+			}
+			templates, err := cards.ListTemplates(cfg.Cards.Dir)
+			if err != nil {
+				return nil, err
+			}
+			return map[string]interface{}{"templates": templates}, nil
+		})
+}
+
+// AwaitCardResponseParams defines the parameters for waiting for a card submission
+type AwaitCardResponseParams struct {
+	MessageId           string `json:"messageId"`
+	PersonId            string `json:"personId,omitempty"`
+	TimeoutSeconds      int    `json:"timeoutSeconds,omitempty"`
+	PollIntervalSeconds int    `json:"pollIntervalSeconds,omitempty"`
+}
+
+// awaitCardResult is a submission, or only a status when nobody answered in time
+type awaitCardResult struct {
+	Status string `json:"status"` // submitted or timeout
+	*cardresponse.Response
+}
+
+// NewAwaitCardResponseTool waits for someone to submit a card
+func NewAwaitCardResponseTool() tools.Tool {
+	return tools.NewContextTool("await_card_response",
+		"Wait until someone submits an Adaptive Card, such as one posted with send_card, and return the submitted inputs "+
+			"and who sent them. Submissions made since the card was posted count. Responses arrive through the server's "+
+			"webhook receiver when webhooks.path is configured; otherwise the Webex events API is polled, which needs a "+
+			"compliance officer's token. Progress is reported while waiting; on timeout the status is \"timeout\".",
+		tools.SimpleSchema("Wait for a card response.", map[string]*jsonschema.Schema{
+			"messageId":           tools.RequiredStringProperty("The message holding the card, by ID."),
+			"personId":            tools.StringProperty("Only accept a submission from this person, by ID."),
+			"timeoutSeconds":      tools.IntegerProperty(fmt.Sprintf("How long to wait (default %d, at most %d).", int(cardresponse.DefaultTimeout.Seconds()), int(cardresponse.MaxTimeout.Seconds()))),
+			"pollIntervalSeconds": tools.IntegerProperty(fmt.Sprintf("How often to poll the events API and report progress (default %d).", int(cardresponse.DefaultPollInterval.Seconds()))),
+		}, []string{"messageId"}),
+		func(ctx context.Context, params *AwaitCardResponseParams, client webex.HTTPClient) (interface{}, error) {
+			cfg, err := config.Load()
+			if err != nil {
+				return nil, err
+			}
+			opts := cardresponse.Options{
+				MessageID: params.MessageId,
+				Timeout:   time.Duration(params.TimeoutSeconds) * time.Second,
+				Hub:       cardresponse.HubFor(cfg),
+				Progress: func(waited, timeout time.Duration) {
+					tools.ReportProgress(ctx, waited.Seconds(), timeout.Seconds(), "Waiting for a response to the card")
+				},
+			}
+			if params.PollIntervalSeconds > 0 {
+				opts.PollInterval = max(time.Duration(params.PollIntervalSeconds)*time.Second, cardresponse.MinPollInterval)
+			}
+			if params.PersonId != "" {
+				opts.PersonIDs = []string{params.PersonId}
+			}
+
+			response, err := cardresponse.Await(ctx, client, opts)
+			if errors.Is(err, cardresponse.ErrTimeout) {
+				return awaitCardResult{Status: "timeout"}, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			return awaitCardResult{Status: "submitted", Response: response}, nil
+		})
+}
@@ -13,6 +13,9 @@ func LoadAdvancedPlugins(manager *tools.PluginManager) {
 	manager.RegisterPlugin(&advancedMembershipPlugin{})
 	manager.RegisterPlugin(&advancedTeamsPlugin{})
 	manager.RegisterPlugin(&advancedMiscPlugin{})
+	manager.RegisterPlugin(&advancedMessagingPlugin{})
+	manager.RegisterPlugin(&advancedCardsPlugin{})
+	manager.RegisterPlugin(&advancedBulkPlugin{})
 }
 
 // advancedRoomsPlugin provides advanced room management tools
@@ -144,3 +147,64 @@ func (p *advancedMiscPlugin) Register(registry *tools.Registry) error {
 	}
 	return nil
 }
+
+// advancedMessagingPlugin provides tools that work across many messages
+type advancedMessagingPlugin struct{}
+
+func (p *advancedMessagingPlugin) Name() string    { return "advanced-messaging" }
+func (p *advancedMessagingPlugin) Version() string { return "1.0.0" }
+
+func (p *advancedMessagingPlugin) Register(registry *tools.Registry) error {
+	toolList := []tools.Tool{
+		NewExportRoomTranscriptTool(),
+		NewGetThreadTool(),
+		NewListThreadsTool(),
+		NewSearchMessagesTool(),
+		NewGetNewMessagesSinceCheckpointTool(),
+		NewScheduleMessageTool(),
+		NewListScheduledMessagesTool(),
+		NewCancelScheduledMessageTool(),
+		NewListMessageTemplatesTool(),
+		NewRenderTemplateTool(),
+		NewSendTemplateMessageTool(),
+	}
+
+	for _, tool := range toolList {
+		if err := registry.Register(tool); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// advancedCardsPlugin provides Adaptive Card tools
+type advancedCardsPlugin struct{}
+
+func (p *advancedCardsPlugin) Name() string    { return "advanced-cards" }
+func (p *advancedCardsPlugin) Version() string { return "1.0.0" }
+
+func (p *advancedCardsPlugin) Register(registry *tools.Registry) error {
+	toolList := []tools.Tool{
+		NewSendCardTool(),
+		NewValidateCardTool(),
+		NewListCardTemplatesTool(),
+		NewAwaitCardResponseTool(),
+	}
+
+	for _, tool := range toolList {
+		if err := registry.Register(tool); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// advancedBulkPlugin provides tools that run many calls at once
+type advancedBulkPlugin struct{}
+
+func (p *advancedBulkPlugin) Name() string    { return "advanced-bulk" }
+func (p *advancedBulkPlugin) Version() string { return "1.0.0" }
+
+func (p *advancedBulkPlugin) Register(registry *tools.Registry) error {
+	return registry.Register(tools.NewBatchTool())
+}
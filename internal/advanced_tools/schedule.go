@@ -0,0 +1,179 @@
+package advanced_tools
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/raja-aiml/webex-mcp-server/internal/config"
+	"github.com/raja-aiml/webex-mcp-server/internal/scheduler"
+	"github.com/raja-aiml/webex-mcp-server/internal/tools"
+	"github.com/raja-aiml/webex-mcp-server/internal/webex"
+)
+
+// ScheduleMessageParams defines the parameters for scheduling a message
+type ScheduleMessageParams struct {
+	RoomId        string `json:"roomId,omitempty"`
+	ToPersonId    string `json:"toPersonId,omitempty"`
+	ToPersonEmail string `json:"toPersonEmail,omitempty"`
+	ParentId      string `json:"parentId,omitempty"`
+	Text          string `json:"text,omitempty"`
+	Markdown      string `json:"markdown,omitempty"`
+	At            string `json:"at,omitempty"`
+	Delay         string `json:"delay,omitempty"`
+	Cron          string `json:"cron,omitempty"`
+	Timezone      string `json:"timezone,omitempty"`
+	CatchUp       string `json:"catchUp,omitempty"`
+}
+
+// ListScheduledMessagesParams defines the parameters for listing scheduled messages
+type ListScheduledMessagesParams struct {
+	IncludeFinished bool `json:"includeFinished,omitempty"`
+}
+
+// CancelScheduledMessageParams defines the parameters for cancelling a scheduled message
+type CancelScheduledMessageParams struct {
+	JobId string `json:"jobId"`
+}
+
+// localLayouts are the accepted forms of a time without a UTC offset
+var localLayouts = []string{"2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02 15:04:05", "2006-01-02 15:04"}
+
+// scheduleStore returns the shared store of scheduled messages
+func scheduleStore(cfg *config.Config) (*scheduler.Store, error) {
+	if cfg.Scheduler.StoreFile == "" {
+		return nil, fmt.Errorf("scheduled messages are not configured: set scheduler.store_file or WEBEX_SCHEDULER_STORE_FILE")
+	}
+	location, err := cfg.Scheduler.Location()
+	if err != nil {
+		return nil, err
+	}
+	return scheduler.Shared(cfg.Scheduler.StoreFile, location, cfg.Scheduler.CatchUp)
+}
+
+// parseAt reads an RFC 3339 time, or a local time in the named zone
+func parseAt(value string, location *time.Location) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	for _, layout := range localLayouts {
+		if t, err := time.ParseInLocation(layout, value, location); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("at must be a time such as 2026-01-02T09:00 or 2026-01-02T09:00:00+01:00, got %q", value)
+}
+
+// NewScheduleMessageTool schedules a message for later, once or on a cron schedule
+func NewScheduleMessageTool() tools.Tool {
+	properties := map[string]*jsonschema.Schema{
+		"roomId":        tools.StringProperty("The room to post in, by ID."),
+		"toPersonId":    tools.StringProperty("The person to message directly, by ID."),
+		"toPersonEmail": tools.StringProperty("The person to message directly, by email address."),
+		"parentId":      tools.StringProperty("Post as a reply to this message."),
+		"text":          tools.StringProperty("The message, in plain text."),
+		"markdown":      tools.StringProperty("The message, in Markdown."),
+		"at":            tools.StringProperty("When to post, e.g. 2026-01-02T09:00 in the timezone, or with a UTC offset."),
+		"delay":         tools.StringProperty("Post after this long instead, e.g. 90m or 2h30m."),
+		"cron":          tools.StringProperty("Post repeatedly on this cron schedule (minute hour day-of-month month day-of-week), e.g. \"0 9 * * mon-fri\"."),
+		"timezone":      tools.StringProperty("IANA time zone for at and cron, e.g. Europe/Berlin. Defaults to the server's scheduler.timezone."),
+		"catchUp":       tools.EnumProperty("What to do with runs missed while the server was down: post once late (once) or drop them (skip). Defaults to scheduler.catch_up.", scheduler.CatchUpOnce, scheduler.CatchUpSkip),
+	}
+
+	return tools.NewContextTool("schedule_message",
+		"Schedule a message to be posted later: at a time, after a delay, or repeatedly on a cron schedule. "+
+			"Scheduled messages are saved on the server and survive restarts, and are posted as the profile that scheduled them. "+
+			"Returns the job with its ID and next run.",
+		tools.SimpleSchema("Schedule a message.", properties, nil),
+		func(ctx context.Context, params *ScheduleMessageParams, client webex.HTTPClient) (interface{}, error) {
+			cfg, err := config.Load()
+			if err != nil {
+				return nil, err
+			}
+			store, err := scheduleStore(cfg)
+			if err != nil {
+				return nil, err
+			}
+
+			spec := scheduler.Spec{
+				Message: scheduler.Message{
+					RoomID:        params.RoomId,
+					ToPersonID:    params.ToPersonId,
+					ToPersonEmail: params.ToPersonEmail,
+					ParentID:      params.ParentId,
+					Text:          params.Text,
+					Markdown:      params.Markdown,
+				},
+				Cron:     params.Cron,
+				Timezone: params.Timezone,
+				CatchUp:  params.CatchUp,
+				Profile:  tools.ProfileFromContext(ctx),
+			}
+			if params.At != "" && params.Delay != "" {
+				return nil, fmt.Errorf("give either at or delay, not both")
+			}
+			if params.At != "" {
+				location, err := cfg.Scheduler.Location()
+				if err != nil {
+					return nil, err
+				}
+				if params.Timezone != "" {
+					if location, err = time.LoadLocation(params.Timezone); err != nil {
+						return nil, fmt.Errorf("unknown timezone %q", params.Timezone)
+					}
+				}
+				if spec.At, err = parseAt(params.At, location); err != nil {
+					return nil, err
+				}
+			}
+			if params.Delay != "" {
+				delay, err := time.ParseDuration(params.Delay)
+				if err != nil || delay <= 0 {
+					return nil, fmt.Errorf("delay must be a positive duration such as 90m, got %q", params.Delay)
+				}
+				spec.At = time.Now().Add(delay)
+			}
+			return store.Add(spec)
+		})
+}
+
+// NewListScheduledMessagesTool lists scheduled messages
+func NewListScheduledMessagesTool() tools.Tool {
+	return tools.NewGenericTool("list_scheduled_messages",
+		"List scheduled messages by next run. Finished ones (sent, missed, failed or cancelled in the last week) are only included on request.",
+		tools.SimpleSchema("List scheduled messages.", map[string]*jsonschema.Schema{
+			"includeFinished": tools.BooleanProperty("Include messages that will not run again."),
+		}, nil),
+		func(params *ListScheduledMessagesParams, client webex.HTTPClient) (interface{}, error) {
+			cfg, err := config.Load()
+			if err != nil {
+				return nil, err
+			}
+			store, err := scheduleStore(cfg)
+			if err != nil {
+				return nil, err
+			}
+			return map[string]interface{}{"jobs": store.List(params.IncludeFinished)}, nil
+		})
+}
+
+// NewCancelScheduledMessageTool cancels a scheduled message
+func NewCancelScheduledMessageTool() tools.Tool {
+	return tools.NewGenericTool("cancel_scheduled_message",
+		"Cancel a scheduled message so it is not posted again.",
+		tools.SimpleSchema("Cancel a scheduled message.", map[string]*jsonschema.Schema{
+			"jobId": tools.RequiredStringProperty("The scheduled message, by the ID schedule_message returned."),
+		}, []string{"jobId"}),
+		func(params *CancelScheduledMessageParams, client webex.HTTPClient) (interface{}, error) {
+			cfg, err := config.Load()
+			if err != nil {
+				return nil, err
+			}
+			store, err := scheduleStore(cfg)
+			if err != nil {
+				return nil, err
+			}
+			return store.Cancel(params.JobId)
+		})
+}
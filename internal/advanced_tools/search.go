@@ -0,0 +1,75 @@
+package advanced_tools
+
+import (
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/raja-aiml/webex-mcp-server/internal/config"
+	"github.com/raja-aiml/webex-mcp-server/internal/search"
+	"github.com/raja-aiml/webex-mcp-server/internal/tools"
+	"github.com/raja-aiml/webex-mcp-server/internal/transcript"
+	"github.com/raja-aiml/webex-mcp-server/internal/webex"
+)
+
+// SearchMessagesParams defines the parameters for searching indexed messages
+type SearchMessagesParams struct {
+	Query  string `json:"query,omitempty"`
+	RoomId string `json:"roomId,omitempty"`
+	Sender string `json:"sender,omitempty"`
+	From   string `json:"from,omitempty"`
+	To     string `json:"to,omitempty"`
+	Max    int    `json:"max,omitempty"`
+}
+
+// NewSearchMessagesTool searches the local index of synced rooms
+func NewSearchMessagesTool() tools.Tool {
+	properties := map[string]*jsonschema.Schema{
+		"query":  tools.StringProperty(`Words that must all appear. End a word with * to match words it starts; put phrases in "double quotes". Leave empty to list the newest messages matching the filters.`),
+		"roomId": tools.StringProperty("Only messages in this room, by ID."),
+		"sender": tools.StringProperty("Only messages from this sender: part of their name or email, or their person ID."),
+		"from":   tools.StringProperty("Only messages sent at or after this time (RFC 3339 or YYYY-MM-DD)."),
+		"to":     tools.StringProperty("Only messages sent before this time (RFC 3339 or YYYY-MM-DD)."),
+		"max":    tools.IntegerProperty(fmt.Sprintf("Maximum number of results (default %d).", search.DefaultMaxResults)),
+	}
+
+	return tools.NewGenericTool("search_messages",
+		"Search message text in the rooms this server indexes locally, with sender, room and date filters. "+
+			"Returns the best matches with a snippet and a link that opens each message in Webex. "+
+			"Only rooms listed in the server's search configuration are searched, up to their last sync.",
+		tools.SimpleSchema("Search indexed messages.", properties, nil),
+		func(params *SearchMessagesParams, client webex.HTTPClient) (interface{}, error) {
+			cfg, err := config.Load()
+			if err != nil {
+				return nil, err
+			}
+			if cfg.Search.Dir == "" {
+				return nil, fmt.Errorf("message search is not configured: set search.dir and search.rooms, or WEBEX_SEARCH_DIR and WEBEX_SEARCH_ROOMS")
+			}
+			from, err := transcript.ParseTime(params.From)
+			if err != nil {
+				return nil, err
+			}
+			to, err := transcript.ParseTime(params.To)
+			if err != nil {
+				return nil, err
+			}
+
+			index, err := search.Shared(cfg.Search.Dir)
+			if err != nil {
+				return nil, err
+			}
+			results := index.Search(search.Query{
+				Text:   params.Query,
+				RoomID: params.RoomId,
+				Sender: params.Sender,
+				From:   from,
+				To:     to,
+				Max:    params.Max,
+			})
+			return map[string]interface{}{
+				"total":           results.Total,
+				"results":         results.Results,
+				"indexedMessages": index.Len(),
+			}, nil
+		})
+}
@@ -0,0 +1,71 @@
+package advanced_tools
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/raja-aiml/webex-mcp-server/internal/config"
+	"github.com/raja-aiml/webex-mcp-server/internal/roomsync"
+	"github.com/raja-aiml/webex-mcp-server/internal/tools"
+	"github.com/raja-aiml/webex-mcp-server/internal/webex"
+)
+
+// GetNewMessagesParams defines the parameters for reading new messages of synced rooms
+type GetNewMessagesParams struct {
+	RoomId  string `json:"roomId,omitempty"`
+	Peek    bool   `json:"peek,omitempty"`
+	SyncNow bool   `json:"syncNow,omitempty"`
+}
+
+// NewGetNewMessagesSinceCheckpointTool returns the messages posted in synced rooms since they were last read
+func NewGetNewMessagesSinceCheckpointTool() tools.Tool {
+	properties := map[string]*jsonschema.Schema{
+		"roomId":  tools.StringProperty("Only this synced room, by ID. Defaults to every synced room."),
+		"peek":    tools.BooleanProperty("Return the new messages without moving the checkpoint, so the next call returns them again."),
+		"syncNow": tools.BooleanProperty("Fetch new messages from Webex first instead of waiting for the next sync."),
+	}
+
+	return tools.NewGenericTool("get_new_messages_since_checkpoint",
+		"Get the messages posted in the server's synced rooms since the last call, oldest first, and move each room's "+
+			"checkpoint past them. Use it to catch up on what is new without re-reading whole rooms.",
+		tools.SimpleSchema("Get new messages since the checkpoint.", properties, nil),
+		func(params *GetNewMessagesParams, client webex.HTTPClient) (interface{}, error) {
+			cfg, err := config.Load()
+			if err != nil {
+				return nil, err
+			}
+			if cfg.Sync.StateFile == "" {
+				return nil, fmt.Errorf("room sync is not configured: set sync.state_file and sync.rooms, or WEBEX_SYNC_STATE_FILE and WEBEX_SYNC_ROOMS")
+			}
+			rooms := cfg.Sync.Rooms
+			if params.RoomId != "" {
+				if !slices.Contains(rooms, params.RoomId) {
+					return nil, fmt.Errorf("room %s is not synced; add it to sync.rooms", params.RoomId)
+				}
+				rooms = []string{params.RoomId}
+			}
+
+			engine, err := roomsync.Shared(cfg.Sync.StateFile)
+			if err != nil {
+				return nil, err
+			}
+			if params.SyncNow {
+				if _, err := engine.Cycle(client, rooms); err != nil {
+					return nil, err
+				}
+			}
+			updates, err := engine.Since(rooms, !params.Peek)
+			if err != nil {
+				return nil, err
+			}
+			total := 0
+			for _, update := range updates {
+				total += len(update.Messages)
+			}
+			return map[string]interface{}{
+				"newMessages": total,
+				"rooms":       updates,
+			}, nil
+		})
+}
@@ -0,0 +1,129 @@
+package advanced_tools
+
+import (
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/raja-aiml/webex-mcp-server/internal/cards"
+	"github.com/raja-aiml/webex-mcp-server/internal/config"
+	"github.com/raja-aiml/webex-mcp-server/internal/templates"
+	"github.com/raja-aiml/webex-mcp-server/internal/tools"
+	"github.com/raja-aiml/webex-mcp-server/internal/webex"
+)
+
+// RenderTemplateParams defines the parameters for rendering a message template
+type RenderTemplateParams struct {
+	Template string                 `json:"template"`
+	Data     map[string]interface{} `json:"data,omitempty"`
+}
+
+// SendTemplateMessageParams defines the parameters for sending a message template
+type SendTemplateMessageParams struct {
+	RoomId        string `json:"roomId,omitempty"`
+	ToPersonId    string `json:"toPersonId,omitempty"`
+	ToPersonEmail string `json:"toPersonEmail,omitempty"`
+	ParentId      string `json:"parentId,omitempty"`
+	RenderTemplateParams
+}
+
+// templatesDir returns the configured message template directory
+func templatesDir() (string, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return "", err
+	}
+	if cfg.Templates.Dir == "" {
+		return "", fmt.Errorf("message templates are not configured: set templates.dir or WEBEX_TEMPLATES_DIR")
+	}
+	return cfg.Templates.Dir, nil
+}
+
+// templateProperties are the schema of RenderTemplateParams
+func templateProperties() map[string]*jsonschema.Schema {
+	return map[string]*jsonschema.Schema{
+		"template": tools.RequiredStringProperty("Name of a message template; see list_message_templates."),
+		"data":     tools.ObjectProperty("Values for the template's variables.", map[string]*jsonschema.Schema{}),
+	}
+}
+
+// NewListMessageTemplatesTool lists the message templates and their variables
+func NewListMessageTemplatesTool() tools.Tool {
+	return tools.NewGenericTool("list_message_templates",
+		"List the message templates in the server's template directory with their description, whether they "+
+			"produce Markdown, a card or both, and the variables each one requires or accepts.",
+		tools.SimpleSchema("List message templates.", map[string]*jsonschema.Schema{}, nil),
+		func(params *struct{}, client webex.HTTPClient) (interface{}, error) {
+			dir, err := templatesDir()
+			if err != nil {
+				return nil, err
+			}
+			list, err := templates.List(dir)
+			if err != nil {
+				return nil, err
+			}
+			return map[string]interface{}{"templates": list}, nil
+		})
+}
+
+// NewRenderTemplateTool renders a message template without sending it
+func NewRenderTemplateTool() tools.Tool {
+	return tools.NewGenericTool("render_template",
+		"Render a message template with data without sending it, to preview the Markdown and card "+
+			"send_template_message would post. Every missing required variable is reported.",
+		tools.SimpleSchema("Render a message template.", templateProperties(), []string{"template"}),
+		func(params *RenderTemplateParams, client webex.HTTPClient) (interface{}, error) {
+			dir, err := templatesDir()
+			if err != nil {
+				return nil, err
+			}
+			return templates.Render(dir, params.Template, params.Data)
+		})
+}
+
+// NewSendTemplateMessageTool renders a message template and posts it
+func NewSendTemplateMessageTool() tools.Tool {
+	properties := templateProperties()
+	properties["roomId"] = tools.StringProperty("The room to post in, by ID.")
+	properties["toPersonId"] = tools.StringProperty("The person to message directly, by ID.")
+	properties["toPersonEmail"] = tools.StringProperty("The person to message directly, by email address.")
+	properties["parentId"] = tools.StringProperty("Post as a reply to this message.")
+
+	return tools.NewGenericTool("send_template_message",
+		"Render a message template with data and post it to a room or person. A template's Markdown becomes the "+
+			"message and its card is attached, with the Markdown as the text shown by clients that cannot render cards. "+
+			"Nothing is sent unless every required variable is given.",
+		tools.SimpleSchema("Send a message template. Specify either roomId, toPersonId, or toPersonEmail.", properties, []string{"template"}),
+		func(params *SendTemplateMessageParams, client webex.HTTPClient) (interface{}, error) {
+			message, err := recipient(params.RoomId, params.ToPersonId, params.ToPersonEmail)
+			if err != nil {
+				return nil, err
+			}
+			dir, err := templatesDir()
+			if err != nil {
+				return nil, err
+			}
+			rendered, err := templates.Render(dir, params.Template, params.Data)
+			if err != nil {
+				return nil, err
+			}
+
+			markdown := rendered.Markdown
+			if rendered.Card != nil {
+				if raw, ok := rendered.Card.(map[string]interface{}); ok && markdown == "" {
+					markdown, _ = raw["fallbackText"].(string)
+				}
+				if markdown == "" {
+					markdown = defaultCardFallback
+				}
+				message["attachments"] = []interface{}{cards.Attachment(rendered.Card)}
+			}
+			if markdown == "" {
+				return nil, fmt.Errorf("template %s rendered an empty message", params.Template)
+			}
+			message["markdown"] = markdown
+			if params.ParentId != "" {
+				message["parentId"] = params.ParentId
+			}
+			return client.Post("/messages", message)
+		})
+}
@@ -0,0 +1,72 @@
+package advanced_tools
+
+import (
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/raja-aiml/webex-mcp-server/internal/tools"
+	"github.com/raja-aiml/webex-mcp-server/internal/transcript"
+	"github.com/raja-aiml/webex-mcp-server/internal/webex"
+)
+
+// GetThreadParams defines the parameters for getting a thread
+type GetThreadParams struct {
+	MessageId string `json:"messageId" required:"true"`
+}
+
+// NewGetThreadTool returns a message's thread with its replies in order
+func NewGetThreadTool() tools.Tool {
+	properties := map[string]*jsonschema.Schema{
+		"messageId": tools.RequiredStringProperty("The parent message of the thread, or any reply in it."),
+	}
+
+	return tools.NewGenericTool("get_thread",
+		"Get a conversation thread: the parent message and all of its replies oldest first, with sender names, "+
+			"the reply count, the participants and the time of the last activity.",
+		tools.SimpleSchema("Get a message thread.", properties, []string{"messageId"}),
+		func(params *GetThreadParams, client webex.HTTPClient) (interface{}, error) {
+			return transcript.GetThread(client, params.MessageId)
+		})
+}
+
+// ListThreadsParams defines the parameters for listing the threads of a room
+type ListThreadsParams struct {
+	RoomId         string `json:"roomId" required:"true"`
+	Max            int    `json:"max,omitempty"`
+	From           string `json:"from,omitempty"`
+	To             string `json:"to,omitempty"`
+	IncludeReplies bool   `json:"includeReplies,omitempty"`
+}
+
+// NewListThreadsTool lists the threads of a room by most recent activity
+func NewListThreadsTool() tools.Tool {
+	properties := map[string]*jsonschema.Schema{
+		"roomId":         tools.RequiredStringProperty("The room to list threads in, by ID."),
+		"max":            tools.IntegerProperty(fmt.Sprintf("Maximum number of threads to return (default %d).", transcript.DefaultMaxThreads)),
+		"from":           tools.StringProperty("Only threads with a reply at or after this time (RFC 3339 or YYYY-MM-DD)."),
+		"to":             tools.StringProperty("Only consider replies sent before this time (RFC 3339 or YYYY-MM-DD)."),
+		"includeReplies": tools.BooleanProperty("Include each thread's replies, not only the counts."),
+	}
+
+	return tools.NewGenericTool("list_threads",
+		fmt.Sprintf("List the conversation threads in a room, most recently active first. Each thread has its parent message, "+
+			"reply count, participants and last activity time. Threads are found among the newest %d messages; "+
+			"use get_thread to read one in full.", transcript.DefaultScanMessages),
+		tools.SimpleSchema("List the threads in a room.", properties, []string{"roomId"}),
+		func(params *ListThreadsParams, client webex.HTTPClient) (interface{}, error) {
+			from, err := transcript.ParseTime(params.From)
+			if err != nil {
+				return nil, err
+			}
+			to, err := transcript.ParseTime(params.To)
+			if err != nil {
+				return nil, err
+			}
+			return transcript.ListThreads(client, params.RoomId, transcript.ThreadOptions{
+				From:           from,
+				To:             to,
+				MaxThreads:     params.Max,
+				IncludeReplies: params.IncludeReplies,
+			})
+		})
+}
@@ -0,0 +1,81 @@
+package advanced_tools
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/raja-aiml/webex-mcp-server/internal/config"
+	"github.com/raja-aiml/webex-mcp-server/internal/tools"
+	"github.com/raja-aiml/webex-mcp-server/internal/transcript"
+	"github.com/raja-aiml/webex-mcp-server/internal/webex"
+)
+
+// ExportRoomTranscriptParams defines the parameters for exporting a room transcript
+type ExportRoomTranscriptParams struct {
+	RoomId      string `json:"roomId" required:"true"`
+	Format      string `json:"format,omitempty"`
+	From        string `json:"from,omitempty"`
+	To          string `json:"to,omitempty"`
+	MaxMessages int    `json:"maxMessages,omitempty"`
+}
+
+// NewExportRoomTranscriptTool renders a room's history, writing it to the export directory when one is configured
+func NewExportRoomTranscriptTool() tools.Tool {
+	properties := map[string]*jsonschema.Schema{
+		"roomId":      tools.RequiredStringProperty("The room to export, by ID."),
+		"format":      tools.EnumProperty("Output format (default markdown).", transcript.Formats...),
+		"from":        tools.StringProperty("Only messages sent at or after this time (RFC 3339 or YYYY-MM-DD)."),
+		"to":          tools.StringProperty("Only messages sent before this time (RFC 3339 or YYYY-MM-DD)."),
+		"maxMessages": tools.IntegerProperty(fmt.Sprintf("Keep at most this many of the newest messages (default %d).", transcript.DefaultMaxMessages)),
+	}
+
+	return tools.NewGenericTool("export_room_transcript",
+		"Export the message history of a room, with thread replies under their parents and sender names resolved, "+
+			"as Markdown, JSON Lines or HTML. Writes a file to the server's export directory when one is configured, "+
+			"otherwise returns the transcript.",
+		tools.SimpleSchema("Export a room transcript.", properties, []string{"roomId"}),
+		func(params *ExportRoomTranscriptParams, client webex.HTTPClient) (interface{}, error) {
+			format := strings.ToLower(params.Format)
+			if format == "" {
+				format = transcript.FormatMarkdown
+			}
+			if !slices.Contains(transcript.Formats, format) {
+				return nil, fmt.Errorf("format must be one of %s", strings.Join(transcript.Formats, ", "))
+			}
+			from, err := transcript.ParseTime(params.From)
+			if err != nil {
+				return nil, err
+			}
+			to, err := transcript.ParseTime(params.To)
+			if err != nil {
+				return nil, err
+			}
+
+			t, err := transcript.Fetch(client, params.RoomId, transcript.Options{From: from, To: to, MaxMessages: params.MaxMessages})
+			if err != nil {
+				return nil, err
+			}
+
+			cfg, err := config.Load()
+			if err != nil || cfg.Export.Dir == "" {
+				var b strings.Builder
+				if err := transcript.Render(&b, t, format); err != nil {
+					return nil, err
+				}
+				return b.String(), nil
+			}
+			path, err := transcript.WriteFile(cfg.Export.Dir, t, format, time.Now())
+			if err != nil {
+				return nil, err
+			}
+			return map[string]interface{}{
+				"path":      path,
+				"format":    format,
+				"messages":  t.Count,
+				"truncated": t.Truncated,
+			}, nil
+		})
+}
@@ -8,17 +8,57 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/raja-aiml/webex-mcp-server/internal/config"
+	"github.com/raja-aiml/webex-mcp-server/internal/roomsync"
+	"github.com/raja-aiml/webex-mcp-server/internal/scheduler"
+	"github.com/raja-aiml/webex-mcp-server/internal/search"
 	"github.com/raja-aiml/webex-mcp-server/internal/server"
+	"github.com/raja-aiml/webex-mcp-server/internal/tools"
+	"github.com/raja-aiml/webex-mcp-server/internal/webex"
+	"github.com/raja-aiml/webex-mcp-server/internal/webhooks"
 )
 
 // Config holds the application configuration
+// Apart from Name and Version these are command-line settings; zero values defer to
+// the environment, the config file and the defaults, in that order.
 type Config struct {
 	Name        string
 	Version     string
 	HTTPAddr    string
 	EnvPath     string
+	ConfigFile  string
 	UseAllTools bool
 	SSEMode     bool // Preserve SSE support
+	LogLevel    string
+	Profile     string // Default Webex profile
+}
+
+// ConfigOptions returns the options for loading the shared configuration with these settings applied last
+func (c Config) ConfigOptions() config.Options {
+	return config.Options{
+		File:      c.ConfigFile,
+		Overrides: c.applyOverrides,
+	}
+}
+
+// applyOverrides overlays command-line settings, which take precedence over every other source
+func (c Config) applyOverrides(cfg *config.Config) {
+	if c.HTTPAddr != "" {
+		cfg.Server.Mode = config.ModeHTTP
+		cfg.Server.Addr = c.HTTPAddr
+	}
+	if c.SSEMode {
+		cfg.Server.Mode = config.ModeSSE
+	}
+	if c.UseAllTools {
+		cfg.Tools.All = true
+	}
+	if c.LogLevel != "" {
+		cfg.Logging.Level = c.LogLevel
+	}
+	if c.Profile != "" {
+		cfg.DefaultProfile = c.Profile
+	}
 }
 
 // App represents the main application
@@ -41,46 +81,167 @@ func New(cfg Config) *App {
 // Run starts the application
 func (a *App) Run() error {
 	// Initialize configuration
+	config.SetOptions(a.config.ConfigOptions())
 	if err := server.InitializeConfig(a.config.EnvPath); err != nil {
 		return err
 	}
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	configureLogging(cfg.Logging)
 
 	// Create MCP server
-	mcpServer, err := server.CreateMCPServerWithMode(a.config.Name, a.config.Version, a.config.UseAllTools)
+	mcpServer, toolSet, err := server.CreateMCPServerFromConfig(a.config.Name, a.config.Version, cfg)
 	if err != nil {
 		return err
 	}
 
+	if cfg.Search.Dir != "" {
+		if err := a.startSearchSync(cfg); err != nil {
+			return err
+		}
+	}
+	if cfg.Sync.StateFile != "" {
+		if err := a.startRoomSync(cfg); err != nil {
+			return err
+		}
+	}
+	if cfg.Scheduler.StoreFile != "" {
+		if err := a.startScheduler(cfg); err != nil {
+			return err
+		}
+	}
+	if cfg.Webhooks.TargetURL != "" {
+		go a.registerCardWebhook(cfg)
+	}
+
 	// Setup signal handling
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 
+	// SIGHUP and, when enabled, config file changes trigger a reload
+	reloadChan := make(chan struct{}, 1)
+	hupChan := make(chan os.Signal, 1)
+	signal.Notify(hupChan, syscall.SIGHUP)
+	defer signal.Stop(hupChan)
+	if cfg.Reload.Watch {
+		paths := []string{server.EnvFilePath(a.config.EnvPath)}
+		if cfg.Source != "" {
+			paths = append(paths, cfg.Source)
+		}
+		go watchFiles(a.ctx, paths, cfg.Reload.Interval, reloadChan)
+	}
+
 	// Start server
 	errChan := make(chan error, 1)
 	go func() {
-		if a.config.HTTPAddr != "" || a.config.SSEMode {
-			addr := a.config.HTTPAddr
-			if addr == "" && a.config.SSEMode {
-				addr = ":3001"
-			}
-			if a.config.SSEMode {
-				errChan <- server.RunSSEServer(a.ctx, addr, mcpServer, a.config.Name, a.config.Version)
-			} else {
-				errChan <- server.RunHTTPServer(a.ctx, addr, mcpServer, a.config.Name, a.config.Version)
-			}
-		} else {
+		switch cfg.Server.Mode {
+		case config.ModeSSE, config.ModeHTTP:
+			// Both transports are served together; see handlers.SetupHTTPHandlers
+			errChan <- server.RunHTTPServerWithConfig(a.ctx, cfg.Server, mcpServer, server.NewReadiness(toolSet).Check, a.config.Name, a.config.Version)
+		default:
 			errChan <- server.RunStdioServer(a.ctx, mcpServer, a.config.Name, a.config.Version)
 		}
 	}()
 
 	// Wait for signal or error
-	select {
-	case sig := <-sigChan:
-		log.Printf("Received signal: %v", sig)
-		return a.Shutdown()
-	case err := <-errChan:
+	for {
+		select {
+		case sig := <-sigChan:
+			log.Printf("Received signal: %v", sig)
+			return a.Shutdown()
+		case <-hupChan:
+			log.Println("Received SIGHUP, reloading configuration")
+			cfg = a.reloadOrLog(cfg, toolSet)
+		case <-reloadChan:
+			log.Println("Configuration files changed, reloading")
+			cfg = a.reloadOrLog(cfg, toolSet)
+		case err := <-errChan:
+			return err
+		}
+	}
+}
+
+// startSearchSync keeps the local search index up to date in the background.
+// Changes to the search settings take effect after a restart.
+func (a *App) startSearchSync(cfg *config.Config) error {
+	index, err := search.Shared(cfg.Search.Dir)
+	if err != nil {
+		return err
+	}
+	client, err := webex.NewClientWithConfig(cfg)
+	if err != nil {
+		return err
+	}
+	syncer := &search.Syncer{Index: index, Client: client, Rooms: cfg.Search.Rooms}
+	go syncer.Run(a.ctx, cfg.Search.Interval)
+	log.Printf("Indexing %d rooms for search every %s", len(cfg.Search.Rooms), cfg.Search.Interval)
+	return nil
+}
+
+// startRoomSync polls the synced rooms for new messages in the background.
+// Changes to the sync settings take effect after a restart.
+func (a *App) startRoomSync(cfg *config.Config) error {
+	engine, err := roomsync.Shared(cfg.Sync.StateFile)
+	if err != nil {
 		return err
 	}
+	client, err := webex.NewClientWithConfig(cfg)
+	if err != nil {
+		return err
+	}
+	go engine.Run(a.ctx, client, cfg.Sync.Rooms, cfg.Sync.Interval)
+	log.Printf("Syncing %d rooms every %s", len(cfg.Sync.Rooms), cfg.Sync.Interval)
+	return nil
+}
+
+// startScheduler posts scheduled messages in the background, catching up on
+// runs missed while the server was down. A changed store file takes effect after a restart.
+func (a *App) startScheduler(cfg *config.Config) error {
+	location, err := cfg.Scheduler.Location()
+	if err != nil {
+		return err
+	}
+	store, err := scheduler.Shared(cfg.Scheduler.StoreFile, location, cfg.Scheduler.CatchUp)
+	if err != nil {
+		return err
+	}
+	// Clients are looked up on every run, so jobs post as their own profile with its current token
+	go store.Run(a.ctx, tools.ClientFor)
+	log.Printf("Posting scheduled messages from %s", cfg.Scheduler.StoreFile)
+	return nil
+}
+
+// registerCardWebhook points card submissions at the webhook receiver, logging
+// failures since await_card_response can still be answered by polling
+func (a *App) registerCardWebhook(cfg *config.Config) {
+	client, err := webex.NewClientWithConfig(cfg)
+	if err == nil {
+		var created bool
+		_, created, err = webhooks.Ensure(client, webhooks.Spec{
+			Name:      a.config.Name + " card responses",
+			TargetURL: cfg.Webhooks.TargetURL,
+			Resource:  "attachmentActions",
+			Event:     "created",
+			Secret:    cfg.Webhooks.Secret,
+		})
+		if err == nil && created {
+			log.Printf("Registered the card response webhook for %s", cfg.Webhooks.TargetURL)
+		}
+	}
+	if err != nil {
+		log.Printf("Failed to register the card response webhook: %v", err)
+	}
+}
+
+// reloadOrLog reloads and logs failures, returning the configuration now in effect
+func (a *App) reloadOrLog(current *config.Config, toolSet *server.ToolSet) *config.Config {
+	cfg, err := a.reload(current, toolSet)
+	if err != nil {
+		log.Printf("Reload failed, keeping previous configuration: %v", err)
+	}
+	return cfg
 }
 
 // Shutdown gracefully shuts down the application
@@ -4,6 +4,8 @@ import (
 	"os"
 	"testing"
 	"time"
+
+	"github.com/raja-aiml/webex-mcp-server/internal/config"
 )
 
 func TestNew(t *testing.T) {
@@ -116,3 +118,58 @@ func TestApp_ContextCancellation(t *testing.T) {
 		t.Error("Context should be done after cancel")
 	}
 }
+
+func TestConfig_ConfigOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		flags    Config
+		wantMode string
+		wantAddr string
+		wantAll  bool
+		wantProf string
+	}{
+		{
+			name:     "no flags keeps loaded values",
+			flags:    Config{},
+			wantMode: config.ModeHTTP,
+			wantAddr: ":9000",
+		},
+		{
+			name:     "http flag sets mode and address",
+			flags:    Config{HTTPAddr: ":8080"},
+			wantMode: config.ModeHTTP,
+			wantAddr: ":8080",
+		},
+		{
+			name:     "sse flag switches mode",
+			flags:    Config{SSEMode: true, UseAllTools: true},
+			wantMode: config.ModeSSE,
+			wantAddr: ":9000",
+			wantAll:  true,
+		},
+		{
+			name:     "profile flag sets the default profile",
+			flags:    Config{Profile: "admin"},
+			wantMode: config.ModeHTTP,
+			wantAddr: ":9000",
+			wantProf: "admin",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{Server: config.ServerConfig{Mode: config.ModeHTTP, Addr: ":9000"}}
+			tt.flags.ConfigOptions().Overrides(cfg)
+
+			if cfg.Server.Mode != tt.wantMode || cfg.Server.Addr != tt.wantAddr {
+				t.Errorf("server = %+v, want mode %s addr %s", cfg.Server, tt.wantMode, tt.wantAddr)
+			}
+			if cfg.Tools.All != tt.wantAll {
+				t.Errorf("Tools.All = %v, want %v", cfg.Tools.All, tt.wantAll)
+			}
+			if cfg.DefaultProfile != tt.wantProf {
+				t.Errorf("DefaultProfile = %q, want %q", cfg.DefaultProfile, tt.wantProf)
+			}
+		})
+	}
+}
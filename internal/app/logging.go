@@ -0,0 +1,37 @@
+package app
+
+import (
+	"log"
+	"log/slog"
+	"os"
+
+	"github.com/raja-aiml/webex-mcp-server/internal/config"
+	"github.com/raja-aiml/webex-mcp-server/internal/secrets"
+)
+
+// logOutput masks registered secrets such as the Webex token in every log line
+var logOutput = secrets.NewRedactingWriter(os.Stderr)
+
+// configureLogging routes the standard logger through slog when a level other than
+// info or JSON output is configured. The default keeps the plain log format, and
+// slog is reset to info-level text so a reload back to the default takes effect.
+func configureLogging(cfg config.LoggingConfig) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(cfg.Level)); err != nil {
+		level = slog.LevelInfo
+	}
+	opts := &slog.HandlerOptions{Level: level}
+
+	var handler slog.Handler
+	if cfg.Format == "json" {
+		handler = slog.NewJSONHandler(logOutput, opts)
+	} else {
+		handler = slog.NewTextHandler(logOutput, opts)
+	}
+	// SetDefault also sends the standard logger through the handler
+	slog.SetDefault(slog.New(handler))
+	if cfg.Level == "info" && cfg.Format == "text" {
+		log.SetOutput(logOutput)
+		log.SetFlags(log.LstdFlags)
+	}
+}
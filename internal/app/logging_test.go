@@ -0,0 +1,52 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/raja-aiml/webex-mcp-server/internal/config"
+	"github.com/raja-aiml/webex-mcp-server/internal/secrets"
+)
+
+func TestConfigureLogging(t *testing.T) {
+	var out bytes.Buffer
+	output, logger := logOutput, slog.Default()
+	logOutput = secrets.NewRedactingWriter(&out)
+	defer func() {
+		logOutput = output
+		slog.SetDefault(logger)
+		log.SetOutput(output)
+		log.SetFlags(log.LstdFlags)
+	}()
+
+	tests := []struct {
+		name      string
+		cfg       config.LoggingConfig
+		wantDebug bool
+		wantLine  string
+	}{
+		{name: "json at debug", cfg: config.LoggingConfig{Level: "debug", Format: "json"}, wantDebug: true, wantLine: `"msg":"hello"`},
+		{name: "back to the default", cfg: config.LoggingConfig{Level: "info", Format: "text"}, wantLine: " hello\n"},
+		{name: "text at debug", cfg: config.LoggingConfig{Level: "debug", Format: "text"}, wantDebug: true, wantLine: "level=INFO msg=hello"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out.Reset()
+			configureLogging(tt.cfg)
+			if got := slog.Default().Enabled(context.Background(), slog.LevelDebug); got != tt.wantDebug {
+				t.Errorf("debug enabled = %v, want %v", got, tt.wantDebug)
+			}
+			log.Print("hello")
+			if !strings.Contains(out.String(), tt.wantLine) {
+				t.Errorf("log line = %q, want %q", out.String(), tt.wantLine)
+			}
+			if tt.cfg.Format == "text" && tt.cfg.Level == "info" && strings.Contains(out.String(), "level=") {
+				t.Errorf("log line = %q, want the plain format", out.String())
+			}
+		})
+	}
+}
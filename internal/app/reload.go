@@ -0,0 +1,97 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/raja-aiml/webex-mcp-server/internal/config"
+	"github.com/raja-aiml/webex-mcp-server/internal/server"
+	"github.com/raja-aiml/webex-mcp-server/internal/tools"
+)
+
+// reload re-reads the .env file and configuration, then applies the resulting tool
+// set to the running server. On error the running configuration is kept, along
+// with the environment it was built from.
+func (a *App) reload(current *config.Config, toolSet *server.ToolSet) (*config.Config, error) {
+	restoreEnv, err := server.ReloadEnvFile(a.config.EnvPath)
+	if err != nil {
+		return current, err
+	}
+
+	cfg, err := config.Reload()
+	if err != nil {
+		restoreEnv()
+		return current, fmt.Errorf("configuration error: %w", err)
+	}
+
+	// Tools read the shared configuration when they are created, so the new
+	// configuration is installed first and rolled back if the tools cannot load
+	registry, err := tools.LoadConfiguredTools(cfg)
+	if err != nil {
+		config.Replace(current)
+		restoreEnv()
+		return current, fmt.Errorf("failed to load tools: %w", err)
+	}
+
+	// The shared default client must be rebuilt from the new configuration as well
+	tools.ResetDefaultClient()
+	result := toolSet.Apply(registry)
+	toolSet.SetLimits(cfg.Limits)
+	configureLogging(cfg.Logging)
+
+	if cfg.Server != current.Server {
+		log.Printf("Warning: server listener changes (%s %s) take effect after a restart; rotated TLS certificates are picked up automatically", cfg.Server.Mode, cfg.Server.Addr)
+	}
+	log.Printf("Configuration reloaded: %d tools (added %v, removed %v, updated %v)",
+		len(toolSet.Names()), result.Added, result.Removed, result.Updated)
+	return cfg, nil
+}
+
+// watchFiles polls paths and sends on changed whenever one of them is modified,
+// created or removed. It returns when ctx is cancelled.
+func watchFiles(ctx context.Context, paths []string, interval time.Duration, changed chan<- struct{}) {
+	snapshot := func() map[string]string {
+		state := make(map[string]string, len(paths))
+		for _, path := range paths {
+			if info, err := os.Stat(path); err == nil {
+				state[path] = fmt.Sprintf("%d/%d", info.ModTime().UnixNano(), info.Size())
+			}
+		}
+		return state
+	}
+
+	last := snapshot()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			next := snapshot()
+			if !sameSnapshot(last, next) {
+				last = next
+				select {
+				case changed <- struct{}{}:
+				default: // A reload is already pending
+				}
+			}
+		}
+	}
+}
+
+func sameSnapshot(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for path, state := range a {
+		if b[path] != state {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,107 @@
+package app
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/raja-aiml/webex-mcp-server/internal/config"
+	"github.com/raja-aiml/webex-mcp-server/internal/server"
+	"github.com/raja-aiml/webex-mcp-server/internal/testutil"
+)
+
+func TestWatchFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("a: 1\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changed := make(chan struct{}, 1)
+	go watchFiles(ctx, []string{path}, 10*time.Millisecond, changed)
+
+	select {
+	case <-changed:
+		t.Fatal("watchFiles() reported a change before the file was modified")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	if err := os.WriteFile(path, []byte("a: 22\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-changed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("watchFiles() did not report the modification")
+	}
+}
+
+func TestApp_Reload(t *testing.T) {
+	cleanup := testutil.SetEnv(t, "WEBEX_PUBLIC_WORKSPACE_API_KEY", "")
+	defer func() {
+		cleanup()
+		config.ResetForTesting()
+	}()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	write := func(content string) {
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("auth:\n  token: first\ntools:\n  include: [list_rooms]\n")
+
+	a := New(Config{Name: "test-app", Version: "1.0.0", ConfigFile: path, EnvPath: filepath.Join(dir, ".env")})
+	config.SetOptions(a.config.ConfigOptions())
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	_, toolSet, err := server.CreateMCPServerFromConfig("test-app", "1.0.0", cfg)
+	if err != nil {
+		t.Fatalf("CreateMCPServerFromConfig() error = %v", err)
+	}
+
+	write("auth:\n  token: second\ntools:\n  include: [list_rooms, list_messages]\n")
+	cfg, err = a.reload(cfg, toolSet)
+	if err != nil {
+		t.Fatalf("reload() error = %v", err)
+	}
+	if cfg.WebexAPIKey != "second" {
+		t.Errorf("token after reload = %q, want second", cfg.WebexAPIKey)
+	}
+	if got := strings.Join(toolSet.Names(), ","); got != "list_messages,list_rooms" {
+		t.Errorf("tools after reload = %s", got)
+	}
+
+	// A broken file keeps the previous configuration and tools
+	write("tools:\n  include: [no_such_tool]\nauth:\n  token: third\n")
+	kept, err := a.reload(cfg, toolSet)
+	if err == nil {
+		t.Fatal("reload() expected error for unknown tool")
+	}
+	if kept != cfg || strings.Join(toolSet.Names(), ",") != "list_messages,list_rooms" {
+		t.Errorf("failed reload changed state: token %q tools %v", kept.WebexAPIKey, toolSet.Names())
+	}
+	if token, _ := config.GetWebexToken(); token != "second" {
+		t.Errorf("shared configuration token after failed reload = %q, want second", token)
+	}
+
+	// A .env file that makes the configuration invalid leaves the environment as it was
+	write("auth:\n  token: second\ntools:\n  include: [list_rooms, list_messages]\n")
+	if err := os.WriteFile(filepath.Join(dir, ".env"), []byte("WEBEX_WEBHOOK_PATH=/webhooks\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Unsetenv("WEBEX_WEBHOOK_PATH")
+	if _, err := a.reload(cfg, toolSet); err == nil || !strings.Contains(err.Error(), "webhooks.secret is required") {
+		t.Fatalf("reload() error = %v, want the missing webhook secret", err)
+	}
+	if path, exists := os.LookupEnv("WEBEX_WEBHOOK_PATH"); exists {
+		t.Errorf("WEBEX_WEBHOOK_PATH = %q after a failed reload, want it unset", path)
+	}
+}
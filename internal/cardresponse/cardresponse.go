@@ -0,0 +1,300 @@
+// Package cardresponse waits for someone to submit an Adaptive Card. Submissions
+// come from the webhook receiver when the server runs one, and otherwise from
+// polling the Webex events API.
+package cardresponse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/raja-aiml/webex-mcp-server/internal/config"
+	"github.com/raja-aiml/webex-mcp-server/internal/webex"
+	"github.com/raja-aiml/webex-mcp-server/internal/webhooks"
+)
+
+const (
+	// DefaultTimeout is how long Await waits when Options.Timeout is not set
+	DefaultTimeout = 5 * time.Minute
+	// MaxTimeout bounds Options.Timeout
+	MaxTimeout = 15 * time.Minute
+	// DefaultPollInterval is how often the events API is read, and progress reported
+	DefaultPollInterval = 5 * time.Second
+	// MinPollInterval is the shortest interval worth asking the events API for
+	MinPollInterval = time.Second
+)
+
+// Sources of a Response
+const (
+	SourceWebhook = "webhook"
+	SourcePoll    = "poll"
+)
+
+// ErrTimeout is returned when nobody submits the card in time
+var ErrTimeout = errors.New("no response to the card before the timeout")
+
+// Response is a submission of a card
+type Response struct {
+	ActionID    string                 `json:"actionId"`
+	MessageID   string                 `json:"messageId"`
+	RoomID      string                 `json:"roomId,omitempty"`
+	PersonID    string                 `json:"personId"`
+	PersonEmail string                 `json:"personEmail,omitempty"`
+	PersonName  string                 `json:"personName,omitempty"`
+	Created     time.Time              `json:"created"`
+	Inputs      map[string]interface{} `json:"inputs"`
+	Source      string                 `json:"source"` // SourceWebhook or SourcePoll
+}
+
+// Options select the submission to wait for and how to wait
+type Options struct {
+	MessageID    string
+	PersonIDs    []string                            // Only submissions by these people count; anyone's when empty
+	Timeout      time.Duration                       // DefaultTimeout when 0, at most MaxTimeout
+	PollInterval time.Duration                       // DefaultPollInterval when 0
+	Hub          *webhooks.Hub                       // Wait for webhook notifications instead of polling when set
+	Progress     func(waited, timeout time.Duration) // Called every poll interval while waiting
+}
+
+// HubFor returns the hub to wait on under cfg: the shared one when the server
+// receives webhooks, or nil to poll the events API
+func HubFor(cfg *config.Config) *webhooks.Hub {
+	// The receiver only runs on the HTTP listener
+	if cfg.Webhooks.Path != "" && cfg.Server.Mode != config.ModeStdio {
+		return webhooks.Default
+	}
+	return nil
+}
+
+// Await blocks until a matching submission of the card in opts.MessageID
+// arrives, the timeout passes (ErrTimeout) or ctx is done. Submissions made
+// before the call, since the card was posted, count too.
+func Await(ctx context.Context, client webex.HTTPClient, opts Options) (*Response, error) {
+	if opts.MessageID == "" {
+		return nil, fmt.Errorf("messageId is required")
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	opts.Timeout = min(opts.Timeout, MaxTimeout)
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = DefaultPollInterval
+	}
+
+	message, err := client.Get("/messages/"+opts.MessageID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the card message: %w", err)
+	}
+	if attachments, _ := message["attachments"].([]interface{}); len(attachments) == 0 {
+		return nil, fmt.Errorf("message %s has no card", opts.MessageID)
+	}
+	created, _ := message["created"].(string)
+	since, err := time.Parse(time.RFC3339Nano, created)
+	if err != nil {
+		return nil, fmt.Errorf("message %s has no creation time", opts.MessageID)
+	}
+
+	ctx, cancel := context.WithTimeoutCause(ctx, opts.Timeout, ErrTimeout)
+	defer cancel()
+	w := &waiter{client: client, opts: opts, start: time.Now(), rejected: map[string]bool{}}
+	if opts.Hub != nil {
+		return w.webhook(ctx)
+	}
+	return w.poll(ctx, since)
+}
+
+type waiter struct {
+	client   webex.HTTPClient
+	opts     Options
+	start    time.Time
+	rejected map[string]bool // Actions fetched and found not to be awaited
+}
+
+// matches reports whether a submission of messageID by personID is awaited
+func (w *waiter) matches(messageID, personID string) bool {
+	return messageID == w.opts.MessageID && (len(w.opts.PersonIDs) == 0 || slices.Contains(w.opts.PersonIDs, personID))
+}
+
+func (w *waiter) progress() {
+	if w.opts.Progress != nil {
+		w.opts.Progress(time.Since(w.start), w.opts.Timeout)
+	}
+}
+
+// accept fetches the action a notification or event announced and returns it
+// when it is awaited. Only the fetched action is trusted, so a forged or
+// mistaken announcement is remembered and skipped.
+func (w *waiter) accept(actionID, source string) (*Response, error) {
+	if w.rejected[actionID] {
+		return nil, nil
+	}
+	r, err := fetch(w.client, actionID, source)
+	var apiErr *webex.APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		log.Printf("[Cards] Ignoring announced card response %s, which does not exist", actionID)
+		w.rejected[actionID] = true
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !w.matches(r.MessageID, r.PersonID) {
+		log.Printf("[Cards] Ignoring card response %s, which is not an awaited submission of %s", actionID, w.opts.MessageID)
+		w.rejected[actionID] = true
+		return nil, nil
+	}
+	return r, nil
+}
+
+// webhook waits for an attachmentActions notification, checking those received
+// before it started first
+func (w *waiter) webhook(ctx context.Context) (*Response, error) {
+	recent, notifications, unsubscribe := w.opts.Hub.Subscribe()
+	defer unsubscribe()
+	for _, n := range recent {
+		if r, err := w.notified(n); r != nil || err != nil {
+			return r, err
+		}
+	}
+
+	ticker := time.NewTicker(w.opts.PollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case n := <-notifications:
+			if r, err := w.notified(n); r != nil || err != nil {
+				return r, err
+			}
+		case <-ticker.C:
+			w.progress()
+		case <-ctx.Done():
+			return nil, context.Cause(ctx)
+		}
+	}
+}
+
+// notified returns the submission a notification announces when it is awaited
+func (w *waiter) notified(n webhooks.Notification) (*Response, error) {
+	id := w.action(n)
+	if id == "" {
+		return nil, nil
+	}
+	return w.accept(id, SourceWebhook)
+}
+
+// action returns the ID of the attachment action a notification announces when it is awaited
+func (w *waiter) action(n webhooks.Notification) string {
+	if n.Resource != "attachmentActions" || n.Event != "created" {
+		return ""
+	}
+	messageID, _ := n.Data["messageId"].(string)
+	personID, _ := n.Data["personId"].(string)
+	if !w.matches(messageID, personID) {
+		return ""
+	}
+	id, _ := n.Data["id"].(string)
+	return id
+}
+
+// poll reads attachmentActions events created since the card was posted until one matches
+func (w *waiter) poll(ctx context.Context, since time.Time) (*Response, error) {
+	from := since
+	for {
+		id, newest, err := w.events(from)
+		if err != nil {
+			return nil, err
+		}
+		if id != "" {
+			r, err := w.accept(id, SourcePoll)
+			if r != nil || err != nil {
+				return r, err
+			}
+			// Look again at once for the next candidate
+			continue
+		}
+		// Events at the newest instant are read again, which is harmless
+		from = newest
+
+		w.progress()
+		select {
+		case <-time.After(w.opts.PollInterval):
+		case <-ctx.Done():
+			return nil, context.Cause(ctx)
+		}
+	}
+}
+
+// events returns the earliest awaited submission created from from, and the newest event time seen
+func (w *waiter) events(from time.Time) (string, time.Time, error) {
+	list, err := w.client.Get("/events", map[string]string{
+		"resource": "attachmentActions",
+		"type":     "created",
+		"from":     from.UTC().Format(time.RFC3339Nano),
+		"max":      "1000",
+	})
+	var apiErr *webex.APIError
+	if errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusForbidden || apiErr.StatusCode == http.StatusUnauthorized) {
+		return "", from, fmt.Errorf("polling for card responses reads the Webex events API, which needs a compliance officer's token; "+
+			"configure webhooks.path and webhooks.target_url to receive responses by webhook instead: %w", err)
+	}
+	if err != nil {
+		return "", from, fmt.Errorf("failed to list events: %w", err)
+	}
+
+	var found string
+	var foundAt time.Time
+	newest := from
+	items, _ := list["items"].([]interface{})
+	for _, item := range items {
+		event, _ := item.(map[string]interface{})
+		created, _ := event["created"].(string)
+		at, err := time.Parse(time.RFC3339Nano, created)
+		if err != nil {
+			continue
+		}
+		if at.After(newest) {
+			newest = at
+		}
+		data, _ := event["data"].(map[string]interface{})
+		messageID, _ := data["messageId"].(string)
+		personID, _ := data["personId"].(string)
+		if personID == "" {
+			personID, _ = event["actorId"].(string)
+		}
+		id, _ := data["id"].(string)
+		if id != "" && !w.rejected[id] && w.matches(messageID, personID) && (found == "" || at.Before(foundAt)) {
+			found, foundAt = id, at
+		}
+	}
+	return found, newest, nil
+}
+
+// fetch reads a submission and who made it
+func fetch(client webex.HTTPClient, actionID, source string) (*Response, error) {
+	action, err := client.Get("/attachment/actions/"+actionID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the card response: %w", err)
+	}
+	r := &Response{ActionID: actionID, Source: source, Inputs: map[string]interface{}{}}
+	r.MessageID, _ = action["messageId"].(string)
+	r.RoomID, _ = action["roomId"].(string)
+	r.PersonID, _ = action["personId"].(string)
+	if inputs, ok := action["inputs"].(map[string]interface{}); ok {
+		r.Inputs = inputs
+	}
+	created, _ := action["created"].(string)
+	r.Created, _ = time.Parse(time.RFC3339Nano, created)
+
+	// Who responded is a nicety; the response stands without it
+	if person, err := client.Get("/people/"+r.PersonID, nil); err == nil {
+		r.PersonName, _ = person["displayName"].(string)
+		if emails, _ := person["emails"].([]interface{}); len(emails) > 0 {
+			r.PersonEmail, _ = emails[0].(string)
+		}
+	}
+	return r, nil
+}
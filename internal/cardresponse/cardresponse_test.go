@@ -0,0 +1,176 @@
+package cardresponse
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/raja-aiml/webex-mcp-server/internal/config"
+	"github.com/raja-aiml/webex-mcp-server/internal/fakewebex"
+	"github.com/raja-aiml/webex-mcp-server/internal/testutil"
+	"github.com/raja-aiml/webex-mcp-server/internal/webex"
+	"github.com/raja-aiml/webex-mcp-server/internal/webhooks"
+)
+
+// newCard posts a card and returns the fake, a client, the card's ID and a colleague's ID
+func newCard(t *testing.T) (*fakewebex.Server, webex.HTTPClient, string, string) {
+	t.Helper()
+	fake, baseURL := testutil.NewFakeWebex(t)
+	client, err := webex.NewClientWithConfig(&config.Config{WebexAPIKey: "token", WebexAPIBaseURL: baseURL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	room, _ := fake.Add(fakewebex.Rooms, fakewebex.Item{"title": "Approvals"})
+	card, err := fake.Add(fakewebex.Messages, fakewebex.Item{"roomId": room["id"], "markdown": "Approve?", "attachments": []interface{}{
+		fakewebex.Item{"contentType": "application/vnd.microsoft.card.adaptive", "content": fakewebex.Item{"type": "AdaptiveCard"}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	alice, _ := fake.Add(fakewebex.People, fakewebex.Item{"emails": []interface{}{"alice@example.com"}, "displayName": "Alice"})
+	return fake, client, card["id"].(string), alice["id"].(string)
+}
+
+func TestAwaitPolling(t *testing.T) {
+	fake, client, cardID, aliceID := newCard(t)
+	me := fake.Me()["id"].(string)
+
+	// A submission by someone else is ignored; Alice answers while the call waits
+	if _, err := fake.SubmitAs(me, cardID, fakewebex.Item{"decision": "deny"}); err != nil {
+		t.Fatal(err)
+	}
+	var progress int
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		fake.SubmitAs(aliceID, cardID, fakewebex.Item{"decision": "approve"})
+	}()
+	response, err := Await(context.Background(), client, Options{
+		MessageID:    cardID,
+		PersonIDs:    []string{aliceID},
+		Timeout:      5 * time.Second,
+		PollInterval: 10 * time.Millisecond,
+		Progress:     func(waited, timeout time.Duration) { progress++ },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.Inputs["decision"] != "approve" || response.PersonEmail != "alice@example.com" || response.Source != SourcePoll || response.Created.IsZero() {
+		t.Errorf("response = %+v", response)
+	}
+	if progress == 0 {
+		t.Error("no progress was reported")
+	}
+
+	// Without a person filter the earliest submission wins, even one made before the call
+	response, err = Await(context.Background(), client, Options{MessageID: cardID, PollInterval: 10 * time.Millisecond})
+	if err != nil || response.PersonID != me {
+		t.Errorf("Await() = %+v, %v", response, err)
+	}
+}
+
+func TestAwaitWebhook(t *testing.T) {
+	fake, client, cardID, aliceID := newCard(t)
+	// Webhooks reach the server without the events API
+	fake.InjectFault(fakewebex.Fault{Path: "/events", Status: http.StatusForbidden, Times: 100})
+
+	hub := webhooks.NewHub()
+	action, err := fake.SubmitAs(aliceID, cardID, fakewebex.Item{"comment": "ship it"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hub.Publish(webhooks.Notification{Resource: "messages", Event: "created", Data: map[string]interface{}{"id": "other"}})
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		hub.Publish(webhooks.Notification{Resource: "attachmentActions", Event: "created", Data: map[string]interface{}{
+			"id": action["id"], "messageId": cardID, "personId": aliceID,
+		}})
+	}()
+
+	response, err := Await(context.Background(), client, Options{MessageID: cardID, Hub: hub, Timeout: 5 * time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.Inputs["comment"] != "ship it" || response.Source != SourceWebhook || response.ActionID != action["id"] {
+		t.Errorf("response = %+v", response)
+	}
+}
+
+func TestAwaitForgedNotification(t *testing.T) {
+	fake, client, cardID, aliceID := newCard(t)
+	me := fake.Me()["id"].(string)
+	hub := webhooks.NewHub()
+
+	// Someone else's submission announced as Alice's, and an action that does not exist, are ignored
+	forged, err := fake.SubmitAs(me, cardID, fakewebex.Item{"decision": "approve"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []interface{}{forged["id"], "missing"} {
+		hub.Publish(webhooks.Notification{Resource: "attachmentActions", Event: "created", Data: map[string]interface{}{
+			"id": id, "messageId": cardID, "personId": aliceID,
+		}})
+	}
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		action, _ := fake.SubmitAs(aliceID, cardID, fakewebex.Item{"decision": "deny"})
+		hub.Publish(webhooks.Notification{Resource: "attachmentActions", Event: "created", Data: map[string]interface{}{
+			"id": action["id"], "messageId": cardID, "personId": aliceID,
+		}})
+	}()
+
+	response, err := Await(context.Background(), client, Options{MessageID: cardID, PersonIDs: []string{aliceID}, Hub: hub, Timeout: 5 * time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.PersonID != aliceID || response.Inputs["decision"] != "deny" {
+		t.Errorf("response = %+v, want Alice's own submission", response)
+	}
+}
+
+func TestAwaitErrors(t *testing.T) {
+	fake, client, cardID, aliceID := newCard(t)
+	text, _ := fake.Add(fakewebex.Messages, fakewebex.Item{"roomId": fake.List(fakewebex.Rooms)[0]["id"], "text": "plain"})
+
+	tests := []struct {
+		name    string
+		opts    Options
+		fault   *fakewebex.Fault
+		want    error
+		wantMsg string
+	}{
+		{name: "timeout", opts: Options{MessageID: cardID, PersonIDs: []string{aliceID}, Timeout: 50 * time.Millisecond, PollInterval: 10 * time.Millisecond}, want: ErrTimeout},
+		{name: "timeout by webhook", opts: Options{MessageID: cardID, Hub: webhooks.NewHub(), Timeout: 50 * time.Millisecond, PollInterval: 10 * time.Millisecond}, want: ErrTimeout},
+		{name: "not a card", opts: Options{MessageID: text["id"].(string)}, wantMsg: "has no card"},
+		{name: "unknown message", opts: Options{MessageID: "nope"}, wantMsg: "failed to get the card message"},
+		{
+			name:    "events API forbidden",
+			opts:    Options{MessageID: cardID, Timeout: time.Second},
+			fault:   &fakewebex.Fault{Path: "/events", Status: http.StatusForbidden},
+			wantMsg: "compliance officer",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.fault != nil {
+				fake.InjectFault(*tt.fault)
+			}
+			_, err := Await(context.Background(), client, tt.opts)
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Errorf("Await() = %v, want %v", err, tt.want)
+			}
+			if tt.wantMsg != "" && (err == nil || !strings.Contains(err.Error(), tt.wantMsg)) {
+				t.Errorf("Await() = %v, want %q", err, tt.wantMsg)
+			}
+		})
+	}
+
+	// A caller that goes away stops the wait without a timeout error
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := Await(ctx, client, Options{MessageID: cardID, PersonIDs: []string{aliceID}, PollInterval: 10 * time.Millisecond}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Await() with a cancelled context = %v", err)
+	}
+}
@@ -0,0 +1,288 @@
+// Package cards builds, validates and templates Adaptive Cards for Webex
+// messages. Webex renders Adaptive Cards up to version 1.3, so the builders
+// produce 1.3 cards and Validate rejects anything Webex would not accept.
+package cards
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ContentType is the attachment content type of an Adaptive Card
+const ContentType = "application/vnd.microsoft.card.adaptive"
+
+// Version is the newest Adaptive Card schema version Webex renders
+const Version = "1.3"
+
+const schemaURL = "http://adaptivecards.io/schemas/adaptive-card.json"
+
+// Element is a card, element or action as it is encoded in JSON
+type Element map[string]interface{}
+
+// Fact is a title and value pair of a FactSet
+type Fact struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
+
+// Choice is an option of a ChoiceSet
+type Choice struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
+
+// New returns a version 1.3 card with the body and actions
+func New(body []Element, actions ...Element) Element {
+	if body == nil {
+		body = []Element{}
+	}
+	card := Element{
+		"type":    "AdaptiveCard",
+		"$schema": schemaURL,
+		"version": Version,
+		"body":    body,
+	}
+	if len(actions) > 0 {
+		card["actions"] = actions
+	}
+	return card
+}
+
+// Attachment wraps a card as a message attachment
+func Attachment(card interface{}) map[string]interface{} {
+	return map[string]interface{}{"contentType": ContentType, "content": card}
+}
+
+// TextBlock returns wrapping text
+func TextBlock(text string) Element {
+	return Element{"type": "TextBlock", "text": text, "wrap": true}
+}
+
+// Heading returns large bold text
+func Heading(text string) Element {
+	return Element{"type": "TextBlock", "text": text, "wrap": true, "size": "Large", "weight": "Bolder"}
+}
+
+// FactSet returns a table of titles and values
+func FactSet(facts ...Fact) Element {
+	return Element{"type": "FactSet", "facts": facts}
+}
+
+// TextInput returns a text field
+func TextInput(id, label string) Element {
+	return input("Input.Text", id, label)
+}
+
+// NumberInput returns a number field
+func NumberInput(id, label string) Element {
+	return input("Input.Number", id, label)
+}
+
+// DateInput returns a date picker
+func DateInput(id, label string) Element {
+	return input("Input.Date", id, label)
+}
+
+// TimeInput returns a time picker
+func TimeInput(id, label string) Element {
+	return input("Input.Time", id, label)
+}
+
+// Toggle returns a checkbox submitted as "true" or "false"
+func Toggle(id, title string) Element {
+	return Element{"type": "Input.Toggle", "id": id, "title": title}
+}
+
+// ChoiceSet returns a list of options; multi allows selecting several
+func ChoiceSet(id, label string, multi bool, choices ...Choice) Element {
+	e := input("Input.ChoiceSet", id, label)
+	e["choices"] = choices
+	if multi {
+		e["isMultiSelect"] = true
+	}
+	return e
+}
+
+func input(kind, id, label string) Element {
+	e := Element{"type": kind, "id": id}
+	if label != "" {
+		e["label"] = label
+	}
+	return e
+}
+
+// Submit returns a button that sends the card's inputs, merged with data
+func Submit(title string, data map[string]interface{}) Element {
+	e := Element{"type": "Action.Submit", "title": title}
+	if len(data) > 0 {
+		e["data"] = data
+	}
+	return e
+}
+
+// OpenURL returns a button that opens url
+func OpenURL(title, url string) Element {
+	return Element{"type": "Action.OpenUrl", "title": title, "url": url}
+}
+
+// ShowCard returns a button that reveals card below the current one
+func ShowCard(title string, card Element) Element {
+	return Element{"type": "Action.ShowCard", "title": title, "card": card}
+}
+
+// Input types of InputSpec
+const (
+	InputText   = "text"
+	InputNumber = "number"
+	InputDate   = "date"
+	InputTime   = "time"
+	InputToggle = "toggle"
+	InputChoice = "choice"
+)
+
+// InputTypes lists the values accepted in InputSpec.Type
+var InputTypes = []string{InputText, InputNumber, InputDate, InputTime, InputToggle, InputChoice}
+
+// Action types of ActionSpec
+const (
+	ActionSubmit  = "submit"
+	ActionOpenURL = "openUrl"
+)
+
+// ActionTypes lists the values accepted in ActionSpec.Type
+var ActionTypes = []string{ActionSubmit, ActionOpenURL}
+
+// InputSpec describes one input of a Spec
+type InputSpec struct {
+	ID          string   `json:"id"`
+	Type        string   `json:"type,omitempty"` // One of InputTypes; text when empty
+	Label       string   `json:"label,omitempty"`
+	Placeholder string   `json:"placeholder,omitempty"`
+	Value       string   `json:"value,omitempty"` // Initial value
+	Required    bool     `json:"required,omitempty"`
+	Multiline   bool     `json:"multiline,omitempty"`   // Text inputs only
+	MultiSelect bool     `json:"multiSelect,omitempty"` // Choice inputs only
+	Choices     []Choice `json:"choices,omitempty"`
+}
+
+// ActionSpec describes one button of a Spec
+type ActionSpec struct {
+	Type  string                 `json:"type,omitempty"` // One of ActionTypes; submit when empty
+	Title string                 `json:"title"`
+	URL   string                 `json:"url,omitempty"`  // Required for openUrl
+	Data  map[string]interface{} `json:"data,omitempty"` // Sent along with the inputs on submit
+}
+
+// Spec describes a common card shape: a title, text, facts, inputs and buttons
+type Spec struct {
+	Title   string       `json:"title,omitempty"`
+	Text    string       `json:"text,omitempty"`
+	Facts   []Fact       `json:"facts,omitempty"`
+	Inputs  []InputSpec  `json:"inputs,omitempty"`
+	Actions []ActionSpec `json:"actions,omitempty"`
+}
+
+// IsZero reports whether the spec describes nothing
+func (s Spec) IsZero() bool {
+	return s.Title == "" && s.Text == "" && len(s.Facts) == 0 && len(s.Inputs) == 0 && len(s.Actions) == 0
+}
+
+// Build returns the validated card of the spec. A card with inputs and no
+// buttons gets a Submit button so its inputs can be sent.
+func (s Spec) Build() (Element, error) {
+	if s.IsZero() {
+		return nil, fmt.Errorf("a card needs a title, text, facts, inputs or actions")
+	}
+	var body []Element
+	if s.Title != "" {
+		body = append(body, Heading(s.Title))
+	}
+	if s.Text != "" {
+		body = append(body, TextBlock(s.Text))
+	}
+	if len(s.Facts) > 0 {
+		body = append(body, FactSet(s.Facts...))
+	}
+	for i, spec := range s.Inputs {
+		e, err := spec.build()
+		if err != nil {
+			return nil, fmt.Errorf("inputs[%d]: %w", i, err)
+		}
+		body = append(body, e)
+	}
+
+	var actions []Element
+	for i, spec := range s.Actions {
+		switch spec.Type {
+		case "", ActionSubmit:
+			actions = append(actions, Submit(spec.Title, spec.Data))
+		case ActionOpenURL:
+			if spec.URL == "" {
+				return nil, fmt.Errorf("actions[%d]: url is required", i)
+			}
+			actions = append(actions, OpenURL(spec.Title, spec.URL))
+		default:
+			return nil, fmt.Errorf("actions[%d]: type must be one of %s", i, strings.Join(ActionTypes, ", "))
+		}
+	}
+	if len(s.Inputs) > 0 && len(actions) == 0 {
+		actions = append(actions, Submit("Submit", nil))
+	}
+
+	card := New(body, actions...)
+	if err := Validate(card); err != nil {
+		return nil, err
+	}
+	return card, nil
+}
+
+func (s InputSpec) build() (Element, error) {
+	if s.ID == "" {
+		return nil, fmt.Errorf("id is required")
+	}
+	var e Element
+	switch s.Type {
+	case "", InputText:
+		e = TextInput(s.ID, s.Label)
+		if s.Multiline {
+			e["isMultiline"] = true
+		}
+	case InputNumber:
+		e = NumberInput(s.ID, s.Label)
+		if s.Value != "" {
+			n, err := strconv.ParseFloat(s.Value, 64)
+			if err != nil {
+				return nil, fmt.Errorf("value %q is not a number", s.Value)
+			}
+			e["value"] = n
+		}
+	case InputDate:
+		e = DateInput(s.ID, s.Label)
+	case InputTime:
+		e = TimeInput(s.ID, s.Label)
+	case InputToggle:
+		title := s.Label
+		if title == "" {
+			title = s.ID
+		}
+		e = Toggle(s.ID, title)
+	case InputChoice:
+		if len(s.Choices) == 0 {
+			return nil, fmt.Errorf("choices are required")
+		}
+		e = ChoiceSet(s.ID, s.Label, s.MultiSelect, s.Choices...)
+	default:
+		return nil, fmt.Errorf("type must be one of %s", strings.Join(InputTypes, ", "))
+	}
+	if s.Placeholder != "" && s.Type != InputToggle {
+		e["placeholder"] = s.Placeholder
+	}
+	if s.Value != "" && s.Type != InputNumber {
+		e["value"] = s.Value
+	}
+	if s.Required {
+		e["isRequired"] = true
+	}
+	return e, nil
+}
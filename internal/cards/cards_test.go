@@ -0,0 +1,221 @@
+package cards
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		card interface{}
+		want []string // Problems expected, in part; none means the card is valid
+	}{
+		{
+			name: "builder card",
+			card: New([]Element{
+				Heading("Deploy"),
+				FactSet(Fact{Title: "Service", Value: "api"}),
+				ChoiceSet("env", "Environment", false, Choice{Title: "Production", Value: "prod"}),
+				Toggle("notify", "Notify the team"),
+			}, Submit("Deploy", map[string]interface{}{"action": "deploy"}), OpenURL("Runbook", "https://example.com")),
+		},
+		{
+			name: "nested containers and show card",
+			card: map[string]interface{}{
+				"type": "AdaptiveCard", "version": "1.2",
+				"body": []interface{}{
+					map[string]interface{}{"type": "ColumnSet", "columns": []interface{}{
+						map[string]interface{}{"type": "Column", "width": "auto", "items": []interface{}{
+							map[string]interface{}{"type": "Image", "url": "https://example.com/a.png", "size": "Small"},
+						}},
+					}},
+					map[string]interface{}{"type": "RichTextBlock", "inlines": []interface{}{"plain", map[string]interface{}{"type": "TextRun", "text": "bold", "weight": "Bolder"}}},
+				},
+				"actions": []interface{}{
+					map[string]interface{}{"type": "Action.ShowCard", "title": "Comment", "card": map[string]interface{}{
+						"type": "AdaptiveCard",
+						"body": []interface{}{map[string]interface{}{"type": "Input.Text", "id": "comment", "isMultiline": true}},
+					}},
+				},
+			},
+		},
+		{
+			name: "missing version and type",
+			card: map[string]interface{}{"body": []interface{}{}},
+			want: []string{"type: is required", "version: is required"},
+		},
+		{
+			name: "version newer than Webex supports",
+			card: map[string]interface{}{"type": "Adaptivecard", "version": "1.5"},
+			want: []string{`type must be "AdaptiveCard"`, "version: 1.5 is not supported; Webex supports up to 1.3"},
+		},
+		{
+			name: "element problems carry their path",
+			card: New([]Element{
+				{"type": "TextBlock", "txt": "typo"},
+				{"type": "Container", "items": []Element{{"type": "TextBlock", "text": "x", "size": "huge"}}},
+				{"type": "Media", "sources": []interface{}{}},
+			}),
+			want: []string{
+				"body[0].text: is required",
+				"body[0].txt: unknown property",
+				`body[1].items[0].size: "huge" is not one of`,
+				"body[2]: Webex does not render Media",
+			},
+		},
+		{
+			name: "inputs",
+			card: New([]Element{
+				TextInput("name", "Name"),
+				TextInput("name", "Again"),
+				{"type": "Input.Number", "id": "count", "value": "three"},
+				{"type": "Input.ChoiceSet", "id": "pick", "choices": []interface{}{map[string]interface{}{"title": "A"}}},
+				{"type": "Input.Date"},
+			}),
+			want: []string{
+				`input id "name" is used 2 times`,
+				"body[2].value: must be a number",
+				"body[3].choices[0].value: is required",
+				"body[4].id: is required",
+			},
+		},
+		{
+			name: "actions",
+			card: New(nil,
+				Element{"type": "Action.OpenUrl", "title": "Open"},
+				Element{"type": "Action.Execute", "verb": "x"},
+				Element{"type": "Action.Submit", "style": "primary"},
+			),
+			want: []string{
+				"actions[0].url: is required",
+				"actions[1]: Action.Execute needs version 1.4",
+				`actions[2].style: "primary" is not one of`,
+			},
+		},
+		{
+			name: "show card only at the top",
+			card: New([]Element{{"type": "Image", "url": "https://example.com/a.png", "selectAction": ShowCard("More", New(nil))}}),
+			want: []string{"body[0].selectAction: Action.ShowCard is not allowed here"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.card)
+			if len(tt.want) == 0 {
+				if err != nil {
+					t.Fatalf("Validate() = %v", err)
+				}
+				return
+			}
+			var invalid *ValidationError
+			if !errors.As(err, &invalid) {
+				t.Fatalf("Validate() = %v, want a ValidationError", err)
+			}
+			for _, want := range tt.want {
+				if !slices.ContainsFunc(invalid.Problems, func(p string) bool { return strings.Contains(p, want) }) {
+					t.Errorf("problems %q lack %q", invalid.Problems, want)
+				}
+			}
+			if len(invalid.Problems) != len(tt.want) {
+				t.Errorf("got %d problems %q, want %d", len(invalid.Problems), invalid.Problems, len(tt.want))
+			}
+		})
+	}
+}
+
+func TestSpecBuild(t *testing.T) {
+	card, err := Spec{
+		Title: "Incident",
+		Facts: []Fact{{Title: "Severity", Value: "1"}},
+		Inputs: []InputSpec{
+			{ID: "owner", Label: "Owner", Required: true},
+			{ID: "eta", Type: InputNumber, Value: "30"},
+			{ID: "team", Type: InputChoice, Choices: []Choice{{Title: "Ops", Value: "ops"}}},
+		},
+	}.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := card["body"].([]Element)
+	if len(body) != 5 || body[0]["text"] != "Incident" || body[2]["isRequired"] != true || body[3]["value"] != 30.0 {
+		t.Errorf("body = %v", body)
+	}
+	// Inputs need a button to be submitted
+	if actions := card["actions"].([]Element); len(actions) != 1 || actions[0]["type"] != "Action.Submit" {
+		t.Errorf("actions = %v", card["actions"])
+	}
+
+	for _, spec := range []Spec{
+		{},
+		{Inputs: []InputSpec{{Type: InputText}}},
+		{Inputs: []InputSpec{{ID: "x", Type: "slider"}}},
+		{Inputs: []InputSpec{{ID: "x", Type: InputChoice}}},
+		{Actions: []ActionSpec{{Type: ActionOpenURL, Title: "Open"}}},
+	} {
+		if _, err := spec.Build(); err == nil {
+			t.Errorf("Build(%+v) succeeded", spec)
+		}
+	}
+}
+
+func TestTemplates(t *testing.T) {
+	dir := t.TempDir()
+	template := `{
+  "type": "AdaptiveCard", "version": "1.3",
+  "body": [
+    {"type": "TextBlock", "text": "Release ${release.version} is out, ${ owner }", "wrap": true},
+    {"type": "FactSet", "facts": "${facts}"}
+  ]
+}`
+	if err := os.WriteFile(filepath.Join(dir, "release.json"), []byte(template), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("not a template"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	list, err := ListTemplates(dir)
+	if err != nil || len(list) != 1 || list[0].Name != "release" || strings.Join(list[0].Variables, ",") != "facts,owner,release.version" {
+		t.Fatalf("ListTemplates() = %+v, %v", list, err)
+	}
+
+	card, err := FromTemplate(dir, "release", map[string]interface{}{
+		"release": map[string]interface{}{"version": 2.5},
+		"owner":   "Alice",
+		"facts":   []interface{}{map[string]interface{}{"title": "Notes", "value": "Faster sync"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := card.(map[string]interface{})["body"].([]interface{})
+	if text := body[0].(map[string]interface{})["text"]; text != "Release 2.5 is out, Alice" {
+		t.Errorf("text = %q", text)
+	}
+	if facts := body[1].(map[string]interface{})["facts"].([]interface{}); len(facts) != 1 {
+		t.Errorf("facts = %v", facts)
+	}
+
+	tests := []struct {
+		name, template string
+		data           map[string]interface{}
+		want           string
+	}{
+		{name: "missing data", template: "release", data: map[string]interface{}{"owner": "Alice"}, want: "missing template data: facts, release.version"},
+		{name: "invalid card", template: "release", data: map[string]interface{}{"owner": "A", "release": map[string]interface{}{"version": "1"}, "facts": "none"}, want: "body[1].facts: must be an array"},
+		{name: "unknown template", template: "other", want: `card template "other" not found`},
+		{name: "path outside the directory", template: "../release", want: "invalid template name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := FromTemplate(dir, tt.template, tt.data); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("FromTemplate() = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
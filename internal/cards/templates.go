@@ -0,0 +1,180 @@
+package cards
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Template is a card file in the templates directory
+type Template struct {
+	Name      string   `json:"name"`
+	Variables []string `json:"variables"` // Names used as ${name} in the card
+}
+
+var (
+	templateName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+	variableRef  = regexp.MustCompile(`\$\{\s*([A-Za-z_][A-Za-z0-9_]*(?:\.[A-Za-z_][A-Za-z0-9_]*)*)\s*\}`)
+)
+
+// ListTemplates returns the templates in dir, NAME.json files holding a card, sorted by name
+func ListTemplates(dir string) ([]Template, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list card templates: %w", err)
+	}
+	templates := []Template{}
+	for _, path := range paths {
+		name := strings.TrimSuffix(filepath.Base(path), ".json")
+		if !templateName.MatchString(name) {
+			continue
+		}
+		doc, err := LoadTemplate(dir, name)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, Template{Name: name, Variables: Variables(doc)})
+	}
+	return templates, nil
+}
+
+// LoadTemplate reads the template called name from dir
+func LoadTemplate(dir, name string) (interface{}, error) {
+	if !templateName.MatchString(name) {
+		return nil, fmt.Errorf("invalid template name %q: use letters, digits, - and _", name)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, name+".json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("card template %q not found", name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read card template: %w", err)
+	}
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse card template %s: %w", name, err)
+	}
+	return doc, nil
+}
+
+// FromTemplate loads the template called name from dir, fills it with data and validates the card
+func FromTemplate(dir, name string, data map[string]interface{}) (interface{}, error) {
+	doc, err := LoadTemplate(dir, name)
+	if err != nil {
+		return nil, err
+	}
+	card, err := Expand(doc, data)
+	if err != nil {
+		return nil, fmt.Errorf("card template %s: %w", name, err)
+	}
+	if err := Validate(card); err != nil {
+		return nil, fmt.Errorf("card template %s: %w", name, err)
+	}
+	return card, nil
+}
+
+// Variables returns the sorted names referenced as ${name} in a template
+func Variables(template interface{}) []string {
+	seen := map[string]bool{}
+	walkStrings(template, func(s string) {
+		for _, m := range variableRef.FindAllStringSubmatch(s, -1) {
+			seen[m[1]] = true
+		}
+	})
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Expand replaces ${name} references in the strings of a template with values
+// from data; ${a.b} reads field b of object a. A string that is only a
+// reference takes the value as is, so a variable can fill in a list of facts.
+// Every missing variable is reported in one error.
+func Expand(template interface{}, data map[string]interface{}) (interface{}, error) {
+	var missing []string
+	expanded := expand(template, data, &missing)
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, fmt.Errorf("missing template data: %s", strings.Join(slices.Compact(missing), ", "))
+	}
+	return expanded, nil
+}
+
+func expand(value interface{}, data map[string]interface{}, missing *[]string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			out[key] = expand(item, data, missing)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = expand(item, data, missing)
+		}
+		return out
+	case string:
+		if m := variableRef.FindStringSubmatch(v); m != nil && m[0] == v {
+			found, ok := lookup(data, m[1])
+			if !ok {
+				*missing = append(*missing, m[1])
+			}
+			return found
+		}
+		return variableRef.ReplaceAllStringFunc(v, func(ref string) string {
+			name := variableRef.FindStringSubmatch(ref)[1]
+			found, ok := lookup(data, name)
+			if !ok {
+				*missing = append(*missing, name)
+				return ""
+			}
+			switch found.(type) {
+			case map[string]interface{}, []interface{}:
+				data, _ := json.Marshal(found)
+				return string(data)
+			}
+			return fmt.Sprint(found)
+		})
+	default:
+		return value
+	}
+}
+
+func lookup(data map[string]interface{}, name string) (interface{}, bool) {
+	var current interface{} = data
+	for _, part := range strings.Split(name, ".") {
+		obj, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if current, ok = obj[part]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+func walkStrings(value interface{}, visit func(string)) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, item := range v {
+			walkStrings(item, visit)
+		}
+	case []interface{}:
+		for _, item := range v {
+			walkStrings(item, visit)
+		}
+	case string:
+		visit(v)
+	}
+}
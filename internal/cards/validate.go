@@ -0,0 +1,539 @@
+package cards
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ValidationError lists every problem found in a card
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid adaptive card: " + strings.Join(e.Problems, "; ")
+}
+
+// kind is the expected JSON shape of a property
+type kind int
+
+const (
+	kindAny kind = iota
+	kindString
+	kindBool
+	kindNumber
+	kindObject
+	kindElements // Array of card elements
+	kindActions  // Array of actions
+	kindAction   // A single action other than Action.ShowCard
+	kindCard     // A nested card, as in Action.ShowCard
+	kindColumns  // Array of Column
+	kindImages   // Array of Image
+	kindFacts    // Array of {title, value}
+	kindChoices  // Array of {title, value}
+	kindInlines  // Array of TextRun or strings
+	kindStrings  // Array of strings or {elementId, isVisible}
+)
+
+type property struct {
+	kind  kind
+	enum  []string // Allowed values, compared without case like Webex does
+	isReq bool
+}
+
+func (p property) required() property {
+	p.isReq = true
+	return p
+}
+
+var (
+	anyProp     = property{kind: kindAny}
+	stringProp  = property{kind: kindString}
+	boolProp    = property{kind: kindBool}
+	numberProp  = property{kind: kindNumber}
+	objectProp  = property{kind: kindObject}
+	actionProp  = property{kind: kindAction}
+	elementsArr = property{kind: kindElements}
+	actionsArr  = property{kind: kindActions}
+)
+
+func enumProp(values ...string) property {
+	return property{kind: kindString, enum: values}
+}
+
+var (
+	spacingEnum     = enumProp("none", "small", "default", "medium", "large", "extraLarge", "padding")
+	sizeEnum        = enumProp("default", "small", "medium", "large", "extraLarge")
+	weightEnum      = enumProp("default", "lighter", "bolder")
+	colorEnum       = enumProp("default", "dark", "light", "accent", "good", "warning", "attention")
+	fontTypeEnum    = enumProp("default", "monospace")
+	alignEnum       = enumProp("left", "center", "right")
+	verticalEnum    = enumProp("top", "center", "bottom")
+	containerEnum   = enumProp("default", "emphasis", "good", "attention", "warning", "accent")
+	imageSizeEnum   = enumProp("auto", "stretch", "small", "medium", "large")
+	actionStyleEnum = enumProp("default", "positive", "destructive")
+)
+
+// elementProps are accepted by every element
+var elementProps = map[string]property{
+	"type":      stringProp.required(),
+	"id":        stringProp,
+	"spacing":   spacingEnum,
+	"separator": boolProp,
+	"isVisible": boolProp,
+	"requires":  objectProp,
+	"height":    enumProp("auto", "stretch"),
+	"fallback":  anyProp,
+}
+
+// inputProps are accepted by every input
+var inputProps = map[string]property{
+	"id":           stringProp.required(),
+	"isRequired":   boolProp,
+	"errorMessage": stringProp,
+	"label":        stringProp,
+}
+
+// actionProps are accepted by every action
+var actionProps = map[string]property{
+	"type":     stringProp.required(),
+	"id":       stringProp,
+	"title":    stringProp,
+	"iconUrl":  stringProp,
+	"style":    actionStyleEnum,
+	"fallback": anyProp,
+	"requires": objectProp,
+}
+
+// elements are the Adaptive Card 1.3 elements Webex renders, by type
+var elements = map[string]map[string]property{
+	"TextBlock": {
+		"text":                stringProp.required(),
+		"color":               colorEnum,
+		"fontType":            fontTypeEnum,
+		"horizontalAlignment": alignEnum,
+		"isSubtle":            boolProp,
+		"maxLines":            numberProp,
+		"size":                sizeEnum,
+		"weight":              weightEnum,
+		"wrap":                boolProp,
+	},
+	"RichTextBlock": {
+		"inlines":             property{kind: kindInlines}.required(),
+		"horizontalAlignment": alignEnum,
+	},
+	"Image": {
+		"url":                 stringProp.required(),
+		"altText":             stringProp,
+		"backgroundColor":     stringProp,
+		"height":              stringProp,
+		"horizontalAlignment": alignEnum,
+		"selectAction":        actionProp,
+		"size":                imageSizeEnum,
+		"style":               enumProp("default", "person"),
+		"width":               stringProp,
+	},
+	"ImageSet": {
+		"images":    property{kind: kindImages}.required(),
+		"imageSize": imageSizeEnum,
+	},
+	"Container": {
+		"items":                    elementsArr.required(),
+		"selectAction":             actionProp,
+		"style":                    containerEnum,
+		"verticalContentAlignment": verticalEnum,
+		"bleed":                    boolProp,
+		"backgroundImage":          anyProp,
+		"minHeight":                stringProp,
+	},
+	"ColumnSet": {
+		"columns":             property{kind: kindColumns},
+		"selectAction":        actionProp,
+		"style":               containerEnum,
+		"bleed":               boolProp,
+		"minHeight":           stringProp,
+		"horizontalAlignment": alignEnum,
+	},
+	"Column": {
+		"items":                    elementsArr,
+		"backgroundImage":          anyProp,
+		"bleed":                    boolProp,
+		"minHeight":                stringProp,
+		"selectAction":             actionProp,
+		"style":                    containerEnum,
+		"verticalContentAlignment": verticalEnum,
+		"width":                    anyProp,
+	},
+	"FactSet": {
+		"facts": property{kind: kindFacts}.required(),
+	},
+	"ActionSet": {
+		"actions": actionsArr.required(),
+	},
+	"Input.Text": {
+		"isMultiline":  boolProp,
+		"maxLength":    numberProp,
+		"placeholder":  stringProp,
+		"regex":        stringProp,
+		"style":        enumProp("text", "tel", "url", "email"),
+		"inlineAction": actionProp,
+		"value":        stringProp,
+	},
+	"Input.Number": {
+		"max":         numberProp,
+		"min":         numberProp,
+		"placeholder": stringProp,
+		"value":       numberProp,
+	},
+	"Input.Date": {
+		"max":         stringProp,
+		"min":         stringProp,
+		"placeholder": stringProp,
+		"value":       stringProp,
+	},
+	"Input.Time": {
+		"max":         stringProp,
+		"min":         stringProp,
+		"placeholder": stringProp,
+		"value":       stringProp,
+	},
+	"Input.Toggle": {
+		"title":    stringProp.required(),
+		"value":    stringProp,
+		"valueOff": stringProp,
+		"valueOn":  stringProp,
+		"wrap":     boolProp,
+	},
+	"Input.ChoiceSet": {
+		"choices":       property{kind: kindChoices}.required(),
+		"isMultiSelect": boolProp,
+		"style":         enumProp("compact", "expanded"),
+		"value":         stringProp,
+		"placeholder":   stringProp,
+		"wrap":          boolProp,
+	},
+}
+
+// actions are the Adaptive Card 1.3 actions Webex supports, by type
+var actions = map[string]map[string]property{
+	"Action.Submit": {
+		"data":             anyProp,
+		"associatedInputs": enumProp("auto", "none"),
+	},
+	"Action.OpenUrl": {
+		"url": stringProp.required(),
+	},
+	"Action.ShowCard": {
+		"card": property{kind: kindCard},
+	},
+	"Action.ToggleVisibility": {
+		"targetElements": property{kind: kindStrings}.required(),
+	},
+}
+
+// cardProps are accepted on a card
+var cardProps = map[string]property{
+	"type":                     stringProp.required(),
+	"$schema":                  stringProp,
+	"version":                  stringProp,
+	"body":                     elementsArr,
+	"actions":                  actionsArr,
+	"selectAction":             actionProp,
+	"fallbackText":             stringProp,
+	"backgroundImage":          anyProp,
+	"minHeight":                stringProp,
+	"lang":                     stringProp,
+	"speak":                    stringProp,
+	"verticalContentAlignment": verticalEnum,
+}
+
+// unsupported explains types that exist in Adaptive Cards but not in Webex
+var unsupported = map[string]string{
+	"Media":          "Webex does not render Media",
+	"Table":          "Table needs version 1.5; Webex supports up to " + Version,
+	"Action.Execute": "Action.Execute needs version 1.4; Webex supports up to " + Version,
+}
+
+// Validate checks a card against the Adaptive Card 1.3 schema as Webex
+// renders it. The card may be an Element or any value that encodes to a
+// card object; every problem is reported in a *ValidationError.
+func Validate(card interface{}) error {
+	data, err := json.Marshal(card)
+	if err != nil {
+		return fmt.Errorf("failed to encode card: %w", err)
+	}
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to decode card: %w", err)
+	}
+
+	v := &validator{inputs: map[string]int{}}
+	v.card("", doc, true)
+	for id, n := range v.inputs {
+		if n > 1 {
+			v.problem("", "input id %q is used %d times; ids must be unique", id, n)
+		}
+	}
+	if len(v.problems) > 0 {
+		sort.Strings(v.problems)
+		return &ValidationError{Problems: v.problems}
+	}
+	return nil
+}
+
+type validator struct {
+	problems []string
+	inputs   map[string]int // Uses of each input id
+}
+
+func (v *validator) problem(path, format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	if path != "" {
+		msg = path + ": " + msg
+	}
+	v.problems = append(v.problems, msg)
+}
+
+// card checks a card; only a top-level card must declare its version
+func (v *validator) card(path string, value interface{}, top bool) {
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		v.problem(path, "a card must be an object")
+		return
+	}
+	if t, ok := obj["type"]; ok && t != "AdaptiveCard" {
+		v.problem(path, `type must be "AdaptiveCard"`)
+	}
+	version, hasVersion := obj["version"].(string)
+	switch {
+	case hasVersion:
+		v.version(join(path, "version"), version)
+	case top:
+		v.problem(join(path, "version"), "is required; use %q", Version)
+	}
+	v.properties(path, obj, cardProps)
+}
+
+func (v *validator) version(path, version string) {
+	major, minor, ok := strings.Cut(version, ".")
+	m, err1 := strconv.Atoi(major)
+	n, err2 := strconv.Atoi(minor)
+	if !ok || err1 != nil || err2 != nil {
+		v.problem(path, "%q is not a version like %q", version, Version)
+		return
+	}
+	if m != 1 || n > 3 {
+		v.problem(path, "%s is not supported; Webex supports up to %s", version, Version)
+	}
+}
+
+func (v *validator) element(path string, value interface{}) {
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		v.problem(path, "an element must be an object")
+		return
+	}
+	t, _ := obj["type"].(string)
+	props, ok := elements[t]
+	if !ok {
+		v.unknownType(path, t, "element")
+		return
+	}
+	v.properties(path, obj, merge(elementProps, props, inputPropsFor(t)))
+	if id, ok := obj["id"].(string); ok && strings.HasPrefix(t, "Input.") {
+		v.inputs[id]++
+	}
+}
+
+func (v *validator) action(path string, value interface{}, allowShowCard bool) {
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		v.problem(path, "an action must be an object")
+		return
+	}
+	t, _ := obj["type"].(string)
+	props, ok := actions[t]
+	if !ok {
+		v.unknownType(path, t, "action")
+		return
+	}
+	if t == "Action.ShowCard" && !allowShowCard {
+		v.problem(path, "Action.ShowCard is not allowed here")
+		return
+	}
+	v.properties(path, obj, merge(actionProps, props))
+}
+
+func (v *validator) unknownType(path, t, what string) {
+	switch {
+	case t == "":
+		v.problem(path, "%s type is required", what)
+	case unsupported[t] != "":
+		v.problem(path, "%s", unsupported[t])
+	default:
+		v.problem(path, "unknown %s type %q", what, t)
+	}
+}
+
+// properties checks that obj has the required properties and that each has the right shape
+func (v *validator) properties(path string, obj map[string]interface{}, props map[string]property) {
+	names := make([]string, 0, len(props))
+	for name, prop := range props {
+		if prop.isReq {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, ok := obj[name]; !ok {
+			v.problem(join(path, name), "is required")
+		}
+	}
+
+	for name, value := range obj {
+		prop, ok := props[name]
+		if !ok {
+			v.problem(join(path, name), "unknown property")
+			continue
+		}
+		v.value(join(path, name), value, prop)
+	}
+}
+
+func (v *validator) value(path string, value interface{}, prop property) {
+	switch prop.kind {
+	case kindString:
+		s, ok := value.(string)
+		if !ok {
+			v.problem(path, "must be a string")
+			return
+		}
+		if prop.enum != nil && !slices.ContainsFunc(prop.enum, func(e string) bool { return strings.EqualFold(e, s) }) {
+			v.problem(path, "%q is not one of %s", s, strings.Join(prop.enum, ", "))
+		}
+	case kindBool:
+		if _, ok := value.(bool); !ok {
+			v.problem(path, "must be true or false")
+		}
+	case kindNumber:
+		if _, ok := value.(float64); !ok {
+			v.problem(path, "must be a number")
+		}
+	case kindObject:
+		if _, ok := value.(map[string]interface{}); !ok {
+			v.problem(path, "must be an object")
+		}
+	case kindAction:
+		v.action(path, value, false)
+	case kindCard:
+		v.card(path, value, false)
+	case kindElements:
+		v.each(path, value, v.element)
+	case kindActions:
+		v.each(path, value, func(path string, item interface{}) { v.action(path, item, true) })
+	case kindColumns:
+		v.each(path, value, func(path string, item interface{}) {
+			if obj, ok := item.(map[string]interface{}); ok && obj["type"] != "Column" {
+				v.problem(path, `type must be "Column"`)
+				return
+			}
+			v.element(path, item)
+		})
+	case kindImages:
+		v.each(path, value, func(path string, item interface{}) {
+			if obj, ok := item.(map[string]interface{}); ok && obj["type"] != "Image" {
+				v.problem(path, `type must be "Image"`)
+				return
+			}
+			v.element(path, item)
+		})
+	case kindFacts, kindChoices:
+		v.each(path, value, func(path string, item interface{}) {
+			obj, ok := item.(map[string]interface{})
+			if !ok {
+				v.problem(path, "must be an object with title and value")
+				return
+			}
+			v.properties(path, obj, map[string]property{"title": stringProp.required(), "value": stringProp.required()})
+		})
+	case kindInlines:
+		v.each(path, value, func(path string, item interface{}) {
+			if _, ok := item.(string); ok {
+				return
+			}
+			obj, ok := item.(map[string]interface{})
+			if !ok || obj["type"] != "TextRun" {
+				v.problem(path, "must be a string or a TextRun")
+				return
+			}
+			v.properties(path, obj, textRunProps)
+		})
+	case kindStrings:
+		v.each(path, value, func(path string, item interface{}) {
+			if _, ok := item.(string); ok {
+				return
+			}
+			obj, ok := item.(map[string]interface{})
+			if !ok {
+				v.problem(path, "must be an element id or a target element")
+				return
+			}
+			v.properties(path, obj, map[string]property{"elementId": stringProp.required(), "isVisible": boolProp})
+		})
+	}
+}
+
+var textRunProps = map[string]property{
+	"type":          stringProp.required(),
+	"text":          stringProp.required(),
+	"color":         colorEnum,
+	"fontType":      fontTypeEnum,
+	"highlight":     boolProp,
+	"isSubtle":      boolProp,
+	"italic":        boolProp,
+	"selectAction":  actionProp,
+	"size":          sizeEnum,
+	"strikethrough": boolProp,
+	"underline":     boolProp,
+	"weight":        weightEnum,
+}
+
+func (v *validator) each(path string, value interface{}, check func(string, interface{})) {
+	items, ok := value.([]interface{})
+	if !ok {
+		v.problem(path, "must be an array")
+		return
+	}
+	for i, item := range items {
+		check(fmt.Sprintf("%s[%d]", path, i), item)
+	}
+}
+
+func inputPropsFor(t string) map[string]property {
+	if strings.HasPrefix(t, "Input.") {
+		return inputProps
+	}
+	return nil
+}
+
+// merge combines property sets; later sets win
+func merge(sets ...map[string]property) map[string]property {
+	merged := map[string]property{}
+	for _, set := range sets {
+		for name, prop := range set {
+			merged[name] = prop
+		}
+	}
+	return merged
+}
+
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/raja-aiml/webex-mcp-server/internal/app"
+)
+
+// ErrUsage signals that a command was invoked incorrectly; usage has already been printed
+var ErrUsage = errors.New("invalid usage")
+
+// Env carries process-level settings and output streams into commands
+type Env struct {
+	Name    string
+	Version string
+	Stdin   io.Reader
+	Stdout  io.Writer
+	Stderr  io.Writer
+}
+
+// Command is a subcommand of the server binary
+type Command struct {
+	Name    string
+	Summary string
+	Run     func(ctx context.Context, env *Env, args []string) error
+}
+
+var commands = map[string]*Command{}
+
+// register adds a command; called from init functions in this package
+func register(cmd *Command) {
+	commands[cmd.Name] = cmd
+}
+
+// Lookup returns the command with the given name
+func Lookup(name string) (*Command, bool) {
+	cmd, ok := commands[name]
+	return cmd, ok
+}
+
+// Commands returns all commands sorted by name
+func Commands() []*Command {
+	list := make([]*Command, 0, len(commands))
+	for _, cmd := range commands {
+		list = append(list, cmd)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// Execute runs cmd and returns the process exit code
+func Execute(ctx context.Context, cmd *Command, env *Env, args []string) int {
+	if err := cmd.Run(ctx, env, args); err != nil {
+		if errors.Is(err, ErrUsage) {
+			return 2
+		}
+		fmt.Fprintf(env.Stderr, "%s: %v\n", cmd.Name, err)
+		return 1
+	}
+	return 0
+}
+
+// PrintCommands writes the subcommand summary used in the top-level usage message
+func PrintCommands(w io.Writer) {
+	fmt.Fprintln(w, "Commands:")
+	for _, cmd := range Commands() {
+		fmt.Fprintf(w, "  %-12s %s\n", cmd.Name, cmd.Summary)
+	}
+}
+
+// BindServerFlags registers the flags shared by the server and commands that load its configuration
+func BindServerFlags(fs *flag.FlagSet, cfg *app.Config) {
+	fs.StringVar(&cfg.HTTPAddr, "http", "", "if set, use streamable HTTP at this address, instead of stdin/stdout")
+	fs.StringVar(&cfg.EnvPath, "env", "", "path to .env file. If not set, will try to load from current directory")
+	fs.StringVar(&cfg.ConfigFile, "config", "", "path to a YAML or JSON config file (default $WEBEX_MCP_CONFIG)")
+	fs.BoolVar(&cfg.UseAllTools, "all-tools", false, "load all tools including advanced ones")
+	fs.BoolVar(&cfg.SSEMode, "sse", false, "enable Server-Sent Events mode")
+	fs.StringVar(&cfg.LogLevel, "log-level", "", "log level: debug, info, warn or error")
+	fs.StringVar(&cfg.Profile, "profile", "", "default Webex profile from the config file (default $WEBEX_PROFILE)")
+}
+
+// newFlagSet creates a flag set for a command that reports errors instead of exiting
+func newFlagSet(env *Env, name, usage string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(env.Stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(env.Stderr, "Usage: %s %s\n", env.Name, usage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseFlags parses args; the flag package has already printed the problem and usage on error
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		return ErrUsage
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/raja-aiml/webex-mcp-server/internal/app"
+	"github.com/raja-aiml/webex-mcp-server/internal/config"
+	"github.com/raja-aiml/webex-mcp-server/internal/server"
+)
+
+func init() {
+	register(&Command{
+		Name:    "config",
+		Summary: "Print or validate the effective configuration",
+		Run:     runConfig,
+	})
+}
+
+func runConfig(ctx context.Context, env *Env, args []string) error {
+	if len(args) == 0 {
+		fmt.Fprintf(env.Stderr, "Usage: %s config <print|validate> [flags]\n", env.Name)
+		return ErrUsage
+	}
+
+	switch args[0] {
+	case "print":
+		return runConfigPrint(env, args[1:])
+	case "validate":
+		return runConfigValidate(env, args[1:])
+	default:
+		fmt.Fprintf(env.Stderr, "unknown config subcommand %q (want print or validate)\n", args[0])
+		return ErrUsage
+	}
+}
+
+// runConfigPrint prints the effective configuration with secrets redacted.
+// Validation problems are reported after the configuration so the output is still useful.
+func runConfigPrint(env *Env, args []string) error {
+	var showSecrets bool
+	cfg, err := loadCommandConfig(env, "config print [flags]", args, func(fs flagSet) {
+		fs.BoolVar(&showSecrets, "show-secrets", false, "print secrets instead of redacting them")
+	})
+	if cfg == nil {
+		return err
+	}
+
+	if cfg.Source != "" {
+		fmt.Fprintf(env.Stdout, "# source: %s\n", cfg.Source)
+	}
+	if writeErr := cfg.WriteYAML(env.Stdout, !showSecrets); writeErr != nil {
+		return writeErr
+	}
+	return err
+}
+
+// runConfigValidate loads the configuration and reports whether it is valid
+func runConfigValidate(env *Env, args []string) error {
+	cfg, err := loadCommandConfig(env, "config validate [flags]", args, nil)
+	if err != nil {
+		return err
+	}
+	if cfg.Source != "" {
+		fmt.Fprintf(env.Stdout, "%s: configuration is valid\n", cfg.Source)
+	} else {
+		fmt.Fprintln(env.Stdout, "configuration is valid")
+	}
+	return nil
+}
+
+// flagSet is the subset of flag.FlagSet used by extra flag registrations
+type flagSet interface {
+	BoolVar(p *bool, name string, value bool, usage string)
+	StringVar(p *string, name string, value string, usage string)
+	IntVar(p *int, name string, value int, usage string)
+}
+
+// loadCommandConfig parses the shared server flags plus any extra ones, loads the .env
+// file and returns the configuration. A non-nil config may accompany a validation error.
+func loadCommandConfig(env *Env, usage string, args []string, extra func(flagSet)) (*config.Config, error) {
+	var appCfg app.Config
+	fs := newFlagSet(env, "config", usage)
+	BindServerFlags(fs, &appCfg)
+	if extra != nil {
+		extra(fs)
+	}
+	if err := parseFlags(fs, args); err != nil {
+		return nil, err
+	}
+
+	if err := server.LoadEnvFile(appCfg.EnvPath); err != nil {
+		return nil, err
+	}
+	config.SetOptions(appCfg.ConfigOptions())
+	cfg, err := config.Load()
+
+	var validationErr *config.ValidationError
+	if err != nil && !errors.As(err, &validationErr) {
+		return nil, err
+	}
+	return cfg, err
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/raja-aiml/webex-mcp-server/internal/config"
+	"github.com/raja-aiml/webex-mcp-server/internal/testutil"
+)
+
+func runCommand(t *testing.T, name string, args ...string) (int, string, string) {
+	t.Helper()
+	cmd, ok := Lookup(name)
+	if !ok {
+		t.Fatalf("command %q not registered", name)
+	}
+	var stdout, stderr bytes.Buffer
+	env := &Env{Name: "webex-mcp-server", Version: "test", Stdout: &stdout, Stderr: &stderr}
+	code := Execute(context.Background(), cmd, env, args)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestConfigPrint(t *testing.T) {
+	cleanup := testutil.SetEnv(t, "WEBEX_PUBLIC_WORKSPACE_API_KEY", "")
+	defer func() {
+		cleanup()
+		config.ResetForTesting()
+	}()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := "auth:\n  token: file-secret\nserver:\n  mode: http\n"
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	code, stdout, stderr := runCommand(t, "config", "print", "-config", path, "-sse", "-all-tools", "-env", filepath.Join(t.TempDir(), ".env"))
+	if code != 0 {
+		t.Fatalf("exit code = %d, stderr = %s", code, stderr)
+	}
+	if strings.Contains(stdout, "file-secret") {
+		t.Errorf("output leaks token:\n%s", stdout)
+	}
+	for _, want := range []string{"# source: " + path, "mode: sse", "all: true", "[REDACTED]"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("output missing %q:\n%s", want, stdout)
+		}
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	cleanup := testutil.SetEnv(t, "WEBEX_PUBLIC_WORKSPACE_API_KEY", "")
+	defer func() {
+		cleanup()
+		config.ResetForTesting()
+	}()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("logging:\n  level: loud\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	code, _, stderr := runCommand(t, "config", "validate", "-config", path, "-env", filepath.Join(t.TempDir(), ".env"))
+	if code != 1 {
+		t.Fatalf("exit code = %d, want 1", code)
+	}
+	for _, want := range []string{"logging.level", "WEBEX_PUBLIC_WORKSPACE_API_KEY"} {
+		if !strings.Contains(stderr, want) {
+			t.Errorf("stderr missing %q:\n%s", want, stderr)
+		}
+	}
+
+	if code, _, _ := runCommand(t, "config", "bogus"); code != 2 {
+		t.Errorf("unknown subcommand exit code = %d, want 2", code)
+	}
+}
@@ -0,0 +1,293 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/raja-aiml/webex-mcp-server/internal/app"
+	"github.com/raja-aiml/webex-mcp-server/internal/config"
+	"github.com/raja-aiml/webex-mcp-server/internal/server"
+	"github.com/raja-aiml/webex-mcp-server/internal/webex"
+)
+
+func init() {
+	register(&Command{
+		Name:    "doctor",
+		Summary: "Check the setup and explain how to fix problems",
+		Run:     runDoctor,
+	})
+}
+
+// Check outcomes, from best to worst
+const (
+	statusPass = "pass"
+	statusWarn = "warn"
+	statusFail = "fail"
+	statusSkip = "skip" // An earlier failure made the check impossible
+)
+
+// maxClockSkew is the difference from Webex's clock above which the skew is reported
+const maxClockSkew = 30 * time.Second
+
+// webexTokenPattern matches Webex access tokens: a random part, the cluster and the org ID
+var webexTokenPattern = regexp.MustCompile(`^[A-Za-z0-9]+_[A-Za-z0-9]+_[0-9a-f]{8}(-[0-9a-f]{4}){3}-[0-9a-f]{12}$`)
+
+// scopeProbes are read-only requests that each need one token scope
+var scopeProbes = []struct {
+	endpoint string
+	scope    string
+}{
+	{"/rooms", "spark:rooms_read"},
+	{"/memberships", "spark:memberships_read"},
+	{"/teams", "spark:teams_read"},
+}
+
+// doctorCheck is one line of the doctor report
+type doctorCheck struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	Hint    string `json:"hint,omitempty"`
+}
+
+// doctorReport collects checks; later checks consult earlier results
+type doctorReport struct {
+	Checks []doctorCheck `json:"checks"`
+	OK     bool          `json:"ok"`
+}
+
+func (r *doctorReport) add(name, status, message, hint string) {
+	r.Checks = append(r.Checks, doctorCheck{Name: name, Status: status, Message: message, Hint: hint})
+}
+
+func (r *doctorReport) count(status string) int {
+	n := 0
+	for _, check := range r.Checks {
+		if check.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// runDoctor runs every check, prints the report and fails when any check failed
+func runDoctor(ctx context.Context, env *Env, args []string) error {
+	var appCfg app.Config
+	var asJSON bool
+	fs := newFlagSet(env, "doctor", "doctor [flags]")
+	BindServerFlags(fs, &appCfg)
+	fs.BoolVar(&asJSON, "json", false, "print the report as JSON")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	report := &doctorReport{}
+	checkEnvFile(report, appCfg.EnvPath)
+
+	config.SetOptions(appCfg.ConfigOptions())
+	initErr := server.InitializeConfig(appCfg.EnvPath)
+	cfg, _ := config.Load()
+	checkConfig(report, cfg, initErr)
+
+	if cfg != nil {
+		checkTokenFormat(report, cfg.WebexAPIKey)
+		checkTransport(report, cfg.Server)
+		reachable := checkBaseURL(ctx, report, cfg)
+		checkToken(report, cfg, reachable)
+	}
+
+	report.OK = report.count(statusFail) == 0
+	if asJSON {
+		if err := writeJSON(env.Stdout, report); err != nil {
+			return err
+		}
+	} else {
+		printDoctorReport(env, report)
+	}
+	if !report.OK {
+		return fmt.Errorf("%d check(s) failed", report.count(statusFail))
+	}
+	return nil
+}
+
+func printDoctorReport(env *Env, report *doctorReport) {
+	w := tabwriter.NewWriter(env.Stdout, 0, 0, 2, ' ', 0)
+	for _, check := range report.Checks {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", strings.ToUpper(check.Status), check.Name, check.Message)
+		if check.Hint != "" && check.Status != statusPass {
+			fmt.Fprintf(w, "\t\t→ %s\n", check.Hint)
+		}
+	}
+	w.Flush()
+	fmt.Fprintf(env.Stdout, "\n%d passed, %d warnings, %d failed, %d skipped\n",
+		report.count(statusPass), report.count(statusWarn), report.count(statusFail), report.count(statusSkip))
+}
+
+// checkEnvFile reports whether the .env file exists; it is optional unless named with -env
+func checkEnvFile(report *doctorReport, envPath string) {
+	path := server.EnvFilePath(envPath)
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) && envPath == "" {
+			report.add("env file", statusWarn, fmt.Sprintf("%s not found, using the process environment only", path),
+				"create .env with WEBEX_PUBLIC_WORKSPACE_API_KEY=..., or pass -env <path>")
+			return
+		}
+		report.add("env file", statusFail, err.Error(), "check the path given to -env")
+		return
+	}
+	report.add("env file", statusPass, "found "+path, "")
+}
+
+func checkConfig(report *doctorReport, cfg *config.Config, err error) {
+	if err != nil {
+		hint := "run 'config validate' for details; see CONFIG.md for every setting"
+		var validationErr *config.ValidationError
+		if !errors.As(err, &validationErr) {
+			hint = "fix the config file syntax or the secret reference it names"
+		}
+		report.add("configuration", statusFail, err.Error(), hint)
+		return
+	}
+	source := "environment and defaults"
+	if cfg.Source != "" {
+		source = cfg.Source
+	}
+	report.add("configuration", statusPass, "loaded from "+source, "")
+}
+
+// checkTokenFormat catches tokens damaged by copying before they are sent to Webex
+func checkTokenFormat(report *doctorReport, token string) {
+	switch {
+	case token == "":
+		report.add("token format", statusFail, "no token is configured",
+			"set WEBEX_PUBLIC_WORKSPACE_API_KEY or auth.token; get a token at https://developer.webex.com")
+	case strings.ContainsAny(token, " \t\r\n\"'"):
+		report.add("token format", statusFail, "token contains whitespace or quotes", "remove surrounding quotes and line breaks from the token")
+	case !webexTokenPattern.MatchString(token):
+		report.add("token format", statusWarn, "token does not look like a Webex access token",
+			"Webex tokens look like <random>_<cluster>_<org id>; check it was copied in full")
+	default:
+		report.add("token format", statusPass, "looks like a Webex access token", "")
+	}
+}
+
+func checkTransport(report *doctorReport, cfg config.ServerConfig) {
+	if cfg.Mode == config.ModeStdio {
+		report.add("transport", statusPass, "stdio (for MCP clients that start the server)", "")
+		return
+	}
+
+	scheme := "http"
+	if cfg.TLS.CertFile != "" {
+		scheme = "https"
+	}
+	message := fmt.Sprintf("%s on %s (%s, streamable HTTP and SSE)", cfg.Mode, cfg.Addr, scheme)
+	if _, unix := cfg.UnixSocketPath(); scheme == "http" && !unix && !isLoopbackAddr(cfg.Addr) {
+		report.add("transport", statusWarn, message+" without TLS on a network address",
+			"set server.tls.cert_file and key_file, or listen on 127.0.0.1 or a unix:// socket")
+		return
+	}
+	report.add("transport", statusPass, message, "")
+}
+
+func isLoopbackAddr(addr string) bool {
+	return strings.HasPrefix(addr, "127.") || strings.HasPrefix(addr, "localhost:") || strings.HasPrefix(addr, "[::1]:")
+}
+
+// checkBaseURL makes an unauthenticated request to the API base URL and compares clocks
+// using the Date header. Any HTTP response means the API is reachable.
+func checkBaseURL(ctx context.Context, report *doctorReport, cfg *config.Config) bool {
+	client, err := webex.NewHTTPClient(cfg.HTTP)
+	if err != nil {
+		report.add("base URL", statusFail, err.Error(), "check the WEBEX_HTTP_*, WEBEX_CA_CERT_FILE and client certificate settings")
+		report.add("clock", statusSkip, "needs a reachable API", "")
+		return false
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, cfg.WebexAPIBaseURL, nil)
+	if err != nil {
+		report.add("base URL", statusFail, err.Error(), "set WEBEX_API_BASE_URL to a URL such as https://webexapis.com/v1")
+		report.add("clock", statusSkip, "needs a reachable API", "")
+		return false
+	}
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		report.add("base URL", statusFail, fmt.Sprintf("%s is unreachable: %v", cfg.WebexAPIBaseURL, err),
+			"check network access, HTTPS_PROXY or WEBEX_HTTP_PROXY, and WEBEX_CA_CERT_FILE for TLS-intercepting proxies")
+		report.add("clock", statusSkip, "needs a reachable API", "")
+		return false
+	}
+	resp.Body.Close()
+	report.add("base URL", statusPass, fmt.Sprintf("%s answered in %s", cfg.WebexAPIBaseURL, time.Since(start).Round(time.Millisecond)), "")
+
+	date, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		report.add("clock", statusSkip, "the API did not send a Date header", "")
+		return true
+	}
+	// Date has one-second resolution, so compare against the middle of the request
+	skew := start.Add(time.Since(start) / 2).Sub(date).Round(time.Second)
+	if skew > maxClockSkew || skew < -maxClockSkew {
+		report.add("clock", statusWarn, fmt.Sprintf("local clock is %s off from the API", skew),
+			"enable NTP; large skew breaks TLS and OAuth token checks")
+		return true
+	}
+	report.add("clock", statusPass, fmt.Sprintf("within %s of the API", maxClockSkew), "")
+	return true
+}
+
+// checkToken asks Webex who the token belongs to, then probes read scopes.
+// Webex has no endpoint that lists a token's scopes, so each probe infers one from a 403.
+func checkToken(report *doctorReport, cfg *config.Config, reachable bool) {
+	if !reachable || cfg.WebexAPIKey == "" {
+		report.add("token", statusSkip, "needs a token and a reachable API", "")
+		report.add("scopes", statusSkip, "needs a valid token", "")
+		return
+	}
+
+	client, err := webex.NewClientWithConfig(cfg)
+	if err != nil {
+		report.add("token", statusFail, err.Error(), "")
+		report.add("scopes", statusSkip, "needs a valid token", "")
+		return
+	}
+	me, err := client.Get("/people/me", nil)
+	if err != nil {
+		hint := ""
+		var apiErr *webex.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnauthorized {
+			hint = "the token is expired, revoked or for another environment; personal tokens last 12 hours, so create a new one or use a bot token"
+		}
+		report.add("token", statusFail, err.Error(), hint)
+		report.add("scopes", statusSkip, "needs a valid token", "")
+		return
+	}
+	name, _ := me["displayName"].(string)
+	kind, _ := me["type"].(string)
+	report.add("token", statusPass, fmt.Sprintf("authenticated as %s (%s)", name, kind), "")
+
+	var missing []string
+	for _, probe := range scopeProbes {
+		_, err := client.Get(probe.endpoint, map[string]string{"max": "1"})
+		var apiErr *webex.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusForbidden {
+			missing = append(missing, probe.scope)
+		}
+	}
+	if len(missing) > 0 {
+		report.add("scopes", statusWarn, "missing "+strings.Join(missing, ", "),
+			"tools that need these scopes will fail; grant them to the integration or use a broader token")
+		return
+	}
+	report.add("scopes", statusPass, "read access to rooms, memberships and teams", "")
+}
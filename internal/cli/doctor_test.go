@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/raja-aiml/webex-mcp-server/internal/config"
+	"github.com/raja-aiml/webex-mcp-server/internal/testutil"
+)
+
+const doctorTestToken = "ZmFrZXRva2Vu_PF84_1eb65fdf-9643-417f-9974-ad72cae0e10f"
+
+func TestDoctor(t *testing.T) {
+	tokenStatus := http.StatusOK
+	webexServer := testutil.MockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Header.Get("Authorization") == "":
+			testutil.JSONResponse(w, http.StatusUnauthorized, map[string]interface{}{"message": "missing token"})
+		case tokenStatus != http.StatusOK:
+			testutil.JSONResponse(w, tokenStatus, map[string]interface{}{"message": "invalid token"})
+		case r.URL.Path == "/people/me":
+			testutil.JSONResponse(w, http.StatusOK, map[string]interface{}{"displayName": "Doctor Bot", "type": "bot"})
+		case r.URL.Path == "/teams":
+			testutil.JSONResponse(w, http.StatusForbidden, map[string]interface{}{"message": "missing scope"})
+		default:
+			testutil.JSONResponse(w, http.StatusOK, map[string]interface{}{"items": []interface{}{}})
+		}
+	})
+	defer webexServer.Close()
+
+	cleanups := []func(){
+		testutil.SetEnv(t, "WEBEX_PUBLIC_WORKSPACE_API_KEY", doctorTestToken),
+		testutil.SetEnv(t, "WEBEX_API_BASE_URL", webexServer.URL),
+	}
+	defer func() {
+		for _, cleanup := range cleanups {
+			cleanup()
+		}
+		config.ResetForTesting()
+	}()
+	missingEnv := filepath.Join(t.TempDir(), ".env")
+
+	config.ResetForTesting()
+	code, stdout, stderr := runCommand(t, "doctor", "-json", "-http", "127.0.0.1:3001")
+	if code != 0 {
+		t.Fatalf("doctor exit code = %d, stderr = %s\n%s", code, stderr, stdout)
+	}
+	var report doctorReport
+	if err := json.Unmarshal([]byte(stdout), &report); err != nil {
+		t.Fatalf("doctor -json output is not JSON: %v\n%s", err, stdout)
+	}
+	statuses := map[string]string{}
+	for _, check := range report.Checks {
+		statuses[check.Name] = check.Status + ": " + check.Message
+	}
+	for name, want := range map[string]string{
+		"configuration": "pass",
+		"token format":  "pass",
+		"transport":     "pass: http on 127.0.0.1:3001",
+		"base URL":      "pass",
+		"clock":         "pass",
+		"token":         "pass: authenticated as Doctor Bot (bot)",
+		"scopes":        "warn: missing spark:teams_read",
+	} {
+		if !strings.HasPrefix(statuses[name], want) {
+			t.Errorf("check %s = %q, want prefix %q", name, statuses[name], want)
+		}
+	}
+	if !report.OK {
+		t.Error("report.OK = false, want true with warnings only")
+	}
+
+	// A rejected token fails the report and skips the scope probes
+	tokenStatus = http.StatusUnauthorized
+	config.ResetForTesting()
+	code, stdout, _ = runCommand(t, "doctor", "-env", missingEnv)
+	if code != 1 {
+		t.Fatalf("doctor exit code = %d, want 1\n%s", code, stdout)
+	}
+	for _, want := range []string{"FAIL  env file", "FAIL  token ", "personal tokens last 12 hours", "SKIP  scopes", "2 failed, 1 skipped"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("report missing %q:\n%s", want, stdout)
+		}
+	}
+}
+
+func TestCheckTokenFormat(t *testing.T) {
+	tests := []struct {
+		token string
+		want  string
+	}{
+		{doctorTestToken, statusPass},
+		{"", statusFail},
+		{`"` + doctorTestToken + `"`, statusFail},
+		{"abc def", statusFail},
+		{"ZmFrZXRva2Vu_PF84", statusWarn},
+	}
+	for _, tt := range tests {
+		report := &doctorReport{}
+		checkTokenFormat(report, tt.token)
+		if got := report.Checks[0].Status; got != tt.want {
+			t.Errorf("checkTokenFormat(%q) = %s (%s), want %s", tt.token, got, report.Checks[0].Message, tt.want)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/raja-aiml/webex-mcp-server/internal/fakewebex"
+)
+
+func init() {
+	register(&Command{
+		Name:    "fake-webex",
+		Summary: "Serve an in-memory fake of the Webex API for offline use",
+		Run:     runFakeWebex,
+	})
+}
+
+// runFakeWebex serves the fake until interrupted; point WEBEX_API_BASE_URL at it
+func runFakeWebex(ctx context.Context, env *Env, args []string) error {
+	fs := newFlagSet(env, "fake-webex", "fake-webex [flags]")
+	addr := fs.String("addr", "127.0.0.1:8089", "listen address")
+	token := fs.String("token", "", "only accept this bearer token (default: accept any token)")
+	seed := fs.Bool("seed", true, "start with demo rooms, people and messages")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	fake := fakewebex.New()
+	fake.SetToken(*token)
+	if *seed {
+		if err := fake.Seed(); err != nil {
+			return fmt.Errorf("failed to seed demo data: %w", err)
+		}
+	}
+
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return err
+	}
+	httpServer := &http.Server{Handler: fake, ReadHeaderTimeout: 2 * time.Second}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		httpServer.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Fprintf(env.Stdout, "Fake Webex API listening; use it with:\n  export WEBEX_API_BASE_URL=http://%s/v1\n", listener.Addr())
+	if *token == "" {
+		fmt.Fprintln(env.Stdout, "  export WEBEX_PUBLIC_WORKSPACE_API_KEY=fake-token")
+	}
+	if err := httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
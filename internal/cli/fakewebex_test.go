@@ -0,0 +1,27 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestFakeWebex(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel() // Serve until the already cancelled context is noticed
+
+	cmd, _ := Lookup("fake-webex")
+	var stdout, stderr bytes.Buffer
+	env := &Env{Name: "webex-mcp-server", Stdout: &stdout, Stderr: &stderr}
+	if code := Execute(ctx, cmd, env, []string{"-addr", "127.0.0.1:0"}); code != 0 {
+		t.Fatalf("exit code = %d, stderr = %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "export WEBEX_API_BASE_URL=http://127.0.0.1:") {
+		t.Errorf("output = %q", stdout.String())
+	}
+
+	if code := Execute(context.Background(), cmd, env, []string{"-addr", "not-an-address"}); code != 1 {
+		t.Errorf("bad address exit code = %d, want 1", code)
+	}
+}
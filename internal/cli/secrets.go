@@ -0,0 +1,137 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/raja-aiml/webex-mcp-server/internal/config"
+	"github.com/raja-aiml/webex-mcp-server/internal/secrets"
+	"github.com/raja-aiml/webex-mcp-server/internal/server"
+)
+
+func init() {
+	register(&Command{
+		Name:    "secrets",
+		Summary: "Manage the encrypted secrets file",
+		Run:     runSecrets,
+	})
+}
+
+func runSecrets(ctx context.Context, env *Env, args []string) error {
+	if len(args) == 0 {
+		fmt.Fprintf(env.Stderr, "Usage: %s secrets <set|list|delete> [flags] [name]\n", env.Name)
+		return ErrUsage
+	}
+
+	switch args[0] {
+	case "set":
+		return runSecretsSet(env, args[1:])
+	case "list":
+		return runSecretsList(env, args[1:])
+	case "delete":
+		return runSecretsDelete(env, args[1:])
+	default:
+		fmt.Fprintf(env.Stderr, "unknown secrets subcommand %q (want set, list or delete)\n", args[0])
+		return ErrUsage
+	}
+}
+
+// runSecretsSet stores a secret read from stdin so it never appears in shell history or process listings
+func runSecretsSet(env *Env, args []string) error {
+	store, fs, err := openSecretsStore(env, "secrets set [flags] [name]", args)
+	if store == nil {
+		return err
+	}
+
+	name := config.DefaultTokenSecret
+	if fs.NArg() > 0 {
+		name = fs.Arg(0)
+	}
+
+	if env.Stdin == nil {
+		return fmt.Errorf("no input: pipe the secret value on stdin")
+	}
+	data, err := io.ReadAll(env.Stdin)
+	if err != nil {
+		return fmt.Errorf("failed to read secret from stdin: %w", err)
+	}
+	value := strings.TrimRight(string(data), "\r\n")
+	if value == "" {
+		return fmt.Errorf("secret value is empty")
+	}
+
+	store.Set(name, value)
+	if err := store.Save(); err != nil {
+		return err
+	}
+	fmt.Fprintf(env.Stdout, "stored secret %q\n", name)
+	return nil
+}
+
+// runSecretsList prints secret names; values are never printed
+func runSecretsList(env *Env, args []string) error {
+	store, _, err := openSecretsStore(env, "secrets list [flags]", args)
+	if store == nil {
+		return err
+	}
+	for _, name := range store.Names() {
+		fmt.Fprintln(env.Stdout, name)
+	}
+	return nil
+}
+
+// runSecretsDelete removes a secret from the file
+func runSecretsDelete(env *Env, args []string) error {
+	store, fs, err := openSecretsStore(env, "secrets delete [flags] <name>", args)
+	if store == nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return ErrUsage
+	}
+
+	if !store.Delete(fs.Arg(0)) {
+		return fmt.Errorf("secret %q not found", fs.Arg(0))
+	}
+	if err := store.Save(); err != nil {
+		return err
+	}
+	fmt.Fprintf(env.Stdout, "deleted secret %q\n", fs.Arg(0))
+	return nil
+}
+
+// openSecretsStore parses the shared secrets flags and opens the store they select
+func openSecretsStore(env *Env, usage string, args []string) (*secrets.Store, *flag.FlagSet, error) {
+	var file, envPath string
+	fs := newFlagSet(env, "secrets", usage)
+	fs.StringVar(&file, "file", "", "path to the secrets file (default $WEBEX_SECRETS_FILE)")
+	fs.StringVar(&envPath, "env", "", "path to .env file. If not set, will try to load from current directory")
+	if err := parseFlags(fs, args); err != nil {
+		return nil, fs, err
+	}
+
+	if err := server.LoadEnvFile(envPath); err != nil {
+		return nil, fs, err
+	}
+	if file == "" {
+		file = os.Getenv("WEBEX_SECRETS_FILE")
+	}
+	if file == "" {
+		return nil, fs, fmt.Errorf("no secrets file: pass -file or set WEBEX_SECRETS_FILE")
+	}
+
+	passphrase, err := config.SecretsPassphrase()
+	if err != nil {
+		return nil, fs, err
+	}
+	store, err := secrets.OpenStore(file, passphrase)
+	if err != nil {
+		return nil, fs, err
+	}
+	return store, fs, nil
+}
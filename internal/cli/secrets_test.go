@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/raja-aiml/webex-mcp-server/internal/secrets"
+	"github.com/raja-aiml/webex-mcp-server/internal/testutil"
+)
+
+func TestSecrets(t *testing.T) {
+	cleanups := []func(){
+		testutil.SetEnv(t, "WEBEX_SECRETS_FILE", filepath.Join(t.TempDir(), "secrets.json")),
+		testutil.SetEnv(t, "WEBEX_SECRETS_PASSPHRASE", "passphrase"),
+	}
+	defer func() {
+		for _, cleanup := range cleanups {
+			cleanup()
+		}
+	}()
+	envFile := filepath.Join(t.TempDir(), ".env")
+
+	cmd, _ := Lookup("secrets")
+	var stdout, stderr bytes.Buffer
+	env := &Env{Name: "webex-mcp-server", Version: "test", Stdin: strings.NewReader("stored-token-value\n"), Stdout: &stdout, Stderr: &stderr}
+	if code := Execute(context.Background(), cmd, env, []string{"set", "-env", envFile}); code != 0 {
+		t.Fatalf("secrets set exit code = %d, stderr = %s", code, stderr.String())
+	}
+
+	code, stdout2, stderr2 := runCommand(t, "secrets", "list", "-env", envFile)
+	if code != 0 {
+		t.Fatalf("secrets list exit code = %d, stderr = %s", code, stderr2)
+	}
+	if stdout2 != "webex_token\n" {
+		t.Errorf("secrets list output = %q, want webex_token", stdout2)
+	}
+
+	store, err := secrets.OpenStore(os.Getenv("WEBEX_SECRETS_FILE"), "passphrase")
+	if err != nil {
+		t.Fatalf("OpenStore() error = %v", err)
+	}
+	if value, _ := store.Get("webex_token"); value != "stored-token-value" {
+		t.Errorf("stored value = %q, want stored-token-value", value)
+	}
+
+	if code, _, _ := runCommand(t, "secrets", "delete", "-env", envFile, "webex_token"); code != 0 {
+		t.Errorf("secrets delete exit code = %d", code)
+	}
+	if code, _, stderr := runCommand(t, "secrets", "delete", "-env", envFile, "webex_token"); code != 1 || !strings.Contains(stderr, "not found") {
+		t.Errorf("deleting a missing secret: code = %d, stderr = %s", code, stderr)
+	}
+	if code, _, _ := runCommand(t, "secrets", "bogus"); code != 2 {
+		t.Errorf("unknown subcommand exit code = %d, want 2", code)
+	}
+}
@@ -0,0 +1,211 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/raja-aiml/webex-mcp-server/internal/config"
+	"github.com/raja-aiml/webex-mcp-server/internal/server"
+	"github.com/raja-aiml/webex-mcp-server/internal/tools"
+)
+
+func init() {
+	register(&Command{
+		Name:    "tools",
+		Summary: "List, describe or call tools without an MCP client",
+		Run:     runTools,
+	})
+}
+
+func runTools(ctx context.Context, env *Env, args []string) error {
+	if len(args) == 0 {
+		fmt.Fprintf(env.Stderr, "Usage: %s tools <list|describe|call> [args] [flags]\n", env.Name)
+		return ErrUsage
+	}
+
+	switch args[0] {
+	case "list":
+		return runToolsList(env, args[1:])
+	case "describe":
+		return runToolsDescribe(env, args[1:])
+	case "call":
+		return runToolsCall(env, args[1:])
+	default:
+		fmt.Fprintf(env.Stderr, "unknown tools subcommand %q (want list, describe or call)\n", args[0])
+		return ErrUsage
+	}
+}
+
+// runToolsList prints the tools the server would expose with the same flags and configuration
+func runToolsList(env *Env, args []string) error {
+	var asJSON bool
+	registry, err := loadCommandTools(env, "tools list [flags]", args, false, func(fs flagSet) {
+		fs.BoolVar(&asJSON, "json", false, "print the full tool definitions as JSON")
+	})
+	if err != nil {
+		return err
+	}
+
+	var definitions []*mcp.Tool
+	for _, tool := range registry.GetTools() {
+		definition, err := server.ToolDefinition(tool)
+		if err != nil {
+			fmt.Fprintf(env.Stderr, "skipping %s: %v\n", tool.Name(), err)
+			continue
+		}
+		definitions = append(definitions, definition)
+	}
+	sort.Slice(definitions, func(i, j int) bool { return definitions[i].Name < definitions[j].Name })
+
+	if asJSON {
+		return writeJSON(env.Stdout, definitions)
+	}
+	w := tabwriter.NewWriter(env.Stdout, 0, 0, 2, ' ', 0)
+	for _, definition := range definitions {
+		summary, _, _ := strings.Cut(definition.Description, "\n")
+		fmt.Fprintf(w, "%s\t%s\n", definition.Name, summary)
+	}
+	return w.Flush()
+}
+
+// runToolsDescribe prints the input schema of a tool
+func runToolsDescribe(env *Env, args []string) error {
+	name, args := splitName(args)
+	const usage = "tools describe <name> [flags]"
+	registry, err := loadCommandTools(env, usage, args, false, nil)
+	if err != nil {
+		return err
+	}
+	tool, err := lookupTool(env, usage, registry, name)
+	if err != nil {
+		return err
+	}
+
+	definition, err := server.ToolDefinition(tool)
+	if err != nil {
+		return err
+	}
+	return writeJSON(env.Stdout, definition.InputSchema)
+}
+
+// runToolsCall executes a tool once and prints its result the way an MCP client would receive it
+func runToolsCall(env *Env, args []string) error {
+	name, args := splitName(args)
+	const usage = "tools call <name> [-args JSON] [flags]"
+	var argsJSON string
+	var asJSON bool
+	registry, err := loadCommandTools(env, usage, args, true, func(fs flagSet) {
+		fs.StringVar(&argsJSON, "args", "{}", "tool arguments as a JSON object, or - to read them from stdin")
+		fs.BoolVar(&asJSON, "json", false, "print the MCP CallToolResult as JSON")
+	})
+	if err != nil {
+		return err
+	}
+	tool, err := lookupTool(env, usage, registry, name)
+	if err != nil {
+		return err
+	}
+
+	if argsJSON == "-" {
+		if env.Stdin == nil {
+			return fmt.Errorf("no input: pipe the arguments on stdin")
+		}
+		data, err := io.ReadAll(env.Stdin)
+		if err != nil {
+			return fmt.Errorf("failed to read arguments from stdin: %w", err)
+		}
+		argsJSON = string(data)
+	}
+	var arguments map[string]any
+	if err := json.Unmarshal([]byte(argsJSON), &arguments); err != nil {
+		return fmt.Errorf("invalid -args: want a JSON object: %w", err)
+	}
+	raw, err := json.Marshal(arguments)
+	if err != nil {
+		return err
+	}
+
+	result := server.ExecuteTool(tool, raw)
+	if asJSON {
+		if err := writeJSON(env.Stdout, result); err != nil {
+			return err
+		}
+		if result.IsError {
+			return fmt.Errorf("tool %s failed", name)
+		}
+		return nil
+	}
+
+	text := resultText(result)
+	if result.IsError {
+		return errors.New(text)
+	}
+	fmt.Fprintln(env.Stdout, text)
+	return nil
+}
+
+// loadCommandTools loads the configuration and the tools it selects. Listing and describing
+// tools does not need a Webex token, so validation errors are only fatal when requireValid is set.
+func loadCommandTools(env *Env, usage string, args []string, requireValid bool, extra func(flagSet)) (*tools.Registry, error) {
+	cfg, err := loadCommandConfig(env, usage, args, extra)
+	if cfg == nil {
+		return nil, err
+	}
+	var validationErr *config.ValidationError
+	if err != nil && (requireValid || !errors.As(err, &validationErr)) {
+		return nil, err
+	}
+	return tools.LoadConfiguredTools(cfg)
+}
+
+// lookupTool finds a tool by name, reporting a missing name as a usage error
+func lookupTool(env *Env, usage string, registry *tools.Registry, name string) (tools.Tool, error) {
+	if name == "" {
+		fmt.Fprintf(env.Stderr, "Usage: %s %s\n", env.Name, usage)
+		return nil, ErrUsage
+	}
+	tool, ok := registry.GetTool(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown tool %q (see 'tools list'; advanced tools need -all-tools)", name)
+	}
+	return tool, nil
+}
+
+// splitName takes a leading tool name so flags may follow it
+func splitName(args []string) (string, []string) {
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		return args[0], args[1:]
+	}
+	return "", args
+}
+
+// resultText joins the text content of a tool result; other content is printed as JSON
+func resultText(result *mcp.CallToolResult) string {
+	var parts []string
+	for _, content := range result.Content {
+		if text, ok := content.(*mcp.TextContent); ok {
+			parts = append(parts, text.Text)
+			continue
+		}
+		data, err := json.Marshal(content)
+		if err != nil {
+			parts = append(parts, fmt.Sprintf("<unprintable content: %v>", err))
+			continue
+		}
+		parts = append(parts, string(data))
+	}
+	return strings.Join(parts, "\n")
+}
+
+func writeJSON(w io.Writer, v any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/raja-aiml/webex-mcp-server/internal/config"
+	"github.com/raja-aiml/webex-mcp-server/internal/fakewebex"
+	"github.com/raja-aiml/webex-mcp-server/internal/testutil"
+	"github.com/raja-aiml/webex-mcp-server/internal/tools"
+)
+
+func TestTools(t *testing.T) {
+	fake, baseURL := testutil.NewFakeWebex(t)
+	room, err := fake.Add(fakewebex.Rooms, fakewebex.Item{"title": "CLI"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	roomID := room["id"].(string)
+
+	cleanups := []func(){
+		testutil.SetEnv(t, "WEBEX_PUBLIC_WORKSPACE_API_KEY", ""),
+		testutil.SetEnv(t, "WEBEX_API_BASE_URL", baseURL),
+	}
+	defer func() {
+		for _, cleanup := range cleanups {
+			cleanup()
+		}
+		config.ResetForTesting()
+		tools.ResetDefaultClient()
+	}()
+	envFile := filepath.Join(t.TempDir(), ".env")
+
+	// Listing and describing work without a token
+	code, stdout, stderr := runCommand(t, "tools", "list", "-env", envFile)
+	if code != 0 {
+		t.Fatalf("tools list exit code = %d, stderr = %s", code, stderr)
+	}
+	if !strings.Contains(stdout, "create_a_message") || strings.Contains(stdout, "create_a_team ") {
+		t.Errorf("tools list should show core tools only:\n%s", stdout)
+	}
+
+	config.ResetForTesting()
+	code, stdout, stderr = runCommand(t, "tools", "describe", "create_a_message", "-env", envFile)
+	if code != 0 {
+		t.Fatalf("tools describe exit code = %d, stderr = %s", code, stderr)
+	}
+	var schema map[string]interface{}
+	if err := json.Unmarshal([]byte(stdout), &schema); err != nil {
+		t.Fatalf("tools describe output is not JSON: %v\n%s", err, stdout)
+	}
+	if _, ok := schema["properties"].(map[string]interface{})["roomId"]; !ok {
+		t.Errorf("schema has no roomId property:\n%s", stdout)
+	}
+
+	config.ResetForTesting()
+	if code, _, stderr := runCommand(t, "tools", "describe", "no_such_tool", "-env", envFile); code != 1 || !strings.Contains(stderr, "unknown tool") {
+		t.Errorf("describe unknown tool = %d, %s", code, stderr)
+	}
+
+	// Calling a tool needs a valid configuration
+	config.ResetForTesting()
+	if code, _, stderr := runCommand(t, "tools", "call", "create_a_message", "-env", envFile); code != 1 || !strings.Contains(stderr, "WEBEX_PUBLIC_WORKSPACE_API_KEY") {
+		t.Errorf("call without a token = %d, %s", code, stderr)
+	}
+
+	cleanups = append(cleanups, testutil.SetEnv(t, "WEBEX_PUBLIC_WORKSPACE_API_KEY", "test-token"))
+	config.ResetForTesting()
+	tools.ResetDefaultClient()
+	code, stdout, stderr = runCommand(t, "tools", "call", "create_a_message", "--args", `{"roomId":"`+roomID+`","text":"hello"}`, "-env", envFile)
+	if code != 0 {
+		t.Fatalf("tools call exit code = %d, stderr = %s", code, stderr)
+	}
+	messages := fake.List(fakewebex.Messages)
+	if len(messages) != 1 || messages[0]["text"] != "hello" {
+		t.Fatalf("messages = %v", messages)
+	}
+	if !strings.Contains(stdout, `"id": "`+messages[0]["id"].(string)+`"`) {
+		t.Errorf("tools call output:\n%s", stdout)
+	}
+
+	config.ResetForTesting()
+	if code, _, stderr := runCommand(t, "tools", "call", "create_a_message", "-args", "[1]", "-env", envFile); code != 1 || !strings.Contains(stderr, "JSON object") {
+		t.Errorf("call with invalid args = %d, %s", code, stderr)
+	}
+	if code, _, _ := runCommand(t, "tools", "call"); code != 2 {
+		t.Errorf("call without a name exit code = %d, want 2", code)
+	}
+}
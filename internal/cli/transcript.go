@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/raja-aiml/webex-mcp-server/internal/transcript"
+	"github.com/raja-aiml/webex-mcp-server/internal/webex"
+)
+
+func init() {
+	register(&Command{
+		Name:    "export-transcript",
+		Summary: "Export the message history of a room as Markdown, JSON Lines or HTML",
+		Run:     runExportTranscript,
+	})
+}
+
+// runExportTranscript writes a room transcript to -o, the configured export directory or stdout
+func runExportTranscript(ctx context.Context, env *Env, args []string) error {
+	roomID, args := splitName(args)
+	const usage = "export-transcript <roomId> [-format markdown|jsonl|html] [-from TIME] [-to TIME] [-o FILE] [flags]"
+	var format, from, to, output string
+	var maxMessages int
+	cfg, err := loadCommandConfig(env, usage, args, func(fs flagSet) {
+		fs.StringVar(&format, "format", transcript.FormatMarkdown, "output format: "+strings.Join(transcript.Formats, ", "))
+		fs.StringVar(&from, "from", "", "only messages sent at or after this time (RFC 3339 or YYYY-MM-DD)")
+		fs.StringVar(&to, "to", "", "only messages sent before this time (RFC 3339 or YYYY-MM-DD)")
+		fs.IntVar(&maxMessages, "max", 0, fmt.Sprintf("keep at most this many of the newest messages (default %d)", transcript.DefaultMaxMessages))
+		fs.StringVar(&output, "o", "", "output file, or - for stdout (default: the export directory when configured, else stdout)")
+	})
+	if err != nil {
+		return err
+	}
+	if roomID == "" {
+		fmt.Fprintf(env.Stderr, "Usage: %s %s\n", env.Name, usage)
+		return ErrUsage
+	}
+	if !slices.Contains(transcript.Formats, format) {
+		return fmt.Errorf("format must be one of %s", strings.Join(transcript.Formats, ", "))
+	}
+	opts := transcript.Options{MaxMessages: maxMessages}
+	if opts.From, err = transcript.ParseTime(from); err != nil {
+		return err
+	}
+	if opts.To, err = transcript.ParseTime(to); err != nil {
+		return err
+	}
+
+	client, err := webex.NewClientWithConfig(cfg)
+	if err != nil {
+		return err
+	}
+	t, err := transcript.Fetch(client, roomID, opts)
+	if err != nil {
+		return err
+	}
+	if t.Truncated {
+		fmt.Fprintf(env.Stderr, "warning: kept the newest %d messages; use -from or -max to export the rest\n", t.Count)
+	}
+
+	switch {
+	case output == "-" || output == "" && cfg.Export.Dir == "":
+		return transcript.Render(env.Stdout, t, format)
+	case output == "":
+		path, err := transcript.WriteFile(cfg.Export.Dir, t, format, time.Now())
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(env.Stdout, "Exported %d messages to %s\n", t.Count, path)
+		return nil
+	default:
+		file, err := os.OpenFile(output, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+		if err != nil {
+			return err
+		}
+		if err := transcript.Render(file, t, format); err != nil {
+			file.Close()
+			return err
+		}
+		if err := file.Close(); err != nil {
+			return err
+		}
+		fmt.Fprintf(env.Stdout, "Exported %d messages to %s\n", t.Count, output)
+		return nil
+	}
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/raja-aiml/webex-mcp-server/internal/config"
+	"github.com/raja-aiml/webex-mcp-server/internal/fakewebex"
+	"github.com/raja-aiml/webex-mcp-server/internal/testutil"
+)
+
+func TestExportTranscript(t *testing.T) {
+	fake, baseURL := testutil.NewFakeWebex(t)
+	room, err := fake.Add(fakewebex.Rooms, fakewebex.Item{"title": "Weekly Sync"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	roomID := room["id"].(string)
+	for _, text := range []string{"first", "second"} {
+		if _, err := fake.Add(fakewebex.Messages, fakewebex.Item{"roomId": roomID, "text": text}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	exportDir := filepath.Join(t.TempDir(), "exports")
+	cleanups := []func(){
+		testutil.SetEnv(t, "WEBEX_PUBLIC_WORKSPACE_API_KEY", "test-token"),
+		testutil.SetEnv(t, "WEBEX_API_BASE_URL", baseURL),
+		testutil.SetEnv(t, "WEBEX_EXPORT_DIR", ""),
+	}
+	defer func() {
+		for _, cleanup := range cleanups {
+			cleanup()
+		}
+		config.ResetForTesting()
+	}()
+	envFile := filepath.Join(t.TempDir(), ".env")
+
+	// Without an export directory the transcript goes to stdout
+	config.ResetForTesting()
+	code, stdout, stderr := runCommand(t, "export-transcript", roomID, "-env", envFile)
+	if code != 0 {
+		t.Fatalf("exit code = %d, stderr = %s", code, stderr)
+	}
+	if !strings.HasPrefix(stdout, "# Weekly Sync") || strings.Index(stdout, "first") > strings.Index(stdout, "second") {
+		t.Errorf("Markdown transcript:\n%s", stdout)
+	}
+
+	output := filepath.Join(t.TempDir(), "sync.jsonl")
+	config.ResetForTesting()
+	if code, _, stderr := runCommand(t, "export-transcript", roomID, "-format", "jsonl", "-o", output, "-env", envFile); code != 0 {
+		t.Fatalf("export to a file exit code = %d, stderr = %s", code, stderr)
+	}
+	if data, err := os.ReadFile(output); err != nil || strings.Count(string(data), "\n") != 2 {
+		t.Errorf("JSONL file = %q, %v", data, err)
+	}
+
+	cleanups = append(cleanups, testutil.SetEnv(t, "WEBEX_EXPORT_DIR", exportDir))
+	config.ResetForTesting()
+	code, stdout, stderr = runCommand(t, "export-transcript", roomID, "-format", "html", "-env", envFile)
+	if code != 0 {
+		t.Fatalf("export to the directory exit code = %d, stderr = %s", code, stderr)
+	}
+	files, _ := filepath.Glob(filepath.Join(exportDir, "weekly-sync-*.html"))
+	if len(files) != 1 || !strings.Contains(stdout, files[0]) {
+		t.Errorf("exported files = %v, stdout = %s", files, stdout)
+	}
+
+	config.ResetForTesting()
+	if code, _, stderr := runCommand(t, "export-transcript", roomID, "-format", "pdf", "-env", envFile); code != 1 || !strings.Contains(stderr, "format") {
+		t.Errorf("unknown format = %d, %s", code, stderr)
+	}
+	config.ResetForTesting()
+	if code, _, _ := runCommand(t, "export-transcript", "-env", envFile); code != 2 {
+		t.Errorf("missing room exit code = %d, want 2", code)
+	}
+}
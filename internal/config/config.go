@@ -3,8 +3,12 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
+
+	"github.com/raja-aiml/webex-mcp-server/internal/secrets"
 )
 
 type Config struct {
@@ -13,43 +17,307 @@ type Config struct {
 	WebexAPIBaseURL string
 	Port            string
 	NodeEnv         string // For environment detection
+	HTTP            HTTPConfig
+	Server          ServerConfig
+	Tools           ToolsConfig
+	Logging         LoggingConfig
+	Reload          ReloadConfig
+	Limits          LimitsConfig
+	Export          ExportConfig
+	Search          SearchConfig
+	Sync            SyncConfig
+	Cards           CardsConfig
+	Webhooks        WebhooksConfig
+	Approvals       ApprovalsConfig
+	Scheduler       SchedulerConfig
+	Templates       TemplatesConfig
+	Clients         []ClientConfig
+	OrgID           string // Default organization of the active profile, if any
+	Profiles        map[string]ProfileConfig
+	DefaultProfile  string // Profile applied to the top-level settings
+	TokenFile       string // File holding the token, used when no token is set
+	SecretsFile     string // Encrypted secrets file for secret: references
+	Source          string // Path of the config file that was read, if any
+}
+
+// HTTPConfig holds settings for the outbound HTTP transport used to call the Webex API
+type HTTPConfig struct {
+	Timeout             time.Duration `yaml:"timeout"`                 // Overall request timeout
+	MaxIdleConns        int           `yaml:"max_idle_conns"`          // Idle connections kept across all hosts
+	MaxIdleConnsPerHost int           `yaml:"max_idle_conns_per_host"` // Idle connections kept per host
+	MaxConnsPerHost     int           `yaml:"max_conns_per_host"`      // Total connections per host, 0 means unlimited
+	IdleConnTimeout     time.Duration `yaml:"idle_conn_timeout"`       // How long an idle connection stays in the pool
+	TLSHandshakeTimeout time.Duration `yaml:"tls_handshake_timeout"`
+	ProxyURL            string        `yaml:"proxy_url"`        // Overrides HTTP_PROXY/HTTPS_PROXY when set
+	CACertFile          string        `yaml:"ca_cert_file"`     // Extra PEM bundle trusted in addition to the system roots
+	ClientCertFile      string        `yaml:"client_cert_file"` // PEM client certificate for mutual TLS
+	ClientKeyFile       string        `yaml:"client_key_file"`  // PEM private key for ClientCertFile
+	Cassette            string        `yaml:"cassette"`         // Record or replay Webex calls with this file
+	CassetteMode        string        `yaml:"cassette_mode"`    // record or replay (default)
+}
+
+// Cassette modes for HTTPConfig.CassetteMode
+const (
+	CassetteRecord = "record" // Call Webex and append each interaction to the cassette
+	CassetteReplay = "replay" // Answer from the cassette without network access
+)
+
+// DefaultHTTPConfig returns the transport settings used when nothing is configured
+func DefaultHTTPConfig() HTTPConfig {
+	return HTTPConfig{
+		Timeout:             30 * time.Second,
+		MaxIdleConns:        10,
+		MaxIdleConnsPerHost: 2,
+		IdleConnTimeout:     90 * time.Second,
+		TLSHandshakeTimeout: 10 * time.Second,
+	}
+}
+
+// WithDefaults fills zero-valued pool and timeout settings from DefaultHTTPConfig
+func (h HTTPConfig) WithDefaults() HTTPConfig {
+	defaults := DefaultHTTPConfig()
+	if h.Timeout == 0 {
+		h.Timeout = defaults.Timeout
+	}
+	if h.MaxIdleConns == 0 {
+		h.MaxIdleConns = defaults.MaxIdleConns
+	}
+	if h.MaxIdleConnsPerHost == 0 {
+		h.MaxIdleConnsPerHost = defaults.MaxIdleConnsPerHost
+	}
+	if h.IdleConnTimeout == 0 {
+		h.IdleConnTimeout = defaults.IdleConnTimeout
+	}
+	if h.TLSHandshakeTimeout == 0 {
+		h.TLSHandshakeTimeout = defaults.TLSHandshakeTimeout
+	}
+	return h
 }
 
 var (
 	once     sync.Once
+	mu       sync.RWMutex // Guards instance and loadErr against Reload
 	instance *Config
 	loadErr  error
+	options  Options
 )
 
+// Options controls the sources Load reads beyond the process environment
+type Options struct {
+	// File is a YAML or JSON config file. When empty, WEBEX_MCP_CONFIG is consulted.
+	File string
+	// Overrides runs after every other source, so command-line flags take precedence
+	Overrides func(*Config)
+}
+
 // ResetForTesting resets the singleton state for testing purposes
 func ResetForTesting() {
 	once = sync.Once{}
 	instance = nil
 	loadErr = nil
+	options = Options{}
 }
 
-// Load loads the configuration from environment variables
+// SetOptions sets the sources for the next Load and discards any configuration loaded so far
+func SetOptions(opts Options) {
+	once = sync.Once{}
+	instance = nil
+	loadErr = nil
+	options = opts
+}
+
+// Load loads the configuration with precedence flags > environment > config file > defaults
 func Load() (*Config, error) {
 	once.Do(func() {
-		instance = &Config{
-			WebexAPIKey:     os.Getenv("WEBEX_PUBLIC_WORKSPACE_API_KEY"),
-			WebexAPIBaseURL: getEnvWithDefault("WEBEX_API_BASE_URL", "https://webexapis.com/v1"),
-			Port:            getEnvWithDefault("PORT", "3001"),
-			NodeEnv:         getEnvWithDefault("NODE_ENV", "development"),
-		}
+		cfg, err := build(options)
+		mu.Lock()
+		instance, loadErr = cfg, err
+		mu.Unlock()
+	})
+
+	mu.RLock()
+	defer mu.RUnlock()
+	return instance, loadErr
+}
 
-		// Clean up API key
-		instance.WebexAPIKey = strings.TrimPrefix(instance.WebexAPIKey, "Bearer ")
-		instance.WebexAPIKey = strings.TrimSpace(instance.WebexAPIKey)
-		instance.WebexToken = instance.WebexAPIKey // Set alias
+// Reload rebuilds the configuration from the sources set with SetOptions.
+// The new configuration replaces the current one only when it is valid, so a
+// bad edit leaves the running configuration untouched.
+func Reload() (*Config, error) {
+	Load() // Make sure the initial load has happened before replacing it
 
-		// Validate
-		if instance.WebexAPIKey == "" {
-			loadErr = fmt.Errorf("WEBEX_PUBLIC_WORKSPACE_API_KEY environment variable is not set")
+	cfg, err := build(options)
+	if err != nil {
+		return nil, err
+	}
+
+	Replace(cfg)
+	return cfg, nil
+}
+
+// Replace installs cfg as the current configuration, for example to roll back a reload
+func Replace(cfg *Config) {
+	Load()
+
+	mu.Lock()
+	instance, loadErr = cfg, nil
+	mu.Unlock()
+}
+
+// build assembles a Config from every source and validates the result
+func build(opts Options) (*Config, error) {
+	doc := DefaultFile()
+
+	path := opts.File
+	if path == "" {
+		path = os.Getenv("WEBEX_MCP_CONFIG")
+	}
+	if path != "" {
+		if err := ReadFile(path, &doc); err != nil {
+			return doc.toConfig(), err
 		}
-	})
+	}
 
-	return instance, loadErr
+	cfg := doc.toConfig()
+	cfg.Source = path
+
+	if err := applyEnv(cfg); err != nil {
+		return cfg, err
+	}
+	if opts.Overrides != nil {
+		opts.Overrides(cfg)
+	}
+	// Opening the secrets file is slow by design, so every reference shares one store
+	openStore := cfg.secretsOpener()
+	if err := cfg.resolveToken(openStore); err != nil {
+		return cfg, err
+	}
+	if err := cfg.resolveProfiles(openStore); err != nil {
+		return cfg, err
+	}
+	if err := cfg.resolveClients(openStore); err != nil {
+		return cfg, err
+	}
+	if err := cfg.resolveWebhookSecret(openStore); err != nil {
+		return cfg, err
+	}
+	cfg.applyDefaultProfile()
+	cfg.normalize()
+	secrets.Register(cfg.WebexAPIKey)
+
+	return cfg, cfg.Validate()
+}
+
+// applyEnv overlays environment variables that are set on top of cfg
+func applyEnv(cfg *Config) error {
+	token, err := secrets.Env("WEBEX_PUBLIC_WORKSPACE_API_KEY")
+	if err != nil {
+		return err
+	}
+	if token != "" {
+		cfg.WebexAPIKey = token
+	}
+	cfg.SecretsFile = getEnvWithDefault("WEBEX_SECRETS_FILE", cfg.SecretsFile)
+	cfg.DefaultProfile = getEnvWithDefault("WEBEX_PROFILE", cfg.DefaultProfile)
+	cfg.WebexAPIBaseURL = getEnvWithDefault("WEBEX_API_BASE_URL", cfg.WebexAPIBaseURL)
+	cfg.NodeEnv = getEnvWithDefault("NODE_ENV", cfg.NodeEnv)
+
+	cfg.Server.Mode = getEnvWithDefault("MCP_SERVER_MODE", cfg.Server.Mode)
+	// PORT is an environment setting, so it wins over server.addr from the file;
+	// MCP_SERVER_ADDR names the whole address and wins over both
+	if port := os.Getenv("PORT"); port != "" {
+		cfg.Port = port
+		cfg.Server.Addr = ":" + port
+	}
+	cfg.Server.Addr = getEnvWithDefault("MCP_SERVER_ADDR", cfg.Server.Addr)
+	cfg.Server.SocketMode = getEnvWithDefault("MCP_SOCKET_MODE", cfg.Server.SocketMode)
+	cfg.Server.TLS.CertFile = getEnvWithDefault("MCP_TLS_CERT_FILE", cfg.Server.TLS.CertFile)
+	cfg.Server.TLS.KeyFile = getEnvWithDefault("MCP_TLS_KEY_FILE", cfg.Server.TLS.KeyFile)
+	cfg.Server.TLS.ClientCAFile = getEnvWithDefault("MCP_TLS_CLIENT_CA_FILE", cfg.Server.TLS.ClientCAFile)
+	cfg.Server.TLS.ClientAuth = getEnvWithDefault("MCP_TLS_CLIENT_AUTH", cfg.Server.TLS.ClientAuth)
+
+	all, err := getEnvBool("MCP_TOOLS_ALL", cfg.Tools.All)
+	if err != nil {
+		return err
+	}
+	cfg.Tools.All = all
+	cfg.Tools.Include = getEnvList("MCP_TOOLS_INCLUDE", cfg.Tools.Include)
+	cfg.Tools.Exclude = getEnvList("MCP_TOOLS_EXCLUDE", cfg.Tools.Exclude)
+
+	cfg.Logging.Level = getEnvWithDefault("LOG_LEVEL", cfg.Logging.Level)
+	cfg.Logging.Format = getEnvWithDefault("LOG_FORMAT", cfg.Logging.Format)
+
+	watch, err := getEnvBool("MCP_RELOAD_WATCH", cfg.Reload.Watch)
+	if err != nil {
+		return err
+	}
+	cfg.Reload.Watch = watch
+	if cfg.Reload.Interval, err = getEnvDuration("MCP_RELOAD_INTERVAL", cfg.Reload.Interval); err != nil {
+		return err
+	}
+
+	if cfg.Limits.MaxInFlight, err = getEnvInt("MCP_LIMIT_MAX_IN_FLIGHT", cfg.Limits.MaxInFlight); err != nil {
+		return err
+	}
+	cfg.Export.Dir = getEnvWithDefault("WEBEX_EXPORT_DIR", cfg.Export.Dir)
+	cfg.Cards.Dir = getEnvWithDefault("WEBEX_CARDS_DIR", cfg.Cards.Dir)
+	cfg.Templates.Dir = getEnvWithDefault("WEBEX_TEMPLATES_DIR", cfg.Templates.Dir)
+	cfg.Webhooks.Path = getEnvWithDefault("WEBEX_WEBHOOK_PATH", cfg.Webhooks.Path)
+	cfg.Webhooks.Secret = getEnvWithDefault("WEBEX_WEBHOOK_SECRET", cfg.Webhooks.Secret)
+	cfg.Webhooks.TargetURL = getEnvWithDefault("WEBEX_WEBHOOK_TARGET_URL", cfg.Webhooks.TargetURL)
+	cfg.Approvals.Room = getEnvWithDefault("WEBEX_APPROVALS_ROOM", cfg.Approvals.Room)
+	cfg.Approvals.Approvers = getEnvList("WEBEX_APPROVALS_APPROVERS", cfg.Approvals.Approvers)
+	cfg.Approvals.Tools = getEnvList("WEBEX_APPROVALS_TOOLS", cfg.Approvals.Tools)
+	if cfg.Approvals.Timeout, err = getEnvDuration("WEBEX_APPROVALS_TIMEOUT", cfg.Approvals.Timeout); err != nil {
+		return err
+	}
+	cfg.Scheduler.StoreFile = getEnvWithDefault("WEBEX_SCHEDULER_STORE_FILE", cfg.Scheduler.StoreFile)
+	cfg.Scheduler.Timezone = getEnvWithDefault("WEBEX_SCHEDULER_TIMEZONE", cfg.Scheduler.Timezone)
+	cfg.Scheduler.CatchUp = getEnvWithDefault("WEBEX_SCHEDULER_CATCH_UP", cfg.Scheduler.CatchUp)
+	cfg.Search.Dir = getEnvWithDefault("WEBEX_SEARCH_DIR", cfg.Search.Dir)
+	cfg.Search.Rooms = getEnvList("WEBEX_SEARCH_ROOMS", cfg.Search.Rooms)
+	if cfg.Search.Interval, err = getEnvDuration("WEBEX_SEARCH_INTERVAL", cfg.Search.Interval); err != nil {
+		return err
+	}
+	cfg.Sync.StateFile = getEnvWithDefault("WEBEX_SYNC_STATE_FILE", cfg.Sync.StateFile)
+	cfg.Sync.Rooms = getEnvList("WEBEX_SYNC_ROOMS", cfg.Sync.Rooms)
+	if cfg.Sync.Interval, err = getEnvDuration("WEBEX_SYNC_INTERVAL", cfg.Sync.Interval); err != nil {
+		return err
+	}
+
+	return applyHTTPEnv(&cfg.HTTP)
+}
+
+// normalize cleans up values and fills settings that depend on other settings
+func (c *Config) normalize() {
+	// Clean up API key
+	c.WebexAPIKey = strings.TrimPrefix(c.WebexAPIKey, "Bearer ")
+	c.WebexAPIKey = strings.TrimSpace(c.WebexAPIKey)
+	c.WebexToken = c.WebexAPIKey // Set alias
+
+	c.Server.Mode = strings.ToLower(strings.TrimSpace(c.Server.Mode))
+	if c.Server.Mode == "" {
+		c.Server.Mode = ModeStdio
+	}
+	if c.Port == "" {
+		c.Port = DefaultPort
+	}
+	if c.Server.Addr == "" && c.Server.Mode != ModeStdio {
+		c.Server.Addr = ":" + c.Port
+	}
+
+	c.Server.TLS.ClientAuth = strings.ToLower(strings.TrimSpace(c.Server.TLS.ClientAuth))
+	if c.Server.TLS.ClientAuth == "" && c.Server.TLS.ClientCAFile != "" {
+		c.Server.TLS.ClientAuth = ClientAuthRequire
+	}
+
+	c.HTTP.CassetteMode = strings.ToLower(strings.TrimSpace(c.HTTP.CassetteMode))
+	if c.HTTP.CassetteMode == "" && c.HTTP.Cassette != "" {
+		c.HTTP.CassetteMode = CassetteReplay
+	}
+
+	c.Logging.Level = strings.ToLower(c.Logging.Level)
+	c.Logging.Format = strings.ToLower(c.Logging.Format)
 }
 
 // MustLoad loads the configuration and panics if there's an error
@@ -129,6 +397,88 @@ func IsDevelopment() bool {
 	return cfg == nil || cfg.NodeEnv == "development" || cfg.NodeEnv == ""
 }
 
+// applyHTTPEnv overlays the outbound transport settings from environment variables
+func applyHTTPEnv(cfg *HTTPConfig) error {
+	cfg.ProxyURL = getEnvWithDefault("WEBEX_HTTP_PROXY", cfg.ProxyURL)
+	cfg.CACertFile = getEnvWithDefault("WEBEX_CA_CERT_FILE", cfg.CACertFile)
+	cfg.ClientCertFile = getEnvWithDefault("WEBEX_CLIENT_CERT_FILE", cfg.ClientCertFile)
+	cfg.ClientKeyFile = getEnvWithDefault("WEBEX_CLIENT_KEY_FILE", cfg.ClientKeyFile)
+	cfg.Cassette = getEnvWithDefault("WEBEX_CASSETTE", cfg.Cassette)
+	cfg.CassetteMode = getEnvWithDefault("WEBEX_CASSETTE_MODE", cfg.CassetteMode)
+
+	var err error
+	if cfg.Timeout, err = getEnvDuration("WEBEX_HTTP_TIMEOUT", cfg.Timeout); err != nil {
+		return err
+	}
+	if cfg.IdleConnTimeout, err = getEnvDuration("WEBEX_HTTP_IDLE_CONN_TIMEOUT", cfg.IdleConnTimeout); err != nil {
+		return err
+	}
+	if cfg.TLSHandshakeTimeout, err = getEnvDuration("WEBEX_HTTP_TLS_HANDSHAKE_TIMEOUT", cfg.TLSHandshakeTimeout); err != nil {
+		return err
+	}
+	if cfg.MaxIdleConns, err = getEnvInt("WEBEX_HTTP_MAX_IDLE_CONNS", cfg.MaxIdleConns); err != nil {
+		return err
+	}
+	if cfg.MaxIdleConnsPerHost, err = getEnvInt("WEBEX_HTTP_MAX_IDLE_CONNS_PER_HOST", cfg.MaxIdleConnsPerHost); err != nil {
+		return err
+	}
+	if cfg.MaxConnsPerHost, err = getEnvInt("WEBEX_HTTP_MAX_CONNS_PER_HOST", cfg.MaxConnsPerHost); err != nil {
+		return err
+	}
+	return nil
+}
+
+func getEnvBool(key string, defaultValue bool) (bool, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%s must be true or false, got %q", key, value)
+	}
+	return b, nil
+}
+
+// getEnvList splits a comma-separated variable, ignoring blank entries
+func getEnvList(key string, defaultValue []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func getEnvInt(key string, defaultValue int) (int, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%s must be a non-negative integer, got %q", key, value)
+	}
+	return n, nil
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) (time.Duration, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("%s must be a non-negative duration such as 30s, got %q", key, value)
+	}
+	return d, nil
+}
+
 func getEnvWithDefault(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
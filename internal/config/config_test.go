@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/raja-aiml/webex-mcp-server/internal/testutil"
 )
@@ -397,3 +398,88 @@ func TestGetWebexBaseURL_ErrorCase(t *testing.T) {
 		t.Errorf("GetWebexBaseURL() should return error when config fails")
 	}
 }
+
+func TestLoad_HTTPConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		wantErr bool
+		check   func(t *testing.T, cfg HTTPConfig)
+	}{
+		{
+			name: "defaults",
+			env:  map[string]string{},
+			check: func(t *testing.T, cfg HTTPConfig) {
+				if cfg != DefaultHTTPConfig() {
+					t.Errorf("HTTP = %+v, want defaults %+v", cfg, DefaultHTTPConfig())
+				}
+			},
+		},
+		{
+			name: "overrides from environment",
+			env: map[string]string{
+				"WEBEX_HTTP_TIMEOUT":                 "45s",
+				"WEBEX_HTTP_MAX_IDLE_CONNS":          "100",
+				"WEBEX_HTTP_MAX_IDLE_CONNS_PER_HOST": "20",
+				"WEBEX_HTTP_MAX_CONNS_PER_HOST":      "40",
+				"WEBEX_HTTP_PROXY":                   "http://proxy:8080",
+				"WEBEX_CA_CERT_FILE":                 "/etc/ssl/corp.pem",
+				"WEBEX_CLIENT_CERT_FILE":             "/etc/ssl/client.pem",
+				"WEBEX_CLIENT_KEY_FILE":              "/etc/ssl/client-key.pem",
+			},
+			check: func(t *testing.T, cfg HTTPConfig) {
+				if cfg.Timeout != 45*time.Second {
+					t.Errorf("Timeout = %v, want 45s", cfg.Timeout)
+				}
+				if cfg.MaxIdleConns != 100 || cfg.MaxIdleConnsPerHost != 20 || cfg.MaxConnsPerHost != 40 {
+					t.Errorf("pool = %d/%d/%d, want 100/20/40", cfg.MaxIdleConns, cfg.MaxIdleConnsPerHost, cfg.MaxConnsPerHost)
+				}
+				if cfg.ProxyURL != "http://proxy:8080" {
+					t.Errorf("ProxyURL = %q", cfg.ProxyURL)
+				}
+				if cfg.CACertFile != "/etc/ssl/corp.pem" || cfg.ClientCertFile != "/etc/ssl/client.pem" || cfg.ClientKeyFile != "/etc/ssl/client-key.pem" {
+					t.Errorf("TLS files = %q/%q/%q", cfg.CACertFile, cfg.ClientCertFile, cfg.ClientKeyFile)
+				}
+			},
+		},
+		{
+			name:    "invalid integer",
+			env:     map[string]string{"WEBEX_HTTP_MAX_IDLE_CONNS": "many"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid duration",
+			env:     map[string]string{"WEBEX_HTTP_TIMEOUT": "soon"},
+			wantErr: true,
+		},
+		{
+			name:    "client cert without key",
+			env:     map[string]string{"WEBEX_CLIENT_CERT_FILE": "/etc/ssl/client.pem"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ResetForTesting()
+			cleanups := []func(){testutil.SetEnv(t, "WEBEX_PUBLIC_WORKSPACE_API_KEY", "test-token")}
+			for key, value := range tt.env {
+				cleanups = append(cleanups, testutil.SetEnv(t, key, value))
+			}
+			defer func() {
+				for _, cleanup := range cleanups {
+					cleanup()
+				}
+				ResetForTesting()
+			}()
+
+			cfg, err := Load()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.check != nil {
+				tt.check(t, cfg.HTTP)
+			}
+		})
+	}
+}
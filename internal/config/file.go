@@ -0,0 +1,531 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/raja-aiml/webex-mcp-server/internal/ratelimit"
+	"gopkg.in/yaml.v3"
+)
+
+// Server transport modes
+const (
+	ModeStdio = "stdio"
+	ModeHTTP  = "http"
+	ModeSSE   = "sse"
+)
+
+// redactedValue replaces secrets in printed configuration
+const redactedValue = "[REDACTED]"
+
+// File is the config file schema. YAML is the primary format; JSON is accepted as a YAML subset.
+type File struct {
+	Server    ServerConfig    `yaml:"server"`
+	Auth      AuthConfig      `yaml:"auth"`
+	Webex     WebexConfig     `yaml:"webex"`
+	Tools     ToolsConfig     `yaml:"tools"`
+	Logging   LoggingConfig   `yaml:"logging"`
+	Reload    ReloadConfig    `yaml:"reload"`
+	Limits    LimitsConfig    `yaml:"limits"`
+	Export    ExportConfig    `yaml:"export"`
+	Search    SearchConfig    `yaml:"search"`
+	Sync      SyncConfig      `yaml:"sync"`
+	Cards     CardsConfig     `yaml:"cards"`
+	Webhooks  WebhooksConfig  `yaml:"webhooks"`
+	Approvals ApprovalsConfig `yaml:"approvals"`
+	Scheduler SchedulerConfig `yaml:"scheduler"`
+	Templates TemplatesConfig `yaml:"templates"`
+
+	// Clients are bearer tokens accepted by the HTTP server; when set, requests must present one
+	Clients []ClientConfig `yaml:"clients,omitempty"`
+
+	// Profiles are named Webex identities that tool calls select with the profile argument
+	Profiles       map[string]ProfileConfig `yaml:"profiles,omitempty"`
+	DefaultProfile string                   `yaml:"default_profile,omitempty"` // Profile used when a call names none
+}
+
+// ServerConfig selects how MCP clients reach the server
+type ServerConfig struct {
+	Mode       string          `yaml:"mode"`        // stdio, http or sse
+	Addr       string          `yaml:"addr"`        // host:port or unix:///path.sock for http and sse, defaults to :DefaultPort
+	SocketMode string          `yaml:"socket_mode"` // Octal permissions of a unix socket, defaults to 0600
+	TLS        ServerTLSConfig `yaml:"tls"`
+}
+
+// ServerTLSConfig enables HTTPS on the listener. Certificate files are re-read when
+// they change, so rotated certificates are picked up without a restart.
+type ServerTLSConfig struct {
+	CertFile     string `yaml:"cert_file"`
+	KeyFile      string `yaml:"key_file"`
+	ClientCAFile string `yaml:"client_ca_file"` // Verify client certificates against this PEM bundle
+	ClientAuth   string `yaml:"client_auth"`    // require (default) or optional when ClientCAFile is set
+}
+
+// Client certificate policies for ServerTLSConfig.ClientAuth
+const (
+	ClientAuthRequire  = "require"
+	ClientAuthOptional = "optional"
+)
+
+// DefaultPort is the port HTTP and SSE listen on when neither server.addr nor PORT is set
+const DefaultPort = "3001"
+
+// UnixSocketPrefix marks a server address as a unix domain socket path
+const UnixSocketPrefix = "unix://"
+
+// UnixSocketPath returns the socket path when Addr names a unix domain socket
+func (s ServerConfig) UnixSocketPath() (string, bool) {
+	if !strings.HasPrefix(s.Addr, UnixSocketPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(s.Addr, UnixSocketPrefix), true
+}
+
+// SocketPermissions returns the file mode for a unix socket
+func (s ServerConfig) SocketPermissions() (os.FileMode, error) {
+	if s.SocketMode == "" {
+		return 0600, nil
+	}
+	mode, err := strconv.ParseUint(s.SocketMode, 8, 32)
+	if err != nil || mode > 0777 {
+		return 0, fmt.Errorf("server.socket_mode must be octal permissions such as 0660, got %q", s.SocketMode)
+	}
+	return os.FileMode(mode), nil
+}
+
+// AuthConfig holds the credentials used to call Webex
+type AuthConfig struct {
+	// Token is the access token, or a reference: file:PATH, keyring:NAME or secret:NAME
+	Token       string `yaml:"token"`
+	TokenFile   string `yaml:"token_file"`   // Read the token from this file when Token is empty
+	SecretsFile string `yaml:"secrets_file"` // Encrypted secrets file, unlocked with WEBEX_SECRETS_PASSPHRASE
+}
+
+// LimitsConfig protects the shared Webex quota from a single busy client.
+// Zero values disable the corresponding limit.
+type LimitsConfig struct {
+	PerClient   ratelimit.Rate            `yaml:"per_client"`    // Tool calls per authenticated client
+	PerTool     ratelimit.Rate            `yaml:"per_tool"`      // Tool calls per tool, across all clients
+	Tools       map[string]ratelimit.Rate `yaml:"tools"`         // Per-tool overrides of PerTool
+	MaxInFlight int                       `yaml:"max_in_flight"` // Concurrent Webex API requests
+	MaxWait     time.Duration             `yaml:"max_wait"`      // How long a request waits for a free slot
+}
+
+// ClientConfig identifies an HTTP client by the bearer token it presents
+type ClientConfig struct {
+	Name  string `yaml:"name"`
+	Token string `yaml:"token"` // Plain value or secret reference, as for auth.token
+}
+
+// ProfileConfig is a named Webex identity, such as a bot or an admin account in another org
+type ProfileConfig struct {
+	Token   string `yaml:"token"`              // Access token or secret reference, as for auth.token
+	BaseURL string `yaml:"base_url,omitempty"` // Defaults to webex.base_url
+	OrgID   string `yaml:"org_id,omitempty"`   // Filled into orgId arguments the caller leaves empty
+}
+
+// WebexConfig holds the Webex API endpoint and client transport settings
+type WebexConfig struct {
+	BaseURL string     `yaml:"base_url"`
+	HTTP    HTTPConfig `yaml:"http"`
+}
+
+// ToolsConfig selects which tools are exposed to clients
+type ToolsConfig struct {
+	All     bool     `yaml:"all"`     // Load advanced tools in addition to the core set
+	Include []string `yaml:"include"` // When set, expose only these tools
+	Exclude []string `yaml:"exclude"` // Never expose these tools
+}
+
+// LoggingConfig controls server log output
+type LoggingConfig struct {
+	Level  string `yaml:"level"`  // debug, info, warn or error
+	Format string `yaml:"format"` // text or json
+}
+
+// ReloadConfig controls reloading configuration and tools without a restart.
+// SIGHUP always triggers a reload; Watch additionally polls the config and .env files.
+type ReloadConfig struct {
+	Watch    bool          `yaml:"watch"`
+	Interval time.Duration `yaml:"interval"` // Polling interval for Watch
+}
+
+// ExportConfig controls where exports such as room transcripts are written
+type ExportConfig struct {
+	Dir string `yaml:"dir"` // Output directory; when empty, tools return exports inline
+}
+
+// SearchConfig enables the local message index behind the search_messages tool.
+// The server copies new messages from Rooms into the index every Interval.
+type SearchConfig struct {
+	Dir      string        `yaml:"dir"`   // Where the index is kept; search is off when empty
+	Rooms    []string      `yaml:"rooms"` // Room IDs to index
+	Interval time.Duration `yaml:"interval"`
+}
+
+// SyncConfig enables polling Rooms for new messages every Interval, read with
+// the get_new_messages_since_checkpoint tool
+type SyncConfig struct {
+	StateFile string        `yaml:"state_file"` // Checkpoints and unread messages; sync is off when empty
+	Rooms     []string      `yaml:"rooms"`      // Room IDs to poll
+	Interval  time.Duration `yaml:"interval"`
+}
+
+// CardsConfig locates the Adaptive Card templates used by send_card
+type CardsConfig struct {
+	Dir string `yaml:"dir"` // Directory of NAME.json card templates
+}
+
+// TemplatesConfig locates the message templates used by send_template_message
+type TemplatesConfig struct {
+	Dir string `yaml:"dir"` // Directory of NAME.md and NAME.card.json message templates
+}
+
+// WebhooksConfig receives Webex webhook notifications on the HTTP server, which
+// lets await_card_response see card submissions as they happen
+type WebhooksConfig struct {
+	Path      string `yaml:"path"`       // Route on the HTTP server; receiving is off when empty
+	Secret    string `yaml:"secret"`     // Checks the X-Spark-Signature of notifications; accepts secret references
+	TargetURL string `yaml:"target_url"` // Public URL of Path; when set the server registers its webhook with Webex
+}
+
+// ApprovalsConfig gates tools behind an approve or deny card that a named
+// approver must answer before the tool runs
+type ApprovalsConfig struct {
+	Room      string        `yaml:"room"`      // Room ID the approval cards are posted in
+	Approvers []string      `yaml:"approvers"` // Person IDs or email addresses allowed to answer
+	Tools     []string      `yaml:"tools"`     // Tools that need approval; approvals are off when empty
+	Timeout   time.Duration `yaml:"timeout"`   // How long to wait for an answer before failing the call
+}
+
+// SchedulerConfig enables the scheduled messages of schedule_message, kept in
+// StoreFile and posted by a background worker
+type SchedulerConfig struct {
+	StoreFile string `yaml:"store_file"` // Scheduled jobs; scheduling is off when empty
+	Timezone  string `yaml:"timezone"`   // IANA zone for times without an offset and cron schedules; the server's zone when empty
+	CatchUp   string `yaml:"catch_up"`   // What to do with runs missed while the server was down: skip or once
+}
+
+// Location returns the scheduler's default time zone
+func (s SchedulerConfig) Location() (*time.Location, error) {
+	if s.Timezone == "" {
+		return time.Local, nil
+	}
+	return time.LoadLocation(s.Timezone)
+}
+
+// maxApprovalTimeout is the longest a tool call waits for a card response
+const maxApprovalTimeout = 15 * time.Minute
+
+// DefaultFile returns the configuration used when no source sets a value
+func DefaultFile() File {
+	return File{
+		Server: ServerConfig{Mode: ModeStdio},
+		Webex: WebexConfig{
+			BaseURL: "https://webexapis.com/v1",
+			HTTP:    DefaultHTTPConfig(),
+		},
+		Logging:   LoggingConfig{Level: "info", Format: "text"},
+		Reload:    ReloadConfig{Interval: 2 * time.Second},
+		Limits:    LimitsConfig{MaxWait: 5 * time.Second},
+		Search:    SearchConfig{Interval: 5 * time.Minute},
+		Sync:      SyncConfig{Interval: time.Minute},
+		Approvals: ApprovalsConfig{Timeout: 10 * time.Minute},
+		Scheduler: SchedulerConfig{CatchUp: "once"},
+	}
+}
+
+// ReadFile decodes the config file at path on top of the values already in doc.
+// Unknown keys are rejected so typos surface as errors instead of being ignored.
+func ReadFile(path string, doc *File) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(doc); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// toConfig maps the file schema onto the runtime configuration
+func (f File) toConfig() *Config {
+	return &Config{
+		WebexAPIKey:     f.Auth.Token,
+		TokenFile:       f.Auth.TokenFile,
+		SecretsFile:     f.Auth.SecretsFile,
+		WebexAPIBaseURL: f.Webex.BaseURL,
+		HTTP:            f.Webex.HTTP,
+		Server:          f.Server,
+		Tools:           f.Tools,
+		Logging:         f.Logging,
+		Reload:          f.Reload,
+		Limits:          f.Limits,
+		Export:          f.Export,
+		Search:          f.Search,
+		Sync:            f.Sync,
+		Cards:           f.Cards,
+		Webhooks:        f.Webhooks,
+		Approvals:       f.Approvals,
+		Scheduler:       f.Scheduler,
+		Templates:       f.Templates,
+		Clients:         f.Clients,
+		Profiles:        f.Profiles,
+		DefaultProfile:  f.DefaultProfile,
+	}
+}
+
+// Effective returns the configuration in file form, with secrets masked when redact is true
+func (c *Config) Effective(redact bool) File {
+	doc := File{
+		Server: c.Server,
+		Auth: AuthConfig{
+			Token:       c.WebexAPIKey,
+			TokenFile:   c.TokenFile,
+			SecretsFile: c.SecretsFile,
+		},
+		Webex: WebexConfig{
+			BaseURL: c.WebexAPIBaseURL,
+			HTTP:    c.HTTP,
+		},
+		Tools:     c.Tools,
+		Logging:   c.Logging,
+		Reload:    c.Reload,
+		Limits:    c.Limits,
+		Export:    c.Export,
+		Search:    c.Search,
+		Sync:      c.Sync,
+		Cards:     c.Cards,
+		Webhooks:  c.Webhooks,
+		Approvals: c.Approvals,
+		Scheduler: c.Scheduler,
+		Templates: c.Templates,
+		Clients:   c.Clients,
+
+		Profiles:       c.Profiles,
+		DefaultProfile: c.DefaultProfile,
+	}
+
+	if redact {
+		if len(doc.Clients) > 0 {
+			clients := make([]ClientConfig, len(doc.Clients))
+			for i, client := range doc.Clients {
+				client.Token = redactedValue
+				clients[i] = client
+			}
+			doc.Clients = clients
+		}
+		if len(doc.Profiles) > 0 {
+			profiles := make(map[string]ProfileConfig, len(doc.Profiles))
+			for name, profile := range doc.Profiles {
+				if profile.Token != "" {
+					profile.Token = redactedValue
+				}
+				profiles[name] = profile
+			}
+			doc.Profiles = profiles
+		}
+		if doc.Auth.Token != "" {
+			doc.Auth.Token = redactedValue
+		}
+		if doc.Webhooks.Secret != "" {
+			doc.Webhooks.Secret = redactedValue
+		}
+		if u, err := url.Parse(doc.Webex.HTTP.ProxyURL); err == nil && u.User != nil {
+			doc.Webex.HTTP.ProxyURL = u.Redacted()
+		}
+	}
+	return doc
+}
+
+// WriteYAML writes the effective configuration as YAML
+func (c *Config) WriteYAML(w io.Writer, redact bool) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(c.Effective(redact)); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// ValidationError lists every problem found in a configuration
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	if len(e.Problems) == 1 {
+		return e.Problems[0]
+	}
+	return "invalid configuration:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+// Validate checks the configuration and reports all problems at once
+func (c *Config) Validate() error {
+	var problems []string
+
+	if c.WebexAPIKey == "" {
+		if len(c.Profiles) > 0 {
+			problems = append(problems, "no default identity: set default_profile, or WEBEX_PUBLIC_WORKSPACE_API_KEY or auth.token")
+		} else {
+			problems = append(problems, "WEBEX_PUBLIC_WORKSPACE_API_KEY environment variable is not set (or WEBEX_PUBLIC_WORKSPACE_API_KEY_FILE, or auth.token in the config file)")
+		}
+	}
+
+	if u, err := url.Parse(c.WebexAPIBaseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		problems = append(problems, fmt.Sprintf("webex.base_url must be an absolute http(s) URL, got %q", c.WebexAPIBaseURL))
+	}
+
+	switch c.Server.Mode {
+	case ModeStdio:
+	case ModeHTTP, ModeSSE:
+		if path, ok := c.Server.UnixSocketPath(); ok {
+			if path == "" {
+				problems = append(problems, "server.addr must name a socket path after unix://")
+			}
+		} else if _, _, err := net.SplitHostPort(c.Server.Addr); err != nil {
+			problems = append(problems, fmt.Sprintf("server.addr must be host:port or unix:///path, got %q", c.Server.Addr))
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("server.mode must be one of stdio, http or sse, got %q", c.Server.Mode))
+	}
+
+	if _, err := c.Server.SocketPermissions(); err != nil {
+		problems = append(problems, err.Error())
+	}
+	tlsCfg := c.Server.TLS
+	if (tlsCfg.CertFile == "") != (tlsCfg.KeyFile == "") {
+		problems = append(problems, "server.tls.cert_file and server.tls.key_file must be set together")
+	}
+	if tlsCfg.ClientCAFile != "" && tlsCfg.CertFile == "" {
+		problems = append(problems, "server.tls.client_ca_file requires server.tls.cert_file and key_file")
+	}
+	switch tlsCfg.ClientAuth {
+	case "", ClientAuthRequire, ClientAuthOptional:
+	default:
+		problems = append(problems, fmt.Sprintf("server.tls.client_auth must be require or optional, got %q", tlsCfg.ClientAuth))
+	}
+
+	if (c.HTTP.ClientCertFile == "") != (c.HTTP.ClientKeyFile == "") {
+		problems = append(problems, "webex.http.client_cert_file and webex.http.client_key_file must be set together")
+	}
+	if c.HTTP.MaxIdleConns < 0 || c.HTTP.MaxIdleConnsPerHost < 0 || c.HTTP.MaxConnsPerHost < 0 {
+		problems = append(problems, "webex.http connection limits must not be negative")
+	}
+	if c.HTTP.Cassette != "" && c.HTTP.CassetteMode != CassetteRecord && c.HTTP.CassetteMode != CassetteReplay {
+		problems = append(problems, fmt.Sprintf("webex.http.cassette_mode must be %s or %s, got %q", CassetteRecord, CassetteReplay, c.HTTP.CassetteMode))
+	}
+
+	for _, name := range c.ProfileNames() {
+		profile := c.Profiles[name]
+		if profile.Token == "" {
+			problems = append(problems, fmt.Sprintf("profiles.%s.token is not set", name))
+		}
+		if profile.BaseURL != "" {
+			if u, err := url.Parse(profile.BaseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				problems = append(problems, fmt.Sprintf("profiles.%s.base_url must be an absolute http(s) URL, got %q", name, profile.BaseURL))
+			}
+		}
+	}
+	if c.DefaultProfile != "" {
+		if _, ok := c.Profiles[c.DefaultProfile]; !ok {
+			problems = append(problems, fmt.Sprintf("default_profile %q is not defined in profiles", c.DefaultProfile))
+		}
+	}
+
+	problems = append(problems, c.validateLimits()...)
+
+	if c.Search.Dir != "" {
+		if len(c.Search.Rooms) == 0 {
+			problems = append(problems, "search.rooms must list at least one room when search.dir is set")
+		}
+		if c.Search.Interval <= 0 {
+			problems = append(problems, "search.interval must be positive when search.dir is set")
+		}
+	}
+
+	if c.Sync.StateFile != "" {
+		if len(c.Sync.Rooms) == 0 {
+			problems = append(problems, "sync.rooms must list at least one room when sync.state_file is set")
+		}
+		if c.Sync.Interval <= 0 {
+			problems = append(problems, "sync.interval must be positive when sync.state_file is set")
+		}
+	}
+
+	if c.Webhooks.Path != "" {
+		if !strings.HasPrefix(c.Webhooks.Path, "/") {
+			problems = append(problems, fmt.Sprintf("webhooks.path must start with /, got %q", c.Webhooks.Path))
+		}
+		if c.Server.Mode == ModeStdio {
+			problems = append(problems, "webhooks.path needs server.mode http or sse")
+		}
+		if c.Webhooks.Secret == "" {
+			problem := "webhooks.secret is required when webhooks.path is set, so forged notifications are rejected"
+			if len(c.Approvals.Tools) > 0 {
+				problem += "; approvals.tools would otherwise accept forged approvals"
+			}
+			problems = append(problems, problem)
+		}
+	}
+	if c.Webhooks.TargetURL != "" {
+		if c.Webhooks.Path == "" {
+			problems = append(problems, "webhooks.target_url requires webhooks.path")
+		}
+		if u, err := url.Parse(c.Webhooks.TargetURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			problems = append(problems, fmt.Sprintf("webhooks.target_url must be an absolute http(s) URL, got %q", c.Webhooks.TargetURL))
+		}
+	}
+
+	if len(c.Approvals.Tools) > 0 {
+		if c.Approvals.Room == "" {
+			problems = append(problems, "approvals.room is required when approvals.tools is set")
+		}
+		if len(c.Approvals.Approvers) == 0 {
+			problems = append(problems, "approvals.approvers must list at least one person when approvals.tools is set")
+		}
+		if c.Approvals.Timeout <= 0 || c.Approvals.Timeout > maxApprovalTimeout {
+			problems = append(problems, fmt.Sprintf("approvals.timeout must be positive and at most %s, got %s", maxApprovalTimeout, c.Approvals.Timeout))
+		}
+	}
+
+	if c.Scheduler.StoreFile != "" {
+		if _, err := c.Scheduler.Location(); err != nil {
+			problems = append(problems, fmt.Sprintf("scheduler.timezone %q is not a known time zone", c.Scheduler.Timezone))
+		}
+		if c.Scheduler.CatchUp != "skip" && c.Scheduler.CatchUp != "once" {
+			problems = append(problems, fmt.Sprintf("scheduler.catch_up must be skip or once, got %q", c.Scheduler.CatchUp))
+		}
+	}
+
+	if c.Reload.Watch && c.Reload.Interval <= 0 {
+		problems = append(problems, "reload.interval must be positive when reload.watch is enabled")
+	}
+
+	switch c.Logging.Level {
+	case "debug", "info", "warn", "error":
+	default:
+		problems = append(problems, fmt.Sprintf("logging.level must be one of debug, info, warn or error, got %q", c.Logging.Level))
+	}
+	switch c.Logging.Format {
+	case "text", "json":
+	default:
+		problems = append(problems, fmt.Sprintf("logging.format must be text or json, got %q", c.Logging.Format))
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package config
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"sort"
+
+	"github.com/raja-aiml/webex-mcp-server/internal/ratelimit"
+	"github.com/raja-aiml/webex-mcp-server/internal/secrets"
+)
+
+// ToolRate returns the rate limit for a tool, honouring per-tool overrides
+func (l LimitsConfig) ToolRate(name string) ratelimit.Rate {
+	if rate, ok := l.Tools[name]; ok {
+		return rate
+	}
+	return l.PerTool
+}
+
+// ClientByToken returns the name of the client that owns token
+func (c *Config) ClientByToken(token string) (string, bool) {
+	for _, client := range c.Clients {
+		if subtle.ConstantTimeCompare([]byte(client.Token), []byte(token)) == 1 {
+			return client.Name, true
+		}
+	}
+	return "", false
+}
+
+// resolveClients expands secret references in client tokens and registers them for redaction
+func (c *Config) resolveClients(openStore secrets.StoreOpener) error {
+	if len(c.Clients) == 0 {
+		return nil
+	}
+
+	clients := make([]ClientConfig, len(c.Clients))
+	for i, client := range c.Clients {
+		token, err := secrets.Resolve(client.Token, openStore)
+		if err != nil {
+			return fmt.Errorf("failed to resolve token for client %s: %w", client.Name, err)
+		}
+		client.Token = token
+		secrets.Register(token)
+		clients[i] = client
+	}
+	c.Clients = clients
+	return nil
+}
+
+// validateLimits reports problems with rate limits and client identities
+func (c *Config) validateLimits() []string {
+	var problems []string
+
+	rates := map[string]ratelimit.Rate{
+		"limits.per_client": c.Limits.PerClient,
+		"limits.per_tool":   c.Limits.PerTool,
+	}
+	for name, rate := range c.Limits.Tools {
+		rates["limits.tools."+name] = rate
+	}
+	keys := make([]string, 0, len(rates))
+	for key := range rates {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if rate := rates[key]; rate.PerSecond < 0 || rate.Burst < 0 {
+			problems = append(problems, fmt.Sprintf("%s rate and burst must not be negative", key))
+		}
+	}
+	if c.Limits.MaxInFlight < 0 || c.Limits.MaxWait < 0 {
+		problems = append(problems, "limits.max_in_flight and limits.max_wait must not be negative")
+	}
+
+	seen := make(map[string]bool, len(c.Clients))
+	for i, client := range c.Clients {
+		if client.Name == "" {
+			problems = append(problems, fmt.Sprintf("clients[%d].name is not set", i))
+		} else if seen[client.Name] {
+			problems = append(problems, fmt.Sprintf("clients[%d].name %q is used more than once", i, client.Name))
+		}
+		seen[client.Name] = true
+		if client.Token == "" {
+			problems = append(problems, fmt.Sprintf("clients[%d].token is not set", i))
+		}
+	}
+	return problems
+}
@@ -0,0 +1,74 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/raja-aiml/webex-mcp-server/internal/secrets"
+)
+
+// ProfileNames returns the configured profile names, sorted
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Profile returns a copy of the configuration that talks to Webex as the named profile
+func (c *Config) Profile(name string) (*Config, error) {
+	profile, ok := c.Profiles[name]
+	if !ok {
+		if len(c.Profiles) == 0 {
+			return nil, fmt.Errorf("unknown profile %q: no profiles are configured", name)
+		}
+		return nil, fmt.Errorf("unknown profile %q (available: %s)", name, strings.Join(c.ProfileNames(), ", "))
+	}
+
+	cfg := *c
+	cfg.WebexAPIKey = profile.Token
+	cfg.WebexToken = profile.Token
+	if profile.BaseURL != "" {
+		cfg.WebexAPIBaseURL = profile.BaseURL
+	}
+	cfg.OrgID = profile.OrgID
+	return &cfg, nil
+}
+
+// resolveProfiles expands secret references in profile tokens and registers them for redaction
+func (c *Config) resolveProfiles(openStore secrets.StoreOpener) error {
+	if len(c.Profiles) == 0 {
+		return nil
+	}
+
+	profiles := make(map[string]ProfileConfig, len(c.Profiles))
+	for name, profile := range c.Profiles {
+		token, err := secrets.Resolve(profile.Token, openStore)
+		if err != nil {
+			return fmt.Errorf("failed to resolve token for profile %s: %w", name, err)
+		}
+		profile.Token = strings.TrimSpace(strings.TrimPrefix(token, "Bearer "))
+		profile.BaseURL = strings.TrimRight(profile.BaseURL, "/")
+		secrets.Register(profile.Token)
+		profiles[name] = profile
+	}
+	c.Profiles = profiles
+	return nil
+}
+
+// applyDefaultProfile makes the default profile's identity the top-level one, so calls
+// without a profile argument and the default client use it
+func (c *Config) applyDefaultProfile() {
+	profile, ok := c.Profiles[c.DefaultProfile]
+	if !ok {
+		return
+	}
+	c.WebexAPIKey = profile.Token
+	if profile.BaseURL != "" {
+		c.WebexAPIBaseURL = profile.BaseURL
+	}
+	c.OrgID = profile.OrgID
+}
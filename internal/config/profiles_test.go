@@ -0,0 +1,129 @@
+package config
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/raja-aiml/webex-mcp-server/internal/testutil"
+)
+
+func TestLoad_Profiles(t *testing.T) {
+	content := `
+auth:
+  token: main-token
+profiles:
+  bot:
+    token: "Bearer bot-token"
+  admin:
+    token: admin-token
+    base_url: https://admin.example.com/v1/
+    org_id: admin-org
+`
+
+	tests := []struct {
+		name    string
+		content string
+		profile string
+		check   func(t *testing.T, cfg *Config)
+		wantErr string
+	}{
+		{
+			name:    "top-level identity without a default profile",
+			content: content,
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.WebexAPIKey != "main-token" || cfg.OrgID != "" {
+					t.Errorf("token/org = %q/%q, want main-token and no org", cfg.WebexAPIKey, cfg.OrgID)
+				}
+				if strings.Join(cfg.ProfileNames(), ",") != "admin,bot" {
+					t.Errorf("ProfileNames() = %v", cfg.ProfileNames())
+				}
+				admin, err := cfg.Profile("admin")
+				if err != nil {
+					t.Fatalf("Profile(admin) error = %v", err)
+				}
+				if admin.WebexAPIKey != "admin-token" || admin.WebexAPIBaseURL != "https://admin.example.com/v1" || admin.OrgID != "admin-org" {
+					t.Errorf("admin profile = %q %q %q", admin.WebexAPIKey, admin.WebexAPIBaseURL, admin.OrgID)
+				}
+				bot, _ := cfg.Profile("bot")
+				if bot.WebexAPIKey != "bot-token" || bot.WebexAPIBaseURL != "https://webexapis.com/v1" {
+					t.Errorf("bot profile = %q %q", bot.WebexAPIKey, bot.WebexAPIBaseURL)
+				}
+				if _, err := cfg.Profile("nope"); err == nil || !strings.Contains(err.Error(), "available: admin, bot") {
+					t.Errorf("Profile(nope) error = %v", err)
+				}
+			},
+		},
+		{
+			name:    "default profile from the flag",
+			content: content,
+			profile: "admin",
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.WebexAPIKey != "admin-token" || cfg.WebexAPIBaseURL != "https://admin.example.com/v1" || cfg.OrgID != "admin-org" {
+					t.Errorf("default identity = %q %q %q, want the admin profile", cfg.WebexAPIKey, cfg.WebexAPIBaseURL, cfg.OrgID)
+				}
+			},
+		},
+		{
+			name:    "unknown default profile",
+			content: content,
+			profile: "nope",
+			wantErr: `default_profile "nope" is not defined in profiles`,
+		},
+		{
+			name:    "profile without a token",
+			content: "auth:\n  token: main-token\nprofiles:\n  empty:\n    org_id: x\n",
+			wantErr: "profiles.empty.token is not set",
+		},
+		{
+			name:    "profiles without a default identity",
+			content: "profiles:\n  bot:\n    token: bot-token\n",
+			wantErr: "no default identity",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cleanup := testutil.SetEnv(t, "WEBEX_PUBLIC_WORKSPACE_API_KEY", "")
+			defer func() {
+				cleanup()
+				ResetForTesting()
+			}()
+
+			SetOptions(Options{
+				File: writeConfigFile(t, tt.content),
+				Overrides: func(cfg *Config) {
+					if tt.profile != "" {
+						cfg.DefaultProfile = tt.profile
+					}
+				},
+			})
+			cfg, err := Load()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Load() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			tt.check(t, cfg)
+		})
+	}
+}
+
+func TestWriteYAML_RedactsProfileTokens(t *testing.T) {
+	cfg := &Config{Profiles: map[string]ProfileConfig{"bot": {Token: "bot-token", OrgID: "org"}}}
+
+	var buf bytes.Buffer
+	if err := cfg.WriteYAML(&buf, true); err != nil {
+		t.Fatalf("WriteYAML() error = %v", err)
+	}
+	if strings.Contains(buf.String(), "bot-token") || !strings.Contains(buf.String(), "org_id: org") {
+		t.Errorf("WriteYAML() output:\n%s", buf.String())
+	}
+	if cfg.Profiles["bot"].Token != "bot-token" {
+		t.Error("WriteYAML() modified the configuration")
+	}
+}
@@ -0,0 +1,88 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/raja-aiml/webex-mcp-server/internal/secrets"
+)
+
+// DefaultTokenSecret is the secrets file entry used when no token is configured
+const DefaultTokenSecret = "webex_token"
+
+// resolveToken expands token references and falls back to the token file or secrets file
+func (c *Config) resolveToken(openStore secrets.StoreOpener) error {
+	token := c.WebexAPIKey
+	switch {
+	case token != "":
+	case c.TokenFile != "":
+		token = "file:" + c.TokenFile
+	case c.SecretsFile != "":
+		token = "secret:" + DefaultTokenSecret
+	default:
+		return nil
+	}
+
+	resolved, err := secrets.Resolve(token, openStore)
+	if err != nil {
+		return fmt.Errorf("failed to resolve Webex token: %w", err)
+	}
+	c.WebexAPIKey = resolved
+	return nil
+}
+
+// resolveWebhookSecret expands a reference in the webhook secret
+func (c *Config) resolveWebhookSecret(openStore secrets.StoreOpener) error {
+	if c.Webhooks.Secret == "" {
+		return nil
+	}
+	secret, err := secrets.Resolve(c.Webhooks.Secret, openStore)
+	if err != nil {
+		return fmt.Errorf("failed to resolve webhook secret: %w", err)
+	}
+	c.Webhooks.Secret = secret
+	secrets.Register(secret)
+	return nil
+}
+
+// OpenSecrets opens the configured encrypted secrets file with the passphrase
+// from WEBEX_SECRETS_PASSPHRASE or WEBEX_SECRETS_PASSPHRASE_FILE
+func (c *Config) OpenSecrets() (*secrets.Store, error) {
+	if c.SecretsFile == "" {
+		return nil, fmt.Errorf("no secrets file configured (set auth.secrets_file or WEBEX_SECRETS_FILE)")
+	}
+	passphrase, err := SecretsPassphrase()
+	if err != nil {
+		return nil, err
+	}
+	return secrets.OpenStore(c.SecretsFile, passphrase)
+}
+
+// secretsOpener opens the secrets file on first use and returns the same store to
+// every later caller, so resolving many references derives the key only once
+func (c *Config) secretsOpener() secrets.StoreOpener {
+	var (
+		store  *secrets.Store
+		err    error
+		opened bool
+	)
+	return func() (*secrets.Store, error) {
+		if !opened {
+			store, err = c.OpenSecrets()
+			opened = true
+		}
+		return store, err
+	}
+}
+
+// SecretsPassphrase returns the passphrase that unlocks the secrets file
+func SecretsPassphrase() (string, error) {
+	passphrase, err := secrets.Env("WEBEX_SECRETS_PASSPHRASE")
+	if err != nil {
+		return "", err
+	}
+	if passphrase == "" {
+		return "", fmt.Errorf("WEBEX_SECRETS_PASSPHRASE or WEBEX_SECRETS_PASSPHRASE_FILE must be set to unlock the secrets file")
+	}
+	secrets.Register(passphrase)
+	return passphrase, nil
+}
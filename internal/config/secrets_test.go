@@ -0,0 +1,160 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/raja-aiml/webex-mcp-server/internal/secrets"
+	"github.com/raja-aiml/webex-mcp-server/internal/testutil"
+)
+
+func TestLoad_TokenSources(t *testing.T) {
+	dir := t.TempDir()
+	tokenFile := filepath.Join(dir, "token")
+	if err := os.WriteFile(tokenFile, []byte("token-from-file\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	secretsFile := filepath.Join(dir, "secrets.json")
+	store, err := secrets.OpenStore(secretsFile, "passphrase")
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.Set(DefaultTokenSecret, "token-from-store")
+	store.Set("backup", "backup-token-value")
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		env     map[string]string
+		file    string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "_FILE environment variable",
+			env:  map[string]string{"WEBEX_PUBLIC_WORKSPACE_API_KEY_FILE": tokenFile},
+			want: "token-from-file",
+		},
+		{
+			name: "environment variable wins over _FILE",
+			env: map[string]string{
+				"WEBEX_PUBLIC_WORKSPACE_API_KEY":      "token-from-env",
+				"WEBEX_PUBLIC_WORKSPACE_API_KEY_FILE": tokenFile,
+			},
+			want: "token-from-env",
+		},
+		{
+			name: "auth.token_file",
+			file: "auth:\n  token_file: " + tokenFile + "\n",
+			want: "token-from-file",
+		},
+		{
+			name: "file: reference",
+			file: "auth:\n  token: file:" + tokenFile + "\n",
+			want: "token-from-file",
+		},
+		{
+			name: "secrets file default entry",
+			env:  map[string]string{"WEBEX_SECRETS_PASSPHRASE": "passphrase"},
+			file: "auth:\n  secrets_file: " + secretsFile + "\n",
+			want: "token-from-store",
+		},
+		{
+			name: "secret: reference with secrets file from environment",
+			env: map[string]string{
+				"WEBEX_SECRETS_FILE":       secretsFile,
+				"WEBEX_SECRETS_PASSPHRASE": "passphrase",
+			},
+			file: "auth:\n  token: secret:backup\n",
+			want: "backup-token-value",
+		},
+		{
+			name:    "missing passphrase",
+			file:    "auth:\n  secrets_file: " + secretsFile + "\n",
+			wantErr: "WEBEX_SECRETS_PASSPHRASE",
+		},
+		{
+			name:    "wrong passphrase",
+			env:     map[string]string{"WEBEX_SECRETS_PASSPHRASE": "wrong"},
+			file:    "auth:\n  secrets_file: " + secretsFile + "\n",
+			wantErr: "wrong passphrase",
+		},
+		{
+			name:    "missing token file",
+			env:     map[string]string{"WEBEX_PUBLIC_WORKSPACE_API_KEY_FILE": filepath.Join(dir, "missing")},
+			wantErr: "WEBEX_PUBLIC_WORKSPACE_API_KEY_FILE",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cleanups := []func(){
+				testutil.SetEnv(t, "WEBEX_PUBLIC_WORKSPACE_API_KEY", ""),
+				testutil.SetEnv(t, "WEBEX_PUBLIC_WORKSPACE_API_KEY_FILE", ""),
+				testutil.SetEnv(t, "WEBEX_SECRETS_FILE", ""),
+				testutil.SetEnv(t, "WEBEX_SECRETS_PASSPHRASE", ""),
+				testutil.SetEnv(t, "WEBEX_SECRETS_PASSPHRASE_FILE", ""),
+			}
+			for key, value := range tt.env {
+				cleanups = append(cleanups, testutil.SetEnv(t, key, value))
+			}
+			defer func() {
+				for _, cleanup := range cleanups {
+					cleanup()
+				}
+				ResetForTesting()
+			}()
+
+			var opts Options
+			if tt.file != "" {
+				opts.File = writeConfigFile(t, tt.file)
+			}
+			SetOptions(opts)
+			cfg, err := Load()
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Load() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if cfg.WebexAPIKey != tt.want {
+				t.Errorf("token = %q, want %q", cfg.WebexAPIKey, tt.want)
+			}
+			if got := secrets.Redact("Bearer " + tt.want); got != "Bearer [REDACTED]" {
+				t.Errorf("token not registered for redaction: %q", got)
+			}
+		})
+	}
+}
+
+func TestSecretsOpener(t *testing.T) {
+	secretsFile := filepath.Join(t.TempDir(), "secrets.json")
+	store, err := secrets.OpenStore(secretsFile, "passphrase")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+	cleanup := testutil.SetEnv(t, "WEBEX_SECRETS_PASSPHRASE", "passphrase")
+	defer cleanup()
+
+	// Every reference in one build shares the store, so the key is derived once
+	open := (&Config{SecretsFile: secretsFile}).secretsOpener()
+	first, err := open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second, err := open(); err != nil || second != first {
+		t.Errorf("second open = %p, %v; want the first store %p", second, err, first)
+	}
+}
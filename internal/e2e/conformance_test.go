@@ -0,0 +1,302 @@
+// Package e2e runs the real MCP server behind each transport it supports and
+// checks protocol-level behaviour with the go-sdk client against the fake Webex API.
+package e2e
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	_ "github.com/raja-aiml/webex-mcp-server/internal/advanced_tools"
+	"github.com/raja-aiml/webex-mcp-server/internal/app"
+	"github.com/raja-aiml/webex-mcp-server/internal/config"
+	"github.com/raja-aiml/webex-mcp-server/internal/fakewebex"
+	"github.com/raja-aiml/webex-mcp-server/internal/server"
+	"github.com/raja-aiml/webex-mcp-server/internal/testutil"
+	"github.com/raja-aiml/webex-mcp-server/internal/tools"
+)
+
+const (
+	serverName    = "webex-mcp-server"
+	serverVersion = "0.0.0-e2e"
+
+	// serveStdioEnv makes the test binary run the server in stdio mode instead of the tests
+	serveStdioEnv = "WEBEX_MCP_E2E_SERVE_STDIO"
+)
+
+func TestMain(m *testing.M) {
+	if os.Getenv(serveStdioEnv) != "" {
+		err := app.New(app.Config{Name: serverName, Version: serverVersion}).Run()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// transports start the server and return a client transport connected to it
+var transports = []struct {
+	name    string
+	connect func(t *testing.T, baseURL string) mcp.Transport
+}{
+	{name: "in-memory", connect: connectInMemory},
+	{name: "stdio", connect: connectStdio},
+	{name: "streamable HTTP", connect: connectStreamable},
+	{name: "SSE", connect: connectSSE},
+}
+
+func TestConformance(t *testing.T) {
+	for _, tt := range transports {
+		t.Run(tt.name, func(t *testing.T) {
+			fake, baseURL := testutil.NewFakeWebex(t)
+			session := connect(t, tt.connect(t, baseURL))
+			checkConformance(t, fake, session)
+		})
+	}
+}
+
+// checkConformance runs the initialize, list, call and cancel flows on one session
+func checkConformance(t *testing.T, fake *fakewebex.Server, session *mcp.ClientSession) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// Initialize
+	init := session.InitializeResult()
+	if init.ServerInfo == nil || init.ServerInfo.Name != serverName || init.ServerInfo.Version != serverVersion {
+		t.Errorf("server info = %+v", init.ServerInfo)
+	}
+	if init.Capabilities == nil || init.Capabilities.Tools == nil {
+		t.Errorf("capabilities = %+v, want tools", init.Capabilities)
+	}
+	if init.ProtocolVersion == "" || !strings.Contains(init.Instructions, serverName) {
+		t.Errorf("protocol version %q, instructions %q", init.ProtocolVersion, init.Instructions)
+	}
+	if err := session.Ping(ctx, nil); err != nil {
+		t.Errorf("Ping() error = %v", err)
+	}
+
+	// List tools
+	list, err := session.ListTools(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListTools() error = %v", err)
+	}
+	listed := map[string]*mcp.Tool{}
+	for _, tool := range list.Tools {
+		listed[tool.Name] = tool
+		if err := server.ValidateToolName(tool.Name); err != nil {
+			t.Errorf("tool %q: %v", tool.Name, err)
+		}
+		if tool.InputSchema == nil || tool.InputSchema.Type != "object" {
+			t.Errorf("tool %q input schema = %+v, want an object", tool.Name, tool.InputSchema)
+		}
+	}
+	for _, name := range []string{"create_a_message", "list_rooms"} {
+		if listed[name] == nil {
+			t.Errorf("ListTools() is missing %s", name)
+		}
+	}
+
+	// Call a tool
+	room, err := fake.Add(fakewebex.Rooms, fakewebex.Item{"title": "E2E"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "create_a_message",
+		Arguments: map[string]any{"roomId": room["id"], "text": "hello"},
+	})
+	if err != nil || result.IsError {
+		t.Fatalf("CallTool(create_a_message) = %+v, %v", result, err)
+	}
+	if messages := fake.List(fakewebex.Messages); len(messages) != 1 || messages[0]["text"] != "hello" {
+		t.Errorf("messages = %v", messages)
+	}
+	if text := resultText(result); !strings.Contains(text, "hello") {
+		t.Errorf("create_a_message result = %q", text)
+	}
+
+	// Webex failures are tool errors, unknown tools are protocol errors
+	fake.InjectFault(fakewebex.Fault{Method: http.MethodPost, Path: "/messages", Status: http.StatusForbidden})
+	result, err = session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "create_a_message",
+		Arguments: map[string]any{"roomId": room["id"], "text": "denied"},
+	})
+	if err != nil || !result.IsError || !strings.Contains(resultText(result), "Forbidden") {
+		t.Errorf("CallTool() with a Webex 403 = %+v, %v, want an error result", result, err)
+	}
+	if _, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "no_such_tool"}); err == nil {
+		t.Error("CallTool(no_such_tool) succeeded, want a protocol error")
+	}
+
+	// Cancel a call stuck on Webex; the session stays usable
+	requests := fake.Requests()
+	fake.InjectFault(fakewebex.Fault{Method: http.MethodGet, Path: "/rooms", Delay: time.Second})
+	callCtx, cancelCall := context.WithCancel(ctx)
+	done := make(chan error, 1)
+	go func() {
+		_, err := session.CallTool(callCtx, &mcp.CallToolParams{Name: "list_rooms", Arguments: map[string]any{}})
+		done <- err
+	}()
+	waitFor(t, "the stalled Webex request", func() bool { return fake.Requests() > requests })
+	cancelCall()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("cancelled CallTool() error = %v, want context.Canceled", err)
+		}
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("CallTool() did not return after cancellation")
+	}
+	if err := session.Ping(ctx, nil); err != nil {
+		t.Errorf("Ping() after cancellation error = %v", err)
+	}
+	result, err = session.CallTool(ctx, &mcp.CallToolParams{Name: "list_rooms", Arguments: map[string]any{}})
+	if err != nil || result.IsError || !strings.Contains(resultText(result), "E2E") {
+		t.Errorf("CallTool(list_rooms) after cancellation = %+v, %v", result, err)
+	}
+}
+
+// connect initializes a client session and closes it when the test ends. The SSE
+// client holds its event stream open with the connect context, so it lives as long.
+func connect(t *testing.T, transport mcp.Transport) *mcp.ClientSession {
+	t.Helper()
+	client := mcp.NewClient(&mcp.Implementation{Name: "e2e-client", Version: "1.0.0"}, nil)
+	session, err := client.Connect(t.Context(), transport, nil)
+	if err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	t.Cleanup(func() { session.Close() })
+	return session
+}
+
+// useFakeWebex points the in-process configuration and default client at the fake
+func useFakeWebex(t *testing.T, baseURL string) *config.Config {
+	t.Helper()
+	cleanups := []func(){
+		testutil.SetEnv(t, "WEBEX_PUBLIC_WORKSPACE_API_KEY", "e2e-token"),
+		testutil.SetEnv(t, "WEBEX_API_BASE_URL", baseURL),
+	}
+	config.ResetForTesting()
+	tools.ResetDefaultClient()
+	t.Cleanup(func() {
+		for _, cleanup := range cleanups {
+			cleanup()
+		}
+		config.ResetForTesting()
+		tools.ResetDefaultClient()
+	})
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cfg
+}
+
+func newServer(t *testing.T, baseURL string) *mcp.Server {
+	t.Helper()
+	cfg := useFakeWebex(t, baseURL)
+	mcpServer, _, err := server.CreateMCPServerFromConfig(serverName, serverVersion, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return mcpServer
+}
+
+func connectInMemory(t *testing.T, baseURL string) mcp.Transport {
+	mcpServer := newServer(t, baseURL)
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	serverSession, err := mcpServer.Connect(context.Background(), serverTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { serverSession.Close() })
+	return clientTransport
+}
+
+// connectStdio runs this test binary as the server, in a directory without a .env file
+func connectStdio(t *testing.T, baseURL string) mcp.Transport {
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command(exe)
+	cmd.Dir = t.TempDir()
+	cmd.Env = append(os.Environ(),
+		serveStdioEnv+"=1",
+		"WEBEX_PUBLIC_WORKSPACE_API_KEY=e2e-token",
+		"WEBEX_API_BASE_URL="+baseURL,
+		"MCP_SERVER_MODE=stdio",
+		"WEBEX_MCP_CONFIG=",
+	)
+	return mcp.NewCommandTransport(cmd)
+}
+
+// startHTTPServer serves the real HTTP stack on a unix socket and returns a client for it
+func startHTTPServer(t *testing.T, baseURL string) *http.Client {
+	mcpServer := newServer(t, baseURL)
+	socket := filepath.Join(t.TempDir(), "mcp.sock")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.RunHTTPServerWithConfig(ctx, config.ServerConfig{Addr: "unix://" + socket}, mcpServer, nil, serverName, serverVersion)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-errCh; err != nil {
+			t.Errorf("RunHTTPServerWithConfig() error = %v", err)
+		}
+	})
+	waitFor(t, "the HTTP server socket", func() bool {
+		_, err := os.Stat(socket)
+		return err == nil
+	})
+
+	return &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, "unix", socket)
+		},
+	}}
+}
+
+func connectStreamable(t *testing.T, baseURL string) mcp.Transport {
+	return &mcp.StreamableClientTransport{Endpoint: "http://mcp/", HTTPClient: startHTTPServer(t, baseURL), MaxRetries: -1}
+}
+
+func connectSSE(t *testing.T, baseURL string) mcp.Transport {
+	return &mcp.SSEClientTransport{Endpoint: "http://mcp/sse", HTTPClient: startHTTPServer(t, baseURL)}
+}
+
+func waitFor(t *testing.T, what string, ready func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !ready() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func resultText(result *mcp.CallToolResult) string {
+	var text strings.Builder
+	for _, content := range result.Content {
+		if c, ok := content.(*mcp.TextContent); ok {
+			text.WriteString(c.Text)
+		}
+	}
+	return text.String()
+}
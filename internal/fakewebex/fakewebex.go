@@ -0,0 +1,551 @@
+// Package fakewebex is an in-memory implementation of the parts of the Webex REST API
+// used by the tools: rooms, messages, people, memberships, teams, team memberships,
+// webhooks, attachment actions and events. It serves tests and offline demos
+// through WEBEX_API_BASE_URL.
+package fakewebex
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Item is a Webex resource as it appears in JSON
+type Item = map[string]interface{}
+
+// Collection names, as they appear in request paths
+const (
+	Rooms             = "rooms"
+	Messages          = "messages"
+	People            = "people"
+	Memberships       = "memberships"
+	Teams             = "teams"
+	TeamMemberships   = "team/memberships"
+	Webhooks          = "webhooks"
+	AttachmentActions = "attachment/actions"
+	Events            = "events"
+)
+
+// DefaultMax is the page size when a list request does not set max
+const DefaultMax = 100
+
+// timeFormat is the timestamp format Webex uses
+const timeFormat = "2006-01-02T15:04:05.000Z"
+
+// Fault makes matching requests fail, for example with 429 Too Many Requests, or stall
+type Fault struct {
+	Method     string        // Empty matches any method
+	Path       string        // Path prefix such as /messages; empty matches any path
+	Status     int           // Response status code; 0 serves the request normally after Delay
+	Message    string        // Error message, defaults to the status text
+	RetryAfter time.Duration // Sent as Retry-After, in seconds
+	Delay      time.Duration // Wait before responding, or until the client gives up
+	Times      int           // Number of requests to affect, 0 means once
+}
+
+// Server is a fake Webex API; it implements http.Handler
+type Server struct {
+	mu          sync.Mutex
+	token       string // Accepted bearer token; any token when empty
+	me          string // ID of the authenticated person
+	orgID       string
+	collections map[string]*collection
+	faults      []*Fault
+	seq         int
+	requests    int
+	now         func() time.Time
+}
+
+// collection holds one resource type in creation order
+type collection struct {
+	ids   []string
+	items map[string]Item
+}
+
+// New creates an empty fake with an authenticated user, "Fake User"
+func New() *Server {
+	s := &Server{
+		collections: make(map[string]*collection),
+		now:         time.Now,
+	}
+	for name := range specs {
+		s.collections[name] = &collection{items: make(map[string]Item)}
+	}
+	s.orgID = s.newID("ORGANIZATION")
+	me, _ := s.create(People, Item{"emails": []interface{}{"fake.user@example.com"}, "displayName": "Fake User"})
+	s.me = me["id"].(string)
+	return s
+}
+
+// SetToken makes the fake reject requests whose bearer token is not token
+func (s *Server) SetToken(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.token = token
+}
+
+// SetClock replaces the clock used for created and lastActivity timestamps
+func (s *Server) SetClock(now func() time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.now = now
+}
+
+// Me returns the authenticated person
+func (s *Server) Me() Item {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return copyItem(s.collections[People].items[s.me])
+}
+
+// Add creates a resource as if it were POSTed by the authenticated user
+func (s *Server) Add(name string, fields Item) (Item, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	item, err := s.create(name, copyItem(fields))
+	if err != nil {
+		return nil, err
+	}
+	return copyItem(item), nil
+}
+
+// PostAs creates a message as if personID had sent it, for conversations with other people
+func (s *Server) PostAs(personID string, fields Item) (Item, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.collections[People].items[personID]; !ok {
+		return nil, notFound("person %s not found", personID)
+	}
+	me := s.me
+	s.me = personID
+	defer func() { s.me = me }()
+
+	item, err := s.create(Messages, copyItem(fields))
+	if err != nil {
+		return nil, err
+	}
+	return copyItem(item), nil
+}
+
+// SubmitAs submits the card of a message as if personID had pressed its submit button
+func (s *Server) SubmitAs(personID, messageID string, inputs Item) (Item, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.collections[People].items[personID]; !ok {
+		return nil, notFound("person %s not found", personID)
+	}
+	me := s.me
+	s.me = personID
+	defer func() { s.me = me }()
+
+	item, err := s.create(AttachmentActions, Item{"type": "submit", "messageId": messageID, "inputs": copyItem(inputs)})
+	if err != nil {
+		return nil, err
+	}
+	return copyItem(item), nil
+}
+
+// Get returns a resource by ID
+func (s *Server) Get(name, id string) (Item, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.collections[name]
+	if !ok {
+		return nil, false
+	}
+	item, ok := c.items[id]
+	return copyItem(item), ok
+}
+
+// List returns every resource in a collection in creation order
+func (s *Server) List(name string) []Item {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.collections[name]
+	if !ok {
+		return nil
+	}
+	items := make([]Item, 0, len(c.ids))
+	for _, id := range c.ids {
+		items = append(items, copyItem(c.items[id]))
+	}
+	return items
+}
+
+// InjectFault makes the next matching requests fail
+func (s *Server) InjectFault(fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if fault.Times <= 0 {
+		fault.Times = 1
+	}
+	s.faults = append(s.faults, &fault)
+}
+
+// Requests returns the number of requests served, including failed ones
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+// Seed adds demo data: a team, two rooms, a colleague and a short conversation
+func (s *Server) Seed() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	alice, err := s.create(People, Item{"emails": []interface{}{"alice@example.com"}, "displayName": "Alice Example"})
+	if err != nil {
+		return err
+	}
+	team, err := s.create(Teams, Item{"name": "Engineering"})
+	if err != nil {
+		return err
+	}
+	standup, err := s.create(Rooms, Item{"title": "Daily Standup", "teamId": team["id"]})
+	if err != nil {
+		return err
+	}
+	if _, err := s.create(Rooms, Item{"title": "Random"}); err != nil {
+		return err
+	}
+	if _, err := s.create(Memberships, Item{"roomId": standup["id"], "personId": alice["id"]}); err != nil {
+		return err
+	}
+	for _, text := range []string{"Good morning! Standup in 5 minutes.", "Yesterday I fixed the login bug."} {
+		if _, err := s.create(Messages, Item{"roomId": standup["id"], "text": text}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ServeHTTP serves the API under / and, like webexapis.com, under /v1
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests++
+	path := strings.TrimPrefix(r.URL.Path, "/v1")
+	fault := s.takeFault(r.Method, path)
+	s.mu.Unlock()
+
+	// Stall without holding the lock, so other requests are still served
+	if fault != nil && fault.Delay > 0 {
+		select {
+		case <-time.After(fault.Delay):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if fault != nil && fault.Status != 0 {
+		if fault.RetryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(fault.RetryAfter.Round(time.Second)/time.Second)))
+		}
+		message := fault.Message
+		if message == "" {
+			message = http.StatusText(fault.Status)
+		}
+		s.writeError(w, fault.Status, message)
+		return
+	}
+
+	auth := r.Header.Get("Authorization")
+	token, ok := strings.CutPrefix(auth, "Bearer ")
+	if !ok || token == "" || (s.token != "" && token != s.token) {
+		s.writeError(w, http.StatusUnauthorized, "The request requires a valid access token set in the Authorization request header.")
+		return
+	}
+
+	if path == "/people/me" && r.Method == http.MethodGet {
+		s.writeJSON(w, http.StatusOK, s.collections[People].items[s.me])
+		return
+	}
+
+	name, id := splitPath(path)
+	if _, ok := specs[name]; !ok {
+		s.writeError(w, http.StatusNotFound, "The requested resource could not be found.")
+		return
+	}
+
+	switch {
+	case id == "" && r.Method == http.MethodGet:
+		s.handleList(w, r, name)
+	case id == "" && r.Method == http.MethodPost:
+		var fields Item
+		if err := json.NewDecoder(r.Body).Decode(&fields); err != nil {
+			s.writeError(w, http.StatusBadRequest, "Request body is not valid JSON: "+err.Error())
+			return
+		}
+		item, err := s.create(name, fields)
+		if err != nil {
+			s.writeAPIError(w, err)
+			return
+		}
+		s.writeJSON(w, http.StatusOK, item)
+	case id != "" && r.Method == http.MethodGet:
+		item, ok := s.collections[name].items[id]
+		if !ok {
+			s.writeError(w, http.StatusNotFound, "The requested resource could not be found.")
+			return
+		}
+		s.writeJSON(w, http.StatusOK, item)
+	case id != "" && r.Method == http.MethodPut:
+		var fields Item
+		if err := json.NewDecoder(r.Body).Decode(&fields); err != nil {
+			s.writeError(w, http.StatusBadRequest, "Request body is not valid JSON: "+err.Error())
+			return
+		}
+		item, err := s.update(name, id, fields)
+		if err != nil {
+			s.writeAPIError(w, err)
+			return
+		}
+		s.writeJSON(w, http.StatusOK, item)
+	case id != "" && r.Method == http.MethodDelete:
+		if err := s.remove(name, id); err != nil {
+			s.writeAPIError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		s.writeError(w, http.StatusMethodNotAllowed, "Method not allowed.")
+	}
+}
+
+// splitPath splits /team/memberships/ID into the collection name and the ID
+func splitPath(path string) (string, string) {
+	path = strings.Trim(path, "/")
+	for name := range specs {
+		if path == name {
+			return name, ""
+		}
+		if id, ok := strings.CutPrefix(path, name+"/"); ok && !strings.Contains(id, "/") {
+			return name, id
+		}
+	}
+	return path, ""
+}
+
+// handleList filters a collection by query parameters and pages through it with Link headers
+func (s *Server) handleList(w http.ResponseWriter, r *http.Request, name string) {
+	spec := specs[name]
+	query := r.URL.Query()
+	for _, param := range spec.requiredQuery {
+		if query.Get(param) == "" {
+			s.writeError(w, http.StatusBadRequest, fmt.Sprintf("The %s query parameter is required.", param))
+			return
+		}
+	}
+
+	max := DefaultMax
+	if value := query.Get("max"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			s.writeError(w, http.StatusBadRequest, "max must be a positive integer.")
+			return
+		}
+		max = n
+	}
+	offset, _ := strconv.Atoi(query.Get("cursor"))
+
+	c := s.collections[name]
+	ids := c.ids
+	if before := query.Get("beforeMessage"); before != "" && name == Messages {
+		ids = idsBefore(ids, before)
+	}
+	matches := []Item{}
+	for _, id := range ids {
+		item := c.items[id]
+		if matchesQuery(item, query, spec) {
+			matches = append(matches, item)
+		}
+	}
+	if spec.newestFirst {
+		for i, j := 0, len(matches)-1; i < j; i, j = i+1, j-1 {
+			matches[i], matches[j] = matches[j], matches[i]
+		}
+	}
+
+	if offset > len(matches) {
+		offset = len(matches)
+	}
+	end := offset + max
+	if end < len(matches) {
+		next := *r.URL
+		nextQuery := next.Query()
+		nextQuery.Set("cursor", strconv.Itoa(end))
+		next.RawQuery = nextQuery.Encode()
+		next.Host = r.Host
+		next.Scheme = "http"
+		if r.TLS != nil {
+			next.Scheme = "https"
+		}
+		w.Header().Set("Link", fmt.Sprintf("<%s>; rel=\"next\"", next.String()))
+	} else {
+		end = len(matches)
+	}
+
+	s.writeJSON(w, http.StatusOK, Item{"items": matches[offset:end]})
+}
+
+// idsBefore returns the IDs created before id, or none when id is unknown
+func idsBefore(ids []string, id string) []string {
+	for i, candidate := range ids {
+		if candidate == id {
+			return ids[:i]
+		}
+	}
+	return nil
+}
+
+// matchesQuery applies the collection's filters; unknown parameters are ignored like Webex does
+func matchesQuery(item Item, query url.Values, spec resourceSpec) bool {
+	for _, field := range spec.filters {
+		want := query.Get(field)
+		if want == "" {
+			continue
+		}
+		switch field {
+		case "email":
+			if !containsString(item["emails"], want) {
+				return false
+			}
+		case "displayName":
+			name, _ := item["displayName"].(string)
+			if !strings.HasPrefix(strings.ToLower(name), strings.ToLower(want)) {
+				return false
+			}
+		case "id":
+			id, _ := item["id"].(string)
+			if !containsString(toList(strings.Split(want, ",")), id) {
+				return false
+			}
+		case "before":
+			created, _ := item["created"].(string)
+			createdAt, err1 := time.Parse(time.RFC3339Nano, created)
+			before, err2 := time.Parse(time.RFC3339Nano, want)
+			if err1 != nil || err2 != nil || !createdAt.Before(before) {
+				return false
+			}
+		case "from":
+			created, _ := item["created"].(string)
+			createdAt, err1 := time.Parse(time.RFC3339Nano, created)
+			from, err2 := time.Parse(time.RFC3339Nano, want)
+			if err1 != nil || err2 != nil || createdAt.Before(from) {
+				return false
+			}
+		default:
+			if fmt.Sprint(item[field]) != want {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// takeFault returns and consumes the first fault matching the request
+func (s *Server) takeFault(method, path string) *Fault {
+	for i, fault := range s.faults {
+		if fault.Method != "" && fault.Method != method {
+			continue
+		}
+		if fault.Path != "" && !strings.HasPrefix(path, fault.Path) {
+			continue
+		}
+		fault.Times--
+		if fault.Times == 0 {
+			s.faults = append(s.faults[:i], s.faults[i+1:]...)
+		}
+		return fault
+	}
+	return nil
+}
+
+// apiError is an error with the status code the API answers with
+type apiError struct {
+	status  int
+	message string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%d %s", e.status, e.message)
+}
+
+func badRequest(format string, args ...interface{}) error {
+	return &apiError{status: http.StatusBadRequest, message: fmt.Sprintf(format, args...)}
+}
+
+func notFound(format string, args ...interface{}) error {
+	return &apiError{status: http.StatusNotFound, message: fmt.Sprintf(format, args...)}
+}
+
+func (s *Server) writeAPIError(w http.ResponseWriter, err error) {
+	if apiErr, ok := err.(*apiError); ok {
+		s.writeError(w, apiErr.status, apiErr.message)
+		return
+	}
+	s.writeError(w, http.StatusInternalServerError, err.Error())
+}
+
+// writeError writes an error body in the Webex format
+func (s *Server) writeError(w http.ResponseWriter, status int, message string) {
+	s.writeJSON(w, status, Item{
+		"message":    message,
+		"errors":     []Item{{"description": message}},
+		"trackingId": fmt.Sprintf("FAKE_%d", s.requests),
+	})
+}
+
+func (s *Server) writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Trackingid", fmt.Sprintf("FAKE_%d", s.requests))
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// newID returns a Webex-style ID: base64 of ciscospark://us/<KIND>/<uuid>
+func (s *Server) newID(kind string) string {
+	s.seq++
+	uuid := fmt.Sprintf("%08x-0000-4000-8000-%012x", s.seq, s.seq)
+	return base64.RawStdEncoding.EncodeToString([]byte("ciscospark://us/" + kind + "/" + uuid))
+}
+
+func (s *Server) timestamp() string {
+	return s.now().UTC().Format(timeFormat)
+}
+
+func copyItem(item Item) Item {
+	if item == nil {
+		return nil
+	}
+	data, _ := json.Marshal(item)
+	var copied Item
+	json.Unmarshal(data, &copied)
+	return copied
+}
+
+func toList(values []string) []interface{} {
+	list := make([]interface{}, len(values))
+	for i, value := range values {
+		list[i] = value
+	}
+	return list
+}
+
+func containsString(list interface{}, want string) bool {
+	values, _ := list.([]interface{})
+	for _, value := range values {
+		if s, ok := value.(string); ok && strings.EqualFold(s, want) {
+			return true
+		}
+	}
+	return false
+}
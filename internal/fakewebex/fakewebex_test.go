@@ -0,0 +1,214 @@
+package fakewebex
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func startFake(t *testing.T) (*Server, string) {
+	t.Helper()
+	fake := New()
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	return fake, server.URL + "/v1"
+}
+
+// call sends a request with a bearer token and decodes the JSON response
+func call(t *testing.T, method, url, body string) (int, http.Header, Item) {
+	t.Helper()
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer test-token")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var decoded Item
+	json.NewDecoder(resp.Body).Decode(&decoded)
+	return resp.StatusCode, resp.Header, decoded
+}
+
+func TestServer_Rooms(t *testing.T) {
+	fake, base := startFake(t)
+
+	status, _, room := call(t, http.MethodPost, base+"/rooms", `{"title":"Project X"}`)
+	if status != http.StatusOK || room["type"] != "group" || room["creatorId"] != fake.Me()["id"] {
+		t.Fatalf("create room = %d %v", status, room)
+	}
+	roomID := room["id"].(string)
+
+	// The creator is a member
+	status, _, list := call(t, http.MethodGet, base+"/memberships?roomId="+roomID, "")
+	if items := list["items"].([]interface{}); status != http.StatusOK || len(items) != 1 {
+		t.Errorf("room memberships = %d %v", status, list)
+	}
+
+	if status, _, updated := call(t, http.MethodPut, base+"/rooms/"+roomID, `{"title":"Project Y","type":"direct"}`); status != http.StatusOK || updated["title"] != "Project Y" || updated["type"] != "group" {
+		t.Errorf("update room = %d %v", status, updated)
+	}
+
+	if status, _, _ := call(t, http.MethodDelete, base+"/rooms/"+roomID, ""); status != http.StatusNoContent {
+		t.Errorf("delete room status = %d", status)
+	}
+	if status, _, body := call(t, http.MethodGet, base+"/rooms/"+roomID, ""); status != http.StatusNotFound || body["message"] == "" {
+		t.Errorf("get deleted room = %d %v", status, body)
+	}
+	if len(fake.List(Memberships)) != 0 {
+		t.Errorf("memberships of a deleted room remain: %v", fake.List(Memberships))
+	}
+
+	if status, _, body := call(t, http.MethodPost, base+"/rooms", `{}`); status != http.StatusBadRequest || !strings.Contains(body["message"].(string), "title") {
+		t.Errorf("create room without title = %d %v", status, body)
+	}
+}
+
+func TestServer_MessagesAndPagination(t *testing.T) {
+	fake, base := startFake(t)
+	clock := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	fake.SetClock(func() time.Time {
+		clock = clock.Add(time.Second)
+		return clock
+	})
+
+	room, err := fake.Add(Rooms, Item{"title": "Chat"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	roomID := room["id"].(string)
+	var ids []string
+	for _, text := range []string{"one", "two", "three"} {
+		message, err := fake.Add(Messages, Item{"roomId": roomID, "text": text})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, message["id"].(string))
+	}
+
+	// Newest first, two per page, with a Link header to the next page
+	status, header, page := call(t, http.MethodGet, base+"/messages?roomId="+roomID+"&max=2", "")
+	items := page["items"].([]interface{})
+	if status != http.StatusOK || len(items) != 2 || items[0].(Item)["text"] != "three" {
+		t.Fatalf("first page = %d %v", status, page)
+	}
+	link := header.Get("Link")
+	next := strings.TrimSuffix(strings.TrimPrefix(link, "<"), `>; rel="next"`)
+	if !strings.HasPrefix(next, base+"/messages?") {
+		t.Fatalf("Link = %q", link)
+	}
+	_, header, page = call(t, http.MethodGet, next, "")
+	if items := page["items"].([]interface{}); len(items) != 1 || items[0].(Item)["text"] != "one" || header.Get("Link") != "" {
+		t.Errorf("last page = %v, Link = %q", page, header.Get("Link"))
+	}
+
+	_, _, page = call(t, http.MethodGet, base+"/messages?roomId="+roomID+"&beforeMessage="+ids[2], "")
+	if items := page["items"].([]interface{}); len(items) != 2 || items[0].(Item)["text"] != "two" {
+		t.Errorf("messages before the last = %v", page)
+	}
+
+	if status, _, _ := call(t, http.MethodGet, base+"/messages", ""); status != http.StatusBadRequest {
+		t.Errorf("list messages without roomId status = %d, want 400", status)
+	}
+
+	// A direct message creates a direct room and the recipient
+	status, _, direct := call(t, http.MethodPost, base+"/messages", `{"toPersonEmail":"bob@example.com","text":"hi"}`)
+	if status != http.StatusOK || direct["roomType"] != "direct" || direct["toPersonEmail"] != nil {
+		t.Fatalf("direct message = %d %v", status, direct)
+	}
+	_, _, people := call(t, http.MethodGet, base+"/people?email=bob@example.com", "")
+	if items := people["items"].([]interface{}); len(items) != 1 {
+		t.Errorf("people with bob's email = %v", people)
+	}
+	_, _, again := call(t, http.MethodPost, base+"/messages", `{"toPersonEmail":"bob@example.com","text":"again"}`)
+	if again["roomId"] != direct["roomId"] {
+		t.Error("second direct message went to a new room")
+	}
+}
+
+func TestServer_AuthAndFaults(t *testing.T) {
+	fake, base := startFake(t)
+	fake.SetToken("right-token")
+
+	if status, _, _ := call(t, http.MethodGet, base+"/people/me", ""); status != http.StatusUnauthorized {
+		t.Errorf("wrong token status = %d, want 401", status)
+	}
+	fake.SetToken("")
+	if status, _, me := call(t, http.MethodGet, base+"/people/me", ""); status != http.StatusOK || me["displayName"] != "Fake User" {
+		t.Errorf("people/me = %d %v", status, me)
+	}
+
+	fake.InjectFault(Fault{Path: "/rooms", Status: http.StatusTooManyRequests, RetryAfter: 3 * time.Second, Times: 2})
+	for i := 0; i < 2; i++ {
+		status, header, _ := call(t, http.MethodGet, base+"/rooms", "")
+		if status != http.StatusTooManyRequests || header.Get("Retry-After") != "3" {
+			t.Errorf("request %d = %d Retry-After %q, want 429 after 3s", i, status, header.Get("Retry-After"))
+		}
+	}
+	if status, _, _ := call(t, http.MethodGet, base+"/rooms", ""); status != http.StatusOK {
+		t.Errorf("request after the fault status = %d", status)
+	}
+	if status, _, _ := call(t, http.MethodGet, base+"/people/me", ""); status != http.StatusOK {
+		t.Errorf("faults should only match their path, got %d", status)
+	}
+
+	// A fault without a status only stalls the request
+	fake.InjectFault(Fault{Path: "/rooms", Delay: 50 * time.Millisecond})
+	start := time.Now()
+	if status, _, _ := call(t, http.MethodGet, base+"/rooms", ""); status != http.StatusOK || time.Since(start) < 50*time.Millisecond {
+		t.Errorf("stalled request = %d after %v", status, time.Since(start))
+	}
+	if fake.Requests() != 7 {
+		t.Errorf("Requests() = %d, want 7", fake.Requests())
+	}
+}
+
+func TestServer_Seed(t *testing.T) {
+	fake := New()
+	if err := fake.Seed(); err != nil {
+		t.Fatal(err)
+	}
+	if len(fake.List(Rooms)) != 2 || len(fake.List(Messages)) != 2 || len(fake.List(Teams)) != 1 {
+		t.Errorf("seeded %d rooms, %d messages, %d teams", len(fake.List(Rooms)), len(fake.List(Messages)), len(fake.List(Teams)))
+	}
+}
+
+func TestServer_AttachmentActions(t *testing.T) {
+	fake, base := startFake(t)
+	room, _ := fake.Add(Rooms, Item{"title": "Approvals"})
+	card, err := fake.Add(Messages, Item{"roomId": room["id"], "markdown": "card", "attachments": []interface{}{Item{"contentType": "application/vnd.microsoft.card.adaptive"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	text, _ := fake.Add(Messages, Item{"roomId": room["id"], "text": "no card"})
+	alice, _ := fake.Add(People, Item{"emails": []interface{}{"alice@example.com"}})
+
+	action, err := fake.SubmitAs(alice["id"].(string), card["id"].(string), Item{"decision": "approve"})
+	if err != nil || action["personId"] != alice["id"] || action["roomId"] != room["id"] {
+		t.Fatalf("SubmitAs() = %v, %v", action, err)
+	}
+	if _, err := fake.SubmitAs(alice["id"].(string), text["id"].(string), nil); err == nil {
+		t.Error("submitting a message without a card succeeded")
+	}
+
+	status, _, got := call(t, http.MethodGet, base+"/attachment/actions/"+action["id"].(string), "")
+	if inputs, _ := got["inputs"].(Item); status != http.StatusOK || inputs["decision"] != "approve" {
+		t.Errorf("get attachment action = %d %v", status, got)
+	}
+
+	// Each submission is recorded as an event
+	_, _, page := call(t, http.MethodGet, base+"/events?resource=attachmentActions&type=created&from="+action["created"].(string), "")
+	items := page["items"].([]interface{})
+	if len(items) != 1 || items[0].(Item)["actorId"] != alice["id"] || items[0].(Item)["data"].(Item)["messageId"] != card["id"] {
+		t.Fatalf("events = %v", page)
+	}
+	_, _, page = call(t, http.MethodGet, base+"/events?resource=attachmentActions&from=2999-01-01T00:00:00.000Z", "")
+	if items := page["items"].([]interface{}); len(items) != 0 {
+		t.Errorf("events from the future = %v", items)
+	}
+}
@@ -0,0 +1,410 @@
+package fakewebex
+
+import "fmt"
+
+// resourceSpec describes how a collection is created, filtered and changed
+type resourceSpec struct {
+	kind          string                           // Resource kind encoded in IDs
+	required      []string                         // Fields a POST must set
+	requiredQuery []string                         // Query parameters a list request must set
+	filters       []string                         // Query parameters that filter lists
+	readOnly      []string                         // Fields a PUT may not change
+	newestFirst   bool                             // List newest items first, like messages
+	create        func(s *Server, item Item) error // Fills defaults, may reject the item
+}
+
+// specs is filled in init because the create functions refer back to it
+var specs map[string]resourceSpec
+
+func init() {
+	specs = map[string]resourceSpec{
+		Rooms: {
+			kind:     "ROOM",
+			required: []string{"title"},
+			filters:  []string{"type", "teamId"},
+			readOnly: []string{"type", "creatorId", "created"},
+			create:   (*Server).createRoom,
+		},
+		Messages: {
+			kind:          "MESSAGE",
+			requiredQuery: []string{"roomId"},
+			filters:       []string{"roomId", "parentId", "personId", "before"},
+			readOnly:      []string{"roomId", "roomType", "personId", "personEmail", "created"},
+			newestFirst:   true,
+			create:        (*Server).createMessage,
+		},
+		People: {
+			kind:     "PEOPLE",
+			required: []string{"emails"},
+			filters:  []string{"id", "email", "displayName", "orgId"},
+			readOnly: []string{"created"},
+			create:   (*Server).createPerson,
+		},
+		Memberships: {
+			kind:     "MEMBERSHIP",
+			required: []string{"roomId"},
+			filters:  []string{"roomId", "personId", "personEmail"},
+			readOnly: []string{"roomId", "personId", "personEmail", "created"},
+			create:   (*Server).createMembership,
+		},
+		Teams: {
+			kind:     "TEAM",
+			required: []string{"name"},
+			readOnly: []string{"creatorId", "created"},
+			create:   (*Server).createTeam,
+		},
+		TeamMemberships: {
+			kind:          "TEAM_MEMBERSHIP",
+			required:      []string{"teamId"},
+			requiredQuery: []string{"teamId"},
+			filters:       []string{"teamId"},
+			readOnly:      []string{"teamId", "personId", "personEmail", "created"},
+			create:        (*Server).createTeamMembership,
+		},
+		Webhooks: {
+			kind:     "WEBHOOK",
+			required: []string{"name", "targetUrl", "resource", "event"},
+			readOnly: []string{"resource", "event", "created"},
+			create:   (*Server).createWebhook,
+		},
+		AttachmentActions: {
+			kind:     "ATTACHMENT_ACTION",
+			required: []string{"type", "messageId"},
+			create:   (*Server).createAttachmentAction,
+		},
+		Events: {
+			kind:        "EVENT",
+			required:    []string{"resource", "type"},
+			filters:     []string{"resource", "type", "actorId", "from"},
+			readOnly:    []string{"resource", "type", "actorId", "data", "created"},
+			newestFirst: true,
+			create:      (*Server).createEvent,
+		},
+	}
+}
+
+// create validates and stores a new resource, applying its side effects
+func (s *Server) create(name string, item Item) (Item, error) {
+	spec, ok := specs[name]
+	if !ok {
+		return nil, notFound("unknown resource %s", name)
+	}
+	if item == nil {
+		item = Item{}
+	}
+	for _, field := range spec.required {
+		if isEmpty(item[field]) {
+			return nil, badRequest("%s is required.", field)
+		}
+	}
+
+	id := s.newID(spec.kind)
+	item["id"] = id
+	item["created"] = s.timestamp()
+
+	// Store first so side effects such as the creator's membership can refer to the item
+	c := s.collections[name]
+	c.ids = append(c.ids, id)
+	c.items[id] = item
+	if err := spec.create(s, item); err != nil {
+		c.delete(id)
+		return nil, err
+	}
+	return item, nil
+}
+
+// update merges fields into a resource, ignoring those the API does not let clients change
+func (s *Server) update(name, id string, fields Item) (Item, error) {
+	item, ok := s.collections[name].items[id]
+	if !ok {
+		return nil, notFound("The requested resource could not be found.")
+	}
+	readOnly := map[string]bool{"id": true}
+	for _, field := range specs[name].readOnly {
+		readOnly[field] = true
+	}
+	for key, value := range fields {
+		if !readOnly[key] {
+			item[key] = value
+		}
+	}
+	if name == Messages {
+		s.touchRoom(item["roomId"])
+	}
+	return item, nil
+}
+
+// remove deletes a resource; deleting a room also deletes its messages and memberships
+func (s *Server) remove(name, id string) error {
+	c := s.collections[name]
+	if _, ok := c.items[id]; !ok {
+		return notFound("The requested resource could not be found.")
+	}
+	c.delete(id)
+
+	if name == Rooms {
+		for _, child := range []string{Messages, Memberships} {
+			children := s.collections[child]
+			for _, childID := range append([]string(nil), children.ids...) {
+				if children.items[childID]["roomId"] == id {
+					children.delete(childID)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (c *collection) delete(id string) {
+	delete(c.items, id)
+	for i, existing := range c.ids {
+		if existing == id {
+			c.ids = append(c.ids[:i], c.ids[i+1:]...)
+			break
+		}
+	}
+}
+
+func (s *Server) createRoom(item Item) error {
+	if teamID, ok := item["teamId"].(string); ok && teamID != "" {
+		if _, exists := s.collections[Teams].items[teamID]; !exists {
+			return notFound("Team %s not found.", teamID)
+		}
+	}
+	if isEmpty(item["type"]) {
+		item["type"] = "group"
+	}
+	item["creatorId"] = s.me
+	item["isLocked"] = false
+	item["lastActivity"] = item["created"]
+
+	// The creator is a member of every room they create
+	_, err := s.create(Memberships, Item{"roomId": item["id"], "personId": s.me})
+	return err
+}
+
+func (s *Server) createMessage(item Item) error {
+	if isEmpty(item["text"]) && isEmpty(item["markdown"]) && isEmpty(item["files"]) && isEmpty(item["attachments"]) {
+		return badRequest("Message must contain text, markdown, files or attachments.")
+	}
+	room, err := s.messageRoom(item)
+	if err != nil {
+		return err
+	}
+	if parentID, ok := item["parentId"].(string); ok && parentID != "" {
+		parent, exists := s.collections[Messages].items[parentID]
+		if !exists || parent["roomId"] != room["id"] {
+			return badRequest("Parent message %s is not in the room.", parentID)
+		}
+	}
+
+	me := s.collections[People].items[s.me]
+	item["roomId"] = room["id"]
+	item["roomType"] = room["type"]
+	item["personId"] = s.me
+	item["personEmail"] = firstEmail(me)
+	delete(item, "toPersonId")
+	delete(item, "toPersonEmail")
+	s.touchRoom(room["id"])
+	return nil
+}
+
+// messageRoom finds the room a message goes to, creating a direct room for toPersonId or toPersonEmail
+func (s *Server) messageRoom(item Item) (Item, error) {
+	if roomID, ok := item["roomId"].(string); ok && roomID != "" {
+		room, exists := s.collections[Rooms].items[roomID]
+		if !exists {
+			return nil, notFound("Room %s not found.", roomID)
+		}
+		return room, nil
+	}
+
+	var person Item
+	if personID, ok := item["toPersonId"].(string); ok && personID != "" {
+		person = s.collections[People].items[personID]
+		if person == nil {
+			return nil, notFound("Person %s not found.", personID)
+		}
+	} else if email, ok := item["toPersonEmail"].(string); ok && email != "" {
+		var err error
+		if person, err = s.personByEmail(email); err != nil {
+			return nil, err
+		}
+	} else {
+		return nil, badRequest("roomId, toPersonId or toPersonEmail is required.")
+	}
+
+	memberships := s.collections[Memberships]
+	for _, id := range memberships.ids {
+		membership := memberships.items[id]
+		if membership["personId"] != person["id"] {
+			continue
+		}
+		if room := s.collections[Rooms].items[membership["roomId"].(string)]; room["type"] == "direct" {
+			return room, nil
+		}
+	}
+
+	room, err := s.create(Rooms, Item{"title": person["displayName"], "type": "direct"})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.create(Memberships, Item{"roomId": room["id"], "personId": person["id"]}); err != nil {
+		return nil, err
+	}
+	return room, nil
+}
+
+func (s *Server) createPerson(item Item) error {
+	emails, ok := item["emails"].([]interface{})
+	if !ok || len(emails) == 0 {
+		return badRequest("emails must be a non-empty list.")
+	}
+	if isEmpty(item["displayName"]) {
+		item["displayName"] = emails[0]
+	}
+	if isEmpty(item["type"]) {
+		item["type"] = "person"
+	}
+	item["orgId"] = s.orgID
+	return nil
+}
+
+// personByEmail finds a person by email, creating one as Webex does when inviting a new user
+func (s *Server) personByEmail(email string) (Item, error) {
+	people := s.collections[People]
+	for _, id := range people.ids {
+		if containsString(people.items[id]["emails"], email) {
+			return people.items[id], nil
+		}
+	}
+	return s.create(People, Item{"emails": []interface{}{email}})
+}
+
+// memberPerson resolves personId or personEmail for a membership
+func (s *Server) memberPerson(item Item) (Item, error) {
+	if personID, ok := item["personId"].(string); ok && personID != "" {
+		person, exists := s.collections[People].items[personID]
+		if !exists {
+			return nil, notFound("Person %s not found.", personID)
+		}
+		return person, nil
+	}
+	if email, ok := item["personEmail"].(string); ok && email != "" {
+		return s.personByEmail(email)
+	}
+	return nil, badRequest("personId or personEmail is required.")
+}
+
+func (s *Server) createMembership(item Item) error {
+	roomID, _ := item["roomId"].(string)
+	if _, exists := s.collections[Rooms].items[roomID]; !exists {
+		return notFound("Room %s not found.", roomID)
+	}
+	person, err := s.memberPerson(item)
+	if err != nil {
+		return err
+	}
+	memberships := s.collections[Memberships]
+	for _, id := range memberships.ids {
+		if id != item["id"] && memberships.items[id]["roomId"] == roomID && memberships.items[id]["personId"] == person["id"] {
+			return &apiError{status: 409, message: fmt.Sprintf("%s is already a member of the room.", firstEmail(person))}
+		}
+	}
+	fillMember(item, person)
+	return nil
+}
+
+func (s *Server) createTeam(item Item) error {
+	item["creatorId"] = s.me
+	_, err := s.create(TeamMemberships, Item{"teamId": item["id"], "personId": s.me, "isModerator": true})
+	return err
+}
+
+func (s *Server) createTeamMembership(item Item) error {
+	teamID, _ := item["teamId"].(string)
+	if _, exists := s.collections[Teams].items[teamID]; !exists {
+		return notFound("Team %s not found.", teamID)
+	}
+	person, err := s.memberPerson(item)
+	if err != nil {
+		return err
+	}
+	fillMember(item, person)
+	return nil
+}
+
+func (s *Server) createWebhook(item Item) error {
+	item["status"] = "active"
+	item["createdBy"] = s.me
+	item["orgId"] = s.orgID
+	return nil
+}
+
+func (s *Server) createAttachmentAction(item Item) error {
+	if item["type"] != "submit" {
+		return badRequest("type must be submit.")
+	}
+	messageID, _ := item["messageId"].(string)
+	message, exists := s.collections[Messages].items[messageID]
+	if !exists {
+		return notFound("Message %s not found.", messageID)
+	}
+	if isEmpty(message["attachments"]) {
+		return badRequest("Message %s has no card.", messageID)
+	}
+	if item["inputs"] == nil {
+		item["inputs"] = Item{}
+	}
+	item["roomId"] = message["roomId"]
+	item["personId"] = s.me
+
+	// Compliance officers see every submission in the events API
+	_, err := s.create(Events, Item{"resource": "attachmentActions", "type": "created", "actorId": s.me, "data": copyItem(item)})
+	return err
+}
+
+func (s *Server) createEvent(item Item) error {
+	item["actorId"] = s.me
+	item["orgId"] = s.orgID
+	return nil
+}
+
+// touchRoom records activity in a room
+func (s *Server) touchRoom(roomID interface{}) {
+	id, _ := roomID.(string)
+	if room, ok := s.collections[Rooms].items[id]; ok {
+		room["lastActivity"] = s.timestamp()
+	}
+}
+
+func fillMember(item, person Item) {
+	item["personId"] = person["id"]
+	item["personEmail"] = firstEmail(person)
+	item["personDisplayName"] = person["displayName"]
+	item["personOrgId"] = person["orgId"]
+	if isEmpty(item["isModerator"]) {
+		item["isModerator"] = false
+	}
+}
+
+func firstEmail(person Item) string {
+	emails, _ := person["emails"].([]interface{})
+	if len(emails) == 0 {
+		return ""
+	}
+	email, _ := emails[0].(string)
+	return email
+}
+
+func isEmpty(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case []interface{}:
+		return len(v) == 0
+	}
+	return false
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -48,6 +49,57 @@ func HealthHandler(serviceName, version string) http.HandlerFunc {
 	}
 }
 
+// Readiness is the outcome of a readiness check
+type Readiness struct {
+	Ready   bool                   `json:"ready"`
+	Reasons []string               `json:"reasons,omitempty"`
+	Checks  map[string]interface{} `json:"checks,omitempty"`
+}
+
+// ReadinessFunc reports whether the server can serve tool calls
+type ReadinessFunc func(ctx context.Context) Readiness
+
+// ReadinessHandler returns an HTTP handler that answers 200 when ready and 503 with reasons otherwise
+func ReadinessHandler(serviceName, version string, check ReadinessFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		readiness := Readiness{Ready: true}
+		if check != nil {
+			readiness = check(r.Context())
+		}
+
+		status, code := "ready", http.StatusOK
+		if !readiness.Ready {
+			status, code = "not_ready", http.StatusServiceUnavailable
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(code)
+
+		response := map[string]interface{}{
+			"status":  status,
+			"service": serviceName,
+			"version": version,
+			"time":    time.Now().UTC().Format(time.RFC3339),
+		}
+		if len(readiness.Reasons) > 0 {
+			response["reasons"] = readiness.Reasons
+		}
+		if len(readiness.Checks) > 0 {
+			response["checks"] = readiness.Checks
+		}
+
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			log.Printf("[Readiness Handler] Failed to encode response: %v", err)
+		}
+	}
+}
+
 // CORSMiddleware provides CORS support
 func CORSMiddleware(options HandlerOptions) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -101,11 +153,15 @@ func writeErrorResponse(w http.ResponseWriter, errorResp ErrorResponse, statusCo
 	}
 }
 
-// SetupHTTPHandlers configures HTTP handlers for the server
-func SetupHTTPHandlers(server *mcp.Server, serviceName, version string) *http.ServeMux {
+// SetupHTTPHandlers configures HTTP handlers for the server.
+// /healthz (and the older /health) report liveness, /readyz runs ready; a nil ready always passes.
+// The middleware wraps the MCP endpoints only, leaving the probes and /info open.
+func SetupHTTPHandlers(server *mcp.Server, serviceName, version string, ready ReadinessFunc, middleware ...func(http.Handler) http.Handler) *http.ServeMux {
 	mux := http.NewServeMux()
 
 	mux.HandleFunc("/health", HealthHandler(serviceName, version))
+	mux.HandleFunc("/healthz", HealthHandler(serviceName, version))
+	mux.HandleFunc("/readyz", ReadinessHandler(serviceName, version, ready))
 
 	mux.HandleFunc("/info", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
@@ -129,14 +185,40 @@ func SetupHTTPHandlers(server *mcp.Server, serviceName, version string) *http.Se
 		json.NewEncoder(w).Encode(info)
 	})
 
-	mcpHandler := mcp.NewStreamableHTTPHandler(func(*http.Request) *mcp.Server {
+	getServer := func(*http.Request) *mcp.Server {
 		return server
-	}, nil)
-	mux.Handle("/", mcpHandler)
+	}
+
+	// Legacy HTTP+SSE clients (protocol 2024-11-05) use /sse and /messages,
+	// newer clients use streamable HTTP on every other path
+	wrap := func(handler http.Handler) http.Handler {
+		for i := len(middleware) - 1; i >= 0; i-- {
+			handler = middleware[i](handler)
+		}
+		return handler
+	}
+
+	sseHandler := NewSSEHandler(getServer, MessagesPath)
+	mux.Handle(SSEPath, wrap(http.HandlerFunc(sseHandler.ServeStream)))
+	mux.Handle(MessagesPath, wrap(http.HandlerFunc(sseHandler.ServeMessages)))
+
+	mcpHandler := mcp.NewStreamableHTTPHandler(getServer, nil)
+	mux.Handle("/", wrap(withoutWriteDeadline(mcpHandler)))
 
 	return mux
 }
 
+// withoutWriteDeadline lets streamable HTTP responses outlive the server's write
+// timeout, for event streams and tool calls that wait, such as await_card_response
+func withoutWriteDeadline(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := http.NewResponseController(w).SetWriteDeadline(time.Time{}); err != nil && err != http.ErrNotSupported {
+			log.Printf("[MCP] Failed to clear write deadline: %v", err)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 // ValidateRequest validates incoming requests
 func ValidateRequest(r *http.Request, allowedMethods []string) error {
 	methodAllowed := false
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -63,7 +64,7 @@ func TestHealthHandler(t *testing.T) {
 
 func TestSetupHTTPHandlers(t *testing.T) {
 	// Create a mock MCP server (we'll use nil for this test)
-	mux := SetupHTTPHandlers(nil, "test-service", "1.0.0")
+	mux := SetupHTTPHandlers(nil, "test-service", "1.0.0", nil)
 
 	if mux == nil {
 		t.Fatal("SetupHTTPHandlers returned nil")
@@ -84,6 +85,66 @@ func TestSetupHTTPHandlers(t *testing.T) {
 	}
 }
 
+func TestReadinessHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		check      ReadinessFunc
+		wantCode   int
+		wantStatus string
+		wantReason string
+	}{
+		{name: "no check", wantCode: http.StatusOK, wantStatus: "ready"},
+		{
+			name:       "ready",
+			check:      func(context.Context) Readiness { return Readiness{Ready: true} },
+			wantCode:   http.StatusOK,
+			wantStatus: "ready",
+		},
+		{
+			name: "not ready",
+			check: func(context.Context) Readiness {
+				return Readiness{Reasons: []string{"no tools are registered"}}
+			},
+			wantCode:   http.StatusServiceUnavailable,
+			wantStatus: "not_ready",
+			wantReason: "no tools are registered",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := SetupHTTPHandlers(nil, "test-service", "1.0.0", tt.check)
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+			if rr.Code != tt.wantCode {
+				t.Errorf("status code = %d, want %d", rr.Code, tt.wantCode)
+			}
+			var response struct {
+				Status  string   `json:"status"`
+				Reasons []string `json:"reasons"`
+			}
+			if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to parse response body: %v", err)
+			}
+			if response.Status != tt.wantStatus {
+				t.Errorf("status = %q, want %q", response.Status, tt.wantStatus)
+			}
+			if tt.wantReason != "" && (len(response.Reasons) != 1 || response.Reasons[0] != tt.wantReason) {
+				t.Errorf("reasons = %v, want [%s]", response.Reasons, tt.wantReason)
+			}
+		})
+	}
+
+	// Liveness does not depend on readiness
+	mux := SetupHTTPHandlers(nil, "test-service", "1.0.0", tests[2].check)
+	rr := httptest.NewRecorder()
+	mux.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if rr.Code != http.StatusOK {
+		t.Errorf("/healthz status code = %d, want %d", rr.Code, http.StatusOK)
+	}
+}
+
 func TestHealthHandler_ErrorHandling(t *testing.T) {
 	// This test verifies that the error logging in HealthHandler doesn't panic
 	// We can't easily test the actual logging, but we can ensure it handles errors gracefully
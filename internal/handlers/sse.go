@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Legacy HTTP+SSE endpoints from the 2024-11-05 MCP specification
+const (
+	SSEPath      = "/sse"
+	MessagesPath = "/messages"
+)
+
+// SSEHandler serves the 2024-11-05 HTTP+SSE transport. A GET on the stream path opens
+// an event stream whose first "endpoint" event names the messages URL, and the client
+// POSTs its JSON-RPC messages to that URL with the session ID in the query string.
+type SSEHandler struct {
+	getServer    func(*http.Request) *mcp.Server
+	messagesPath string
+
+	mu       sync.Mutex
+	sessions map[string]*mcp.SSEServerTransport
+}
+
+// NewSSEHandler creates an SSE handler that posts messages to messagesPath
+func NewSSEHandler(getServer func(*http.Request) *mcp.Server, messagesPath string) *SSEHandler {
+	return &SSEHandler{
+		getServer:    getServer,
+		messagesPath: messagesPath,
+		sessions:     make(map[string]*mcp.SSEServerTransport),
+	}
+}
+
+// ServeStream opens a session and holds the event stream until the client disconnects
+func (h *SSEHandler) ServeStream(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// The stream outlives the server's write timeout
+	if err := http.NewResponseController(w).SetWriteDeadline(time.Time{}); err != nil && err != http.ErrNotSupported {
+		log.Printf("[SSE] Failed to clear write deadline: %v", err)
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	sessionID, err := newSessionID()
+	if err != nil {
+		http.Error(w, "failed to create session", http.StatusInternalServerError)
+		return
+	}
+	transport := &mcp.SSEServerTransport{
+		Endpoint: h.messagesPath + "?sessionId=" + sessionID,
+		Response: w,
+	}
+
+	h.mu.Lock()
+	h.sessions[sessionID] = transport
+	h.mu.Unlock()
+	defer func() {
+		h.mu.Lock()
+		delete(h.sessions, sessionID)
+		h.mu.Unlock()
+	}()
+
+	session, err := h.getServer(r).Connect(r.Context(), transport, nil)
+	if err != nil {
+		log.Printf("[SSE] Failed to connect session %s: %v", sessionID, err)
+		http.Error(w, "connection failed", http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+
+	closed := make(chan struct{})
+	go func() {
+		session.Wait()
+		close(closed)
+	}()
+
+	select {
+	case <-r.Context().Done():
+	case <-closed:
+	}
+}
+
+// ServeMessages delivers a client message to the session named by the sessionId query parameter
+func (h *SSEHandler) ServeMessages(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	sessionID := r.URL.Query().Get("sessionId")
+	if sessionID == "" {
+		// Accept the spelling used by the SDK's own SSE handler
+		sessionID = r.URL.Query().Get("sessionid")
+	}
+	if sessionID == "" {
+		http.Error(w, "sessionId must be provided", http.StatusBadRequest)
+		return
+	}
+
+	h.mu.Lock()
+	transport := h.sessions[sessionID]
+	h.mu.Unlock()
+	if transport == nil {
+		http.Error(w, "session not found", http.StatusNotFound)
+		return
+	}
+
+	transport.ServeHTTP(w, r)
+}
+
+// newSessionID returns a random identifier that is hard to guess
+func newSessionID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func newTestMCPServer() *mcp.Server {
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	server.AddTool(&mcp.Tool{
+		Name:        "echo",
+		Description: "Echo a message",
+		InputSchema: &jsonschema.Schema{Type: "object"},
+	}, func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "ok"}}}, nil
+	})
+	return server
+}
+
+func TestSetupHTTPHandlers_BothTransports(t *testing.T) {
+	httpServer := httptest.NewServer(SetupHTTPHandlers(newTestMCPServer(), "test-service", "1.0.0", nil))
+	defer httpServer.Close()
+
+	transports := map[string]mcp.Transport{
+		"legacy SSE":      mcp.NewSSEClientTransport(httpServer.URL+SSEPath, nil),
+		"streamable HTTP": mcp.NewStreamableClientTransport(httpServer.URL, nil),
+	}
+
+	for name, transport := range transports {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, nil)
+			session, err := client.Connect(ctx, transport, nil)
+			if err != nil {
+				t.Fatalf("Connect() error = %v", err)
+			}
+			defer session.Close()
+
+			result, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "echo", Arguments: map[string]any{}})
+			if err != nil {
+				t.Fatalf("CallTool() error = %v", err)
+			}
+			if text := result.Content[0].(*mcp.TextContent).Text; text != "ok" {
+				t.Errorf("CallTool() text = %q, want ok", text)
+			}
+		})
+	}
+}
+
+func TestSSEHandler_Errors(t *testing.T) {
+	handler := NewSSEHandler(func(*http.Request) *mcp.Server { return newTestMCPServer() }, MessagesPath)
+
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		serve      http.HandlerFunc
+		wantStatus int
+	}{
+		{name: "stream rejects POST", method: http.MethodPost, target: SSEPath, serve: handler.ServeStream, wantStatus: http.StatusMethodNotAllowed},
+		{name: "messages rejects GET", method: http.MethodGet, target: MessagesPath, serve: handler.ServeMessages, wantStatus: http.StatusMethodNotAllowed},
+		{name: "missing session ID", method: http.MethodPost, target: MessagesPath, serve: handler.ServeMessages, wantStatus: http.StatusBadRequest},
+		{name: "unknown session", method: http.MethodPost, target: MessagesPath + "?sessionId=nope", serve: handler.ServeMessages, wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader("{}"))
+			rr := httptest.NewRecorder()
+			tt.serve(rr, req)
+			if rr.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rr.Code, tt.wantStatus)
+			}
+		})
+	}
+}
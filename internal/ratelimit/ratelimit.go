@@ -0,0 +1,137 @@
+// Package ratelimit provides the token buckets and concurrency cap that keep a
+// single MCP client from exhausting the shared Webex quota.
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// Rate is a token bucket refill rate and capacity. A zero Rate means unlimited.
+type Rate struct {
+	PerSecond float64 `yaml:"rate"`  // Tokens added per second
+	Burst     int     `yaml:"burst"` // Bucket capacity, defaults to ceil(rate)
+}
+
+// Unlimited reports whether the rate imposes no limit
+func (r Rate) Unlimited() bool {
+	return r.PerSecond <= 0
+}
+
+func (r Rate) capacity() float64 {
+	if r.Burst > 0 {
+		return float64(r.Burst)
+	}
+	return math.Max(1, math.Ceil(r.PerSecond))
+}
+
+// pruneThreshold is the number of buckets above which idle ones are discarded
+const pruneThreshold = 1024
+
+// bucket is a single token bucket
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// Limiter holds one token bucket per key, for example per client or per tool
+type Limiter struct {
+	rate Rate
+	now  func() time.Time
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+// NewLimiter creates a keyed limiter; every key starts with a full bucket
+func NewLimiter(rate Rate) *Limiter {
+	return &Limiter{rate: rate, now: time.Now, buckets: make(map[string]*bucket)}
+}
+
+// Allow takes a token for key. When none is available it returns false and how
+// long the caller should wait before a token will be available.
+func (l *Limiter) Allow(key string) (bool, time.Duration) {
+	if l == nil || l.rate.Unlimited() {
+		return true, 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	capacity := l.rate.capacity()
+	b, ok := l.buckets[key]
+	if !ok {
+		if len(l.buckets) >= pruneThreshold {
+			l.prune(now, capacity)
+		}
+		b = &bucket{tokens: capacity, last: now}
+		l.buckets[key] = b
+	}
+
+	b.tokens = math.Min(capacity, b.tokens+now.Sub(b.last).Seconds()*l.rate.PerSecond)
+	b.last = now
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+
+	wait := time.Duration((1 - b.tokens) / l.rate.PerSecond * float64(time.Second))
+	return false, wait
+}
+
+// prune drops buckets that have refilled completely, since a new bucket starts full anyway
+func (l *Limiter) prune(now time.Time, capacity float64) {
+	for key, b := range l.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*l.rate.PerSecond >= capacity {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// Semaphore caps the number of concurrent operations
+type Semaphore struct {
+	slots chan struct{}
+}
+
+// NewSemaphore creates a semaphore with max slots; max <= 0 means unlimited
+func NewSemaphore(max int) *Semaphore {
+	if max <= 0 {
+		return nil
+	}
+	return &Semaphore{slots: make(chan struct{}, max)}
+}
+
+// Acquire waits up to wait for a free slot. On success it returns a function that
+// releases the slot; otherwise it returns nil once the wait or ctx expires.
+func (s *Semaphore) Acquire(ctx context.Context, wait time.Duration) func() {
+	if s == nil {
+		return func() {}
+	}
+
+	select {
+	case s.slots <- struct{}{}:
+		return s.release
+	default:
+	}
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case s.slots <- struct{}{}:
+		return s.release
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return nil
+	}
+}
+
+func (s *Semaphore) release() {
+	<-s.slots
+}
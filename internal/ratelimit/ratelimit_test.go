@@ -0,0 +1,88 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLimiter(t *testing.T) {
+	now := time.Unix(0, 0)
+	limiter := NewLimiter(Rate{PerSecond: 2, Burst: 3})
+	limiter.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		if ok, _ := limiter.Allow("a"); !ok {
+			t.Fatalf("call %d within burst was rejected", i+1)
+		}
+	}
+
+	ok, wait := limiter.Allow("a")
+	if ok {
+		t.Fatal("call beyond burst was allowed")
+	}
+	if wait != 500*time.Millisecond {
+		t.Errorf("retry hint = %v, want 500ms", wait)
+	}
+
+	if ok, _ := limiter.Allow("b"); !ok {
+		t.Error("other key shares the exhausted bucket")
+	}
+
+	now = now.Add(500 * time.Millisecond)
+	if ok, _ := limiter.Allow("a"); !ok {
+		t.Error("call after the retry hint was rejected")
+	}
+	if ok, _ := limiter.Allow("a"); ok {
+		t.Error("bucket refilled faster than the rate")
+	}
+}
+
+func TestLimiter_Unlimited(t *testing.T) {
+	var nilLimiter *Limiter
+	for _, limiter := range []*Limiter{nilLimiter, NewLimiter(Rate{})} {
+		for i := 0; i < 100; i++ {
+			if ok, _ := limiter.Allow("a"); !ok {
+				t.Fatal("unlimited limiter rejected a call")
+			}
+		}
+	}
+}
+
+func TestLimiter_DefaultBurst(t *testing.T) {
+	limiter := NewLimiter(Rate{PerSecond: 0.5})
+	limiter.now = func() time.Time { return time.Unix(0, 0) }
+
+	if ok, _ := limiter.Allow("a"); !ok {
+		t.Fatal("first call was rejected")
+	}
+	if ok, wait := limiter.Allow("a"); ok || wait != 2*time.Second {
+		t.Errorf("second call = %v, %v; want rejected with 2s hint", ok, wait)
+	}
+}
+
+func TestSemaphore(t *testing.T) {
+	sem := NewSemaphore(1)
+	release := sem.Acquire(context.Background(), 0)
+	if release == nil {
+		t.Fatal("first Acquire() failed")
+	}
+
+	if sem.Acquire(context.Background(), 10*time.Millisecond) != nil {
+		t.Fatal("Acquire() succeeded beyond the cap")
+	}
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		release()
+	}()
+	second := sem.Acquire(context.Background(), time.Second)
+	if second == nil {
+		t.Fatal("Acquire() did not get the released slot")
+	}
+	second()
+
+	if NewSemaphore(0).Acquire(context.Background(), 0) == nil {
+		t.Error("unlimited semaphore rejected Acquire()")
+	}
+}
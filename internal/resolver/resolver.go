@@ -0,0 +1,284 @@
+// Package resolver turns room titles, email addresses and display names into
+// Webex IDs, so callers do not have to list rooms or people first.
+package resolver
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/raja-aiml/webex-mcp-server/internal/webex"
+)
+
+// DefaultTTL is how long lookups are cached
+const DefaultTTL = 5 * time.Minute
+
+// MaxRooms is the number of rooms, most recently active first, searched by title
+const MaxRooms = 1000
+
+// maxCandidates bounds the matches listed in an AmbiguousError
+const maxCandidates = 10
+
+// Candidate is a room or person a name could refer to
+type Candidate struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// AmbiguousError reports a name that matches more than one room or person, or
+// that matches none but is a few typos away from some
+type AmbiguousError struct {
+	Kind       string // "room" or "person"
+	Query      string
+	Candidates []Candidate
+	Near       bool // No name matches; the candidates are close spellings
+}
+
+func (e *AmbiguousError) Error() string {
+	var b strings.Builder
+	if e.Near {
+		fmt.Fprintf(&b, "no %s matches %q; did you mean", e.Kind, e.Query)
+	} else {
+		fmt.Fprintf(&b, "%q matches %d %ss, use one of their IDs or a more specific name:", e.Query, len(e.Candidates), e.Kind)
+	}
+	for i, c := range e.Candidates {
+		if i == maxCandidates {
+			fmt.Fprintf(&b, " and %d more", len(e.Candidates)-maxCandidates)
+			break
+		}
+		fmt.Fprintf(&b, " %q (%s)", c.Name, c.ID)
+		if i < len(e.Candidates)-1 && i < maxCandidates-1 {
+			b.WriteString(",")
+		}
+	}
+	if e.Near {
+		b.WriteString("?")
+	}
+	return b.String()
+}
+
+// Resolver looks up IDs by name, caching results per client
+type Resolver struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[cacheKey]cacheEntry
+}
+
+type cacheKey struct {
+	client webex.HTTPClient
+	kind   string
+	query  string
+}
+
+type cacheEntry struct {
+	candidates []Candidate
+	expires    time.Time
+}
+
+// New creates a resolver that caches lookups for ttl; 0 disables caching
+func New(ttl time.Duration) *Resolver {
+	return &Resolver{ttl: ttl, now: time.Now, entries: map[cacheKey]cacheEntry{}}
+}
+
+// Room returns the ID of the room whose title matches title
+func (r *Resolver) Room(client webex.HTTPClient, title string) (string, error) {
+	if strings.TrimSpace(title) == "" {
+		return "", fmt.Errorf("room title is empty")
+	}
+	rooms, cached, err := r.lookup(client, "rooms", "", func() ([]Candidate, error) {
+		return list(client, "/rooms", map[string]string{"max": fmt.Sprint(MaxRooms), "sortBy": "lastactivity"}, "title")
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to list rooms: %w", err)
+	}
+	id, exact, err := pick("room", title, rooms)
+	if !exact && cached {
+		// A room newer than the cached list may match better
+		r.forget(client, "rooms", "")
+		return r.Room(client, title)
+	}
+	return id, err
+}
+
+// PersonByEmail returns the ID of the person with the email address
+func (r *Resolver) PersonByEmail(client webex.HTTPClient, email string) (string, error) {
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return "", fmt.Errorf("email is empty")
+	}
+	people, _, err := r.lookup(client, "email", strings.ToLower(email), func() ([]Candidate, error) {
+		return list(client, "/people", map[string]string{"email": email}, "displayName")
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to look up %s: %w", email, err)
+	}
+	switch len(people) {
+	case 0:
+		return "", fmt.Errorf("no person has the email %s", email)
+	case 1:
+		return people[0].ID, nil
+	default:
+		return "", &AmbiguousError{Kind: "person", Query: email, Candidates: people}
+	}
+}
+
+// PersonByName returns the ID of the person whose display name matches name.
+// Webex searches display names by prefix, so the first word narrows the search.
+func (r *Resolver) PersonByName(client webex.HTTPClient, name string) (string, error) {
+	words := strings.Fields(name)
+	if len(words) == 0 {
+		return "", fmt.Errorf("name is empty")
+	}
+	query := strings.ToLower(words[0])
+	people, cached, err := r.lookup(client, "name", query, func() ([]Candidate, error) {
+		return list(client, "/people", map[string]string{"displayName": words[0], "max": "100"}, "displayName")
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to look up %s: %w", name, err)
+	}
+	id, exact, err := pick("person", name, people)
+	if !exact && cached {
+		r.forget(client, "name", query)
+		return r.PersonByName(client, name)
+	}
+	return id, err
+}
+
+// lookup returns cached candidates or fetches them; cached reports a cache hit
+func (r *Resolver) lookup(client webex.HTTPClient, kind, query string, fetch func() ([]Candidate, error)) ([]Candidate, bool, error) {
+	// Clients that cannot be map keys are looked up every time
+	cacheable := r.ttl > 0 && client != nil && reflect.TypeOf(client).Comparable()
+	key := cacheKey{client: client, kind: kind, query: query}
+	if cacheable {
+		r.mu.Lock()
+		entry, ok := r.entries[key]
+		r.mu.Unlock()
+		if ok && r.now().Before(entry.expires) {
+			return entry.candidates, true, nil
+		}
+	}
+
+	candidates, err := fetch()
+	if err != nil {
+		return nil, false, err
+	}
+	if cacheable {
+		r.mu.Lock()
+		r.entries[key] = cacheEntry{candidates: candidates, expires: r.now().Add(r.ttl)}
+		r.mu.Unlock()
+	}
+	return candidates, false, nil
+}
+
+func (r *Resolver) forget(client webex.HTTPClient, kind, query string) {
+	if client == nil || !reflect.TypeOf(client).Comparable() {
+		return
+	}
+	r.mu.Lock()
+	delete(r.entries, cacheKey{client: client, kind: kind, query: query})
+	r.mu.Unlock()
+}
+
+// list reads the ID and the named field of each item in a list response
+func list(client webex.HTTPClient, endpoint string, params map[string]string, nameField string) ([]Candidate, error) {
+	result, err := client.Get(endpoint, params)
+	if err != nil {
+		return nil, err
+	}
+	items, _ := result["items"].([]interface{})
+	candidates := make([]Candidate, 0, len(items))
+	for _, item := range items {
+		fields, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		id, _ := fields["id"].(string)
+		name, _ := fields[nameField].(string)
+		if id != "" {
+			candidates = append(candidates, Candidate{ID: id, Name: name})
+		}
+	}
+	return candidates, nil
+}
+
+// pick matches query against the candidates' names, trying stricter matches
+// first: equal, prefix, then containing every word. The first kind of match
+// with any results decides, and exact reports an equal name. Names within a
+// few typos are never picked, only suggested.
+func pick(kind, query string, candidates []Candidate) (id string, exact bool, err error) {
+	q := normalize(query)
+	words := strings.Fields(q)
+	tiers := []func(name string) bool{
+		func(name string) bool { return name == q },
+		func(name string) bool { return strings.HasPrefix(name, q) },
+		func(name string) bool {
+			for _, word := range words {
+				if !strings.Contains(name, word) {
+					return false
+				}
+			}
+			return true
+		},
+	}
+	for i, matches := range tiers {
+		var found []Candidate
+		for _, c := range candidates {
+			if matches(normalize(c.Name)) {
+				found = append(found, c)
+			}
+		}
+		switch len(found) {
+		case 0:
+			continue
+		case 1:
+			return found[0].ID, i == 0, nil
+		default:
+			return "", false, &AmbiguousError{Kind: kind, Query: query, Candidates: found}
+		}
+	}
+
+	var near []Candidate
+	for _, c := range candidates {
+		if distance(normalize(c.Name), q) <= len(q)/4 {
+			near = append(near, c)
+		}
+	}
+	if len(near) > 0 {
+		return "", false, &AmbiguousError{Kind: kind, Query: query, Candidates: near, Near: true}
+	}
+	return "", false, fmt.Errorf("no %s matches %q", kind, query)
+}
+
+// normalize lowercases s and reduces punctuation and spacing to single spaces
+func normalize(s string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+// distance is the Levenshtein distance between a and b
+func distance(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	prev := make([]int, len(t)+1)
+	curr := make([]int, len(t)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(s); i++ {
+		curr[0] = i
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(t)]
+}
@@ -0,0 +1,153 @@
+package resolver
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/raja-aiml/webex-mcp-server/internal/config"
+	"github.com/raja-aiml/webex-mcp-server/internal/fakewebex"
+	"github.com/raja-aiml/webex-mcp-server/internal/testutil"
+	"github.com/raja-aiml/webex-mcp-server/internal/webex"
+)
+
+func newFake(t *testing.T) (*fakewebex.Server, webex.HTTPClient, map[string]string) {
+	t.Helper()
+	fake, baseURL := testutil.NewFakeWebex(t)
+	ids := map[string]string{}
+	for _, title := range []string{"Engineering", "Engineering Leads", "Design Review", "Q3 Planning / Ops"} {
+		room, err := fake.Add(fakewebex.Rooms, fakewebex.Item{"title": title})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids[title] = room["id"].(string)
+	}
+	for _, person := range []struct{ name, email string }{
+		{"Alice Example", "alice@example.com"},
+		{"Alice Other", "alice.other@example.com"},
+		{"Bob Builder", "bob@example.com"},
+	} {
+		p, err := fake.Add(fakewebex.People, fakewebex.Item{"displayName": person.name, "emails": []interface{}{person.email}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids[person.name] = p["id"].(string)
+	}
+
+	client, err := webex.NewClientWithConfig(&config.Config{WebexAPIKey: "token", WebexAPIBaseURL: baseURL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fake, client, ids
+}
+
+func TestRoom(t *testing.T) {
+	_, client, ids := newFake(t)
+	r := New(0)
+
+	tests := []struct {
+		title      string
+		want       string
+		candidates int  // Matches listed by an ambiguous title
+		near       bool // The matches are only suggested spellings
+	}{
+		{title: "engineering", want: ids["Engineering"]},
+		{title: "Design", want: ids["Design Review"]},
+		{title: "q3 planning ops", want: ids["Q3 Planning / Ops"]},
+		{title: "leads engineering", want: ids["Engineering Leads"]},
+		{title: "Desgn Review", candidates: 1, near: true},
+		{title: "Eng", candidates: 2},
+		{title: "Marketing"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			got, err := r.Room(client, tt.title)
+			var ambiguous *AmbiguousError
+			switch {
+			case tt.want != "":
+				if err != nil || got != tt.want {
+					t.Errorf("Room(%q) = %q, %v; want %q", tt.title, got, err, tt.want)
+				}
+			case tt.candidates > 0:
+				if !errors.As(err, &ambiguous) || len(ambiguous.Candidates) != tt.candidates || ambiguous.Near != tt.near || got != "" {
+					t.Fatalf("Room(%q) = %q, %v; want %d candidates", tt.title, got, err, tt.candidates)
+				}
+				if want := `"` + ambiguous.Candidates[0].Name + `" (` + ambiguous.Candidates[0].ID + ")"; !strings.Contains(err.Error(), want) {
+					t.Errorf("error does not list the candidates: %v", err)
+				}
+			default:
+				if err == nil || errors.As(err, &ambiguous) {
+					t.Errorf("Room(%q) = %q, %v; want not found", tt.title, got, err)
+				}
+			}
+		})
+	}
+}
+
+func TestPerson(t *testing.T) {
+	_, client, ids := newFake(t)
+	r := New(0)
+
+	if got, err := r.PersonByEmail(client, "Bob@Example.com "); err != nil || got != ids["Bob Builder"] {
+		t.Errorf("PersonByEmail() = %q, %v", got, err)
+	}
+	if _, err := r.PersonByEmail(client, "nobody@example.com"); err == nil {
+		t.Error("PersonByEmail() of an unknown address succeeded")
+	}
+	if got, err := r.PersonByName(client, "alice example"); err != nil || got != ids["Alice Example"] {
+		t.Errorf("PersonByName() = %q, %v", got, err)
+	}
+	var ambiguous *AmbiguousError
+	if _, err := r.PersonByName(client, "Alice"); !errors.As(err, &ambiguous) || ambiguous.Kind != "person" {
+		t.Errorf("PersonByName() of a shared first name = %v", err)
+	}
+}
+
+func TestCache(t *testing.T) {
+	fake, client, ids := newFake(t)
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	r := New(time.Minute)
+	r.now = func() time.Time { return now }
+
+	lookups := func() int {
+		before := fake.Requests()
+		if got, err := r.Room(client, "Design Review"); err != nil || got != ids["Design Review"] {
+			t.Fatalf("Room() = %q, %v", got, err)
+		}
+		return fake.Requests() - before
+	}
+	if n := lookups(); n != 1 {
+		t.Errorf("first lookup made %d requests, want 1", n)
+	}
+	if n := lookups(); n != 0 {
+		t.Errorf("cached lookup made %d requests, want 0", n)
+	}
+
+	// A room created after the list was cached is found by listing again
+	room, err := fake.Add(fakewebex.Rooms, fakewebex.Item{"title": "New Launch"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := r.Room(client, "New Launch"); err != nil || got != room["id"] {
+		t.Errorf("Room() of a new room = %q, %v", got, err)
+	}
+
+	// A cached near spelling is not taken for a new room one letter away
+	if _, err := fake.Add(fakewebex.Rooms, fakewebex.Item{"title": "Team A"}); err != nil {
+		t.Fatal(err)
+	}
+	r.Room(client, "Team A")
+	teamC, err := fake.Add(fakewebex.Rooms, fakewebex.Item{"title": "Team C"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := r.Room(client, "Team C"); err != nil || got != teamC["id"] {
+		t.Errorf("Room() of a new room near a cached one = %q, %v", got, err)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if n := lookups(); n != 1 {
+		t.Errorf("expired lookup made %d requests, want 1", n)
+	}
+}
@@ -0,0 +1,322 @@
+// Package roomsync polls Webex rooms for new messages and keeps them until they
+// are read, with a checkpoint per room saved in a local state file. It gives
+// callers "what's new" without webhooks or re-reading whole rooms.
+package roomsync
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/raja-aiml/webex-mcp-server/internal/transcript"
+	"github.com/raja-aiml/webex-mcp-server/internal/webex"
+)
+
+// MaxPending is the number of unread messages kept per room; older ones are dropped
+const MaxPending = 1000
+
+// stateVersion is bumped when the state file layout changes
+const stateVersion = 1
+
+// Message is a new message and the room it was posted in
+type Message struct {
+	RoomID string `json:"roomId"`
+	transcript.Message
+}
+
+// Cursor marks a position in a room's history: the creation time of the newest
+// message and the IDs of every message created at that instant
+type Cursor struct {
+	Created time.Time `json:"created"`
+	IDs     []string  `json:"ids,omitempty"`
+}
+
+// IsZero reports whether the cursor marks no position yet
+func (c Cursor) IsZero() bool { return c.Created.IsZero() }
+
+// after reports whether m comes after the cursor
+func (c Cursor) after(m *transcript.Message) bool {
+	if m.Created.Equal(c.Created) {
+		return !slices.Contains(c.IDs, m.ID)
+	}
+	return m.Created.After(c.Created)
+}
+
+// advance moves the cursor past m
+func (c *Cursor) advance(m *transcript.Message) {
+	switch {
+	case m.Created.After(c.Created):
+		*c = Cursor{Created: m.Created, IDs: []string{m.ID}}
+	case m.Created.Equal(c.Created) && !slices.Contains(c.IDs, m.ID):
+		c.IDs = append(c.IDs, m.ID)
+	}
+}
+
+// Room is the sync state of one room
+type Room struct {
+	Title      string     `json:"title,omitempty"`
+	Seen       Cursor     `json:"seen"`       // Newest message fetched
+	Checkpoint Cursor     `json:"checkpoint"` // Newest message read
+	Pending    []*Message `json:"pending,omitempty"`
+	Missed     int        `json:"missed,omitempty"` // Unread messages dropped beyond MaxPending
+	LastSync   time.Time  `json:"lastSync"`
+}
+
+type stateFile struct {
+	Version int              `json:"version"`
+	Rooms   map[string]*Room `json:"rooms"`
+}
+
+// Engine holds the state of the synced rooms
+type Engine struct {
+	path string
+	now  func() time.Time
+
+	cycleMu sync.Mutex // Serializes cycles so a room is never fetched twice at once
+	mu      sync.Mutex
+	rooms   map[string]*Room
+}
+
+var (
+	sharedMu sync.Mutex
+	shared   = map[string]*Engine{}
+)
+
+// Shared returns the engine for the state file at path, opening it on first use,
+// so the background worker and the tools of one process share checkpoints
+func Shared(path string) (*Engine, error) {
+	sharedMu.Lock()
+	defer sharedMu.Unlock()
+
+	if engine, ok := shared[path]; ok {
+		return engine, nil
+	}
+	engine, err := Open(path)
+	if err != nil {
+		return nil, err
+	}
+	shared[path] = engine
+	return engine, nil
+}
+
+// Open loads the state file at path, starting empty when there is none yet
+func Open(path string) (*Engine, error) {
+	engine := &Engine{path: path, now: time.Now, rooms: map[string]*Room{}}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return engine, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sync state: %w", err)
+	}
+	var state stateFile
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse sync state %s: %w", path, err)
+	}
+	if state.Version != stateVersion {
+		return nil, fmt.Errorf("sync state %s has version %d, want %d", path, state.Version, stateVersion)
+	}
+	for id, room := range state.Rooms {
+		engine.rooms[id] = room
+	}
+	return engine, nil
+}
+
+// Cycle fetches the new messages of each room and saves the state. It returns
+// the number of new messages; a room that fails is reported in the error while
+// the others are still synced.
+func (e *Engine) Cycle(client webex.HTTPClient, roomIDs []string) (int, error) {
+	e.cycleMu.Lock()
+	defer e.cycleMu.Unlock()
+
+	added := 0
+	var errs []error
+	for _, roomID := range roomIDs {
+		n, err := e.syncRoom(client, roomID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("room %s: %w", roomID, err))
+			continue
+		}
+		added += n
+	}
+	if err := e.save(); err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		return added, fmt.Errorf("room sync failed: %w", errors.Join(errs...))
+	}
+	return added, nil
+}
+
+// syncRoom pages back from the newest message to the seen cursor, however far. On
+// the first sync only the cursor is set, so messages posted before syncing began
+// are not new.
+func (e *Engine) syncRoom(client webex.HTTPClient, roomID string) (int, error) {
+	info, err := client.Get("/rooms/"+roomID, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get room: %w", err)
+	}
+	title, _ := info["title"].(string)
+
+	e.mu.Lock()
+	room := e.rooms[roomID]
+	first := room == nil
+	var seen Cursor
+	if !first {
+		seen = room.Seen
+	}
+	e.mu.Unlock()
+
+	// Every message since the seen cursor is fetched, so those beyond MaxPending
+	// are counted as missed instead of silently skipped
+	limit := math.MaxInt
+	if first {
+		limit = 1
+	}
+	messages, _, err := transcript.ListMessages(client, map[string]string{"roomId": roomID}, seen.Created, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	// Webex lists newest first; keep the new ones oldest first
+	var fresh []*transcript.Message
+	for i := len(messages) - 1; i >= 0; i-- {
+		if seen.IsZero() || seen.after(messages[i]) {
+			fresh = append(fresh, messages[i])
+		}
+	}
+	// Only messages that can be kept pending need their senders
+	transcript.ResolveSenders(client, fresh[max(len(fresh)-MaxPending, 0):])
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if first {
+		room = &Room{}
+		e.rooms[roomID] = room
+	}
+	room.Title = title
+	room.LastSync = e.now()
+	if first {
+		for _, m := range fresh {
+			room.Seen.advance(m)
+			room.Checkpoint.advance(m)
+		}
+		return 0, nil
+	}
+	for _, m := range fresh {
+		room.Seen.advance(m)
+		room.Pending = append(room.Pending, &Message{RoomID: roomID, Message: *m})
+	}
+	if over := len(room.Pending) - MaxPending; over > 0 {
+		room.Missed += over
+		room.Pending = slices.Clone(room.Pending[over:])
+	}
+	return len(fresh), nil
+}
+
+// Update is what is new in one room since its checkpoint
+type Update struct {
+	RoomID     string     `json:"roomId"`
+	RoomTitle  string     `json:"roomTitle,omitempty"`
+	Messages   []*Message `json:"messages"`
+	Missed     int        `json:"missed,omitempty"` // Dropped because too many were unread
+	Checkpoint Cursor     `json:"checkpoint"`       // After these messages when advanced
+	LastSync   time.Time  `json:"lastSync"`
+}
+
+// Since returns the unread messages of the rooms, oldest first. When advance
+// is set they are marked read and the checkpoints move past them.
+func (e *Engine) Since(roomIDs []string, advance bool) ([]Update, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	updates := []Update{}
+	for _, roomID := range roomIDs {
+		room := e.rooms[roomID]
+		if room == nil {
+			updates = append(updates, Update{RoomID: roomID, Messages: []*Message{}})
+			continue
+		}
+		update := Update{
+			RoomID:     roomID,
+			RoomTitle:  room.Title,
+			Messages:   append([]*Message{}, room.Pending...),
+			Missed:     room.Missed,
+			Checkpoint: room.Checkpoint,
+			LastSync:   room.LastSync,
+		}
+		if advance {
+			for _, m := range room.Pending {
+				room.Checkpoint.advance(&m.Message)
+			}
+			room.Pending = nil
+			room.Missed = 0
+			update.Checkpoint = room.Checkpoint
+		}
+		updates = append(updates, update)
+	}
+	if !advance {
+		return updates, nil
+	}
+	return updates, e.saveLocked()
+}
+
+// Run cycles at once and then every interval until ctx is done, logging failures
+func (e *Engine) Run(ctx context.Context, client webex.HTTPClient, roomIDs []string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if n, err := e.Cycle(client, roomIDs); err != nil {
+			log.Printf("%v", err)
+		} else if n > 0 {
+			log.Printf("Room sync: %d new messages", n)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (e *Engine) save() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.saveLocked()
+}
+
+// saveLocked writes the state file atomically; e.mu must be held
+func (e *Engine) saveLocked() error {
+	data, err := json.MarshalIndent(stateFile{Version: stateVersion, Rooms: e.rooms}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode sync state: %w", err)
+	}
+	dir := filepath.Dir(e.path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create sync state directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, filepath.Base(e.path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write sync state: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write sync state: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write sync state: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), e.path); err != nil {
+		return fmt.Errorf("failed to write sync state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package roomsync
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/raja-aiml/webex-mcp-server/internal/config"
+	"github.com/raja-aiml/webex-mcp-server/internal/fakewebex"
+	"github.com/raja-aiml/webex-mcp-server/internal/testutil"
+	"github.com/raja-aiml/webex-mcp-server/internal/transcript"
+	"github.com/raja-aiml/webex-mcp-server/internal/webex"
+)
+
+func newRoom(t *testing.T) (*fakewebex.Server, webex.HTTPClient, string) {
+	t.Helper()
+	fake, baseURL := testutil.NewFakeWebex(t)
+	room, err := fake.Add(fakewebex.Rooms, fakewebex.Item{"title": "Ops"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := webex.NewClientWithConfig(&config.Config{WebexAPIKey: "token", WebexAPIBaseURL: baseURL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fake, client, room["id"].(string)
+}
+
+func post(t *testing.T, fake *fakewebex.Server, roomID string, texts ...string) {
+	t.Helper()
+	for _, text := range texts {
+		if _, err := fake.Add(fakewebex.Messages, fakewebex.Item{"roomId": roomID, "text": text}); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func texts(update Update) string {
+	var parts []string
+	for _, m := range update.Messages {
+		parts = append(parts, m.Text)
+	}
+	return strings.Join(parts, ",")
+}
+
+func TestEngine(t *testing.T) {
+	fake, client, roomID := newRoom(t)
+	post(t, fake, roomID, "before sync")
+	path := filepath.Join(t.TempDir(), "sync", "state.json")
+	engine, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rooms := []string{roomID}
+
+	// The first cycle only marks where the room is, so earlier messages are not new
+	if n, err := engine.Cycle(client, rooms); err != nil || n != 0 {
+		t.Fatalf("first Cycle() = %d, %v", n, err)
+	}
+	post(t, fake, roomID, "one", "two")
+	if n, err := engine.Cycle(client, rooms); err != nil || n != 2 {
+		t.Fatalf("Cycle() = %d, %v; want 2", n, err)
+	}
+	if n, err := engine.Cycle(client, rooms); err != nil || n != 0 {
+		t.Fatalf("idle Cycle() = %d, %v; want 0", n, err)
+	}
+
+	updates, err := engine.Since(rooms, false)
+	if err != nil || len(updates) != 1 || texts(updates[0]) != "one,two" || updates[0].RoomTitle != "Ops" {
+		t.Fatalf("Since(peek) = %+v, %v", updates, err)
+	}
+	if updates[0].Messages[0].Sender != "Fake User" || updates[0].Messages[0].RoomID != roomID {
+		t.Errorf("message = %+v", updates[0].Messages[0])
+	}
+	updates, _ = engine.Since(rooms, true)
+	if texts(updates[0]) != "one,two" || !updates[0].Checkpoint.Created.Equal(updates[0].Messages[1].Created) {
+		t.Errorf("Since(advance) = %+v", updates[0])
+	}
+	if updates, _ := engine.Since(rooms, true); len(updates[0].Messages) != 0 {
+		t.Errorf("read messages returned again: %s", texts(updates[0]))
+	}
+
+	// Checkpoints and unread messages survive a restart
+	post(t, fake, roomID, "three")
+	if _, err := engine.Cycle(client, rooms); err != nil {
+		t.Fatal(err)
+	}
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updates, _ := reopened.Since(rooms, true); texts(updates[0]) != "three" {
+		t.Errorf("after reopening, Since() = %q, want three", texts(updates[0]))
+	}
+	info, err := os.Stat(path)
+	if err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("state file mode = %v, %v, want 0600", info.Mode().Perm(), err)
+	}
+}
+
+func TestEngineSameInstant(t *testing.T) {
+	fake, client, roomID := newRoom(t)
+	stamp := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	fake.SetClock(func() time.Time { return stamp })
+	engine, err := Open(filepath.Join(t.TempDir(), "state.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rooms := []string{roomID}
+
+	// An empty room has nothing to mark, so everything posted later is new
+	if _, err := engine.Cycle(client, rooms); err != nil {
+		t.Fatal(err)
+	}
+	post(t, fake, roomID, "a", "b")
+	if n, err := engine.Cycle(client, rooms); err != nil || n != 2 {
+		t.Fatalf("Cycle() = %d, %v; want 2", n, err)
+	}
+	// Messages created at the same instant as the cursor are told apart by ID
+	post(t, fake, roomID, "c")
+	if n, err := engine.Cycle(client, rooms); err != nil || n != 1 {
+		t.Fatalf("Cycle() with a message at the same instant = %d, %v; want 1", n, err)
+	}
+	if updates, _ := engine.Since(rooms, true); texts(updates[0]) != "a,b,c" {
+		t.Errorf("Since() = %q", texts(updates[0]))
+	}
+}
+
+func TestEnginePendingLimit(t *testing.T) {
+	fake, client, roomID := newRoom(t)
+	engine, err := Open(filepath.Join(t.TempDir(), "state.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rooms := []string{roomID}
+	if _, err := engine.Cycle(client, rooms); err != nil {
+		t.Fatal(err)
+	}
+	// More messages than one listing returns by default are still all counted
+	missed := transcript.DefaultMaxMessages - MaxPending + 5
+	for i := 0; i < MaxPending+missed; i++ {
+		post(t, fake, roomID, fmt.Sprint(i))
+	}
+	if _, err := engine.Cycle(client, rooms); err != nil {
+		t.Fatal(err)
+	}
+	updates, _ := engine.Since(rooms, true)
+	if len(updates[0].Messages) != MaxPending || updates[0].Missed != missed || updates[0].Messages[0].Text != fmt.Sprint(missed) {
+		t.Errorf("got %d messages from %q, %d missed", len(updates[0].Messages), updates[0].Messages[0].Text, updates[0].Missed)
+	}
+
+	if _, err := engine.Cycle(client, []string{roomID, "missing"}); err == nil || !strings.Contains(err.Error(), "room missing") {
+		t.Errorf("Cycle() with an unknown room = %v", err)
+	}
+}
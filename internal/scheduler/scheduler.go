@@ -0,0 +1,485 @@
+// Package scheduler posts Webex messages at a later time, once or on a cron
+// schedule. Jobs are kept in a local store file so they survive restarts, and
+// runs missed while the server was down follow the job's catch-up policy.
+package scheduler
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/raja-aiml/webex-mcp-server/internal/webex"
+)
+
+// Catch-up policies for runs missed while the server was not running
+const (
+	CatchUpSkip = "skip" // Drop missed runs
+	CatchUpOnce = "once" // Post once for all missed runs, then continue the schedule
+)
+
+// Job statuses
+const (
+	StatusScheduled = "scheduled"
+	StatusSent      = "sent"      // A one-off job was posted
+	StatusMissed    = "missed"    // A one-off job was skipped by its catch-up policy
+	StatusFailed    = "failed"    // A one-off job could not be posted
+	StatusCancelled = "cancelled" // Cancelled before it finished
+)
+
+const (
+	// TickInterval is how often Run looks for due jobs
+	TickInterval = 5 * time.Second
+	// LateGrace is how late a run may start and still count as on time
+	LateGrace = time.Minute
+	// maxAttempts is how often a run is tried before it is given up
+	maxAttempts = 3
+	// keepFinished is how long finished jobs stay listed
+	keepFinished = 7 * 24 * time.Hour
+)
+
+// storeVersion is bumped when the store file layout changes
+const storeVersion = 1
+
+// Message is the message a job posts, as sent to /messages
+type Message struct {
+	RoomID        string `json:"roomId,omitempty"`
+	ToPersonID    string `json:"toPersonId,omitempty"`
+	ToPersonEmail string `json:"toPersonEmail,omitempty"`
+	ParentID      string `json:"parentId,omitempty"`
+	Text          string `json:"text,omitempty"`
+	Markdown      string `json:"markdown,omitempty"`
+}
+
+// ClientFunc returns the client to post as a profile, "" being the default one
+type ClientFunc func(profile string) (webex.HTTPClient, error)
+
+// Job is a scheduled message
+type Job struct {
+	ID       string  `json:"id"`
+	Message  Message `json:"message"`
+	Profile  string  `json:"profile,omitempty"` // Webex profile to post as; the default when empty
+	At       string  `json:"at,omitempty"`      // One-off time, RFC 3339
+	Cron     string  `json:"cron,omitempty"`    // Recurrence; At is unset when this is
+	Timezone string  `json:"timezone"`          // IANA zone Cron is evaluated in
+	CatchUp  string  `json:"catchUp"`
+
+	Status        string    `json:"status"`
+	NextRun       time.Time `json:"nextRun,omitzero"`
+	Created       time.Time `json:"created"`
+	LastRun       time.Time `json:"lastRun,omitzero"`
+	LastMessageID string    `json:"lastMessageId,omitempty"`
+	Runs          int       `json:"runs"`
+	Missed        int       `json:"missed,omitempty"` // Runs dropped by the catch-up policy
+	Attempts      int       `json:"attempts,omitempty"`
+	LastError     string    `json:"lastError,omitempty"`
+	Finished      time.Time `json:"finished,omitzero"`
+}
+
+// done reports whether the job will not run again
+func (j *Job) done() bool { return j.Status != StatusScheduled }
+
+// Spec describes a job to add
+type Spec struct {
+	Message  Message
+	At       time.Time // One-off time; zero when Cron is set
+	Cron     string
+	Timezone string // Defaults to the store's zone
+	CatchUp  string // Defaults to the store's policy
+	Profile  string // Webex profile to post as
+}
+
+type storeFile struct {
+	Version int             `json:"version"`
+	Jobs    map[string]*Job `json:"jobs"`
+}
+
+// Store holds the scheduled jobs and runs those that are due
+type Store struct {
+	path     string
+	location *time.Location // Default zone of new jobs
+	catchUp  string         // Default policy of new jobs
+	now      func() time.Time
+
+	runMu sync.Mutex // Serializes RunDue so a run is never posted twice
+	mu    sync.Mutex
+	jobs  map[string]*Job
+}
+
+var (
+	sharedMu sync.Mutex
+	shared   = map[string]*Store{}
+)
+
+// Shared returns the store for the file at path, opening it on first use, so
+// the background worker and the tools of one process share jobs
+func Shared(path string, location *time.Location, catchUp string) (*Store, error) {
+	sharedMu.Lock()
+	defer sharedMu.Unlock()
+
+	if store, ok := shared[path]; ok {
+		store.mu.Lock()
+		store.location, store.catchUp = location, catchUp
+		store.mu.Unlock()
+		return store, nil
+	}
+	store, err := Open(path, location, catchUp)
+	if err != nil {
+		return nil, err
+	}
+	shared[path] = store
+	return store, nil
+}
+
+// Open loads the store file at path, starting empty when there is none yet
+func Open(path string, location *time.Location, catchUp string) (*Store, error) {
+	if location == nil {
+		location = time.Local
+	}
+	if catchUp == "" {
+		catchUp = CatchUpOnce
+	}
+	store := &Store{path: path, location: location, catchUp: catchUp, now: time.Now, jobs: map[string]*Job{}}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scheduled jobs: %w", err)
+	}
+	var state storeFile
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse scheduled jobs %s: %w", path, err)
+	}
+	if state.Version != storeVersion {
+		return nil, fmt.Errorf("scheduled jobs %s have version %d, want %d", path, state.Version, storeVersion)
+	}
+	for id, job := range state.Jobs {
+		store.jobs[id] = job
+	}
+	return store, nil
+}
+
+// ValidCatchUp reports whether policy is a known catch-up policy
+func ValidCatchUp(policy string) bool {
+	return policy == CatchUpSkip || policy == CatchUpOnce
+}
+
+// Add schedules a new job and saves the store
+func (s *Store) Add(spec Spec) (*Job, error) {
+	m := spec.Message
+	if m.RoomID == "" && m.ToPersonID == "" && m.ToPersonEmail == "" {
+		return nil, fmt.Errorf("a room or a person to message is required")
+	}
+	if m.Text == "" && m.Markdown == "" {
+		return nil, fmt.Errorf("text or markdown is required")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	job := &Job{ID: newID(), Message: m, Profile: spec.Profile, CatchUp: spec.CatchUp, Status: StatusScheduled, Created: now}
+	if job.CatchUp == "" {
+		job.CatchUp = s.catchUp
+	}
+	if !ValidCatchUp(job.CatchUp) {
+		return nil, fmt.Errorf("catch-up policy must be %s or %s, got %q", CatchUpSkip, CatchUpOnce, job.CatchUp)
+	}
+	location := s.location
+	if spec.Timezone != "" {
+		var err error
+		if location, err = time.LoadLocation(spec.Timezone); err != nil {
+			return nil, fmt.Errorf("unknown timezone %q", spec.Timezone)
+		}
+	}
+	job.Timezone = location.String()
+
+	switch {
+	case spec.Cron != "" && !spec.At.IsZero():
+		return nil, fmt.Errorf("give either a time or a cron schedule, not both")
+	case spec.Cron != "":
+		cron, err := ParseCron(spec.Cron)
+		if err != nil {
+			return nil, err
+		}
+		job.Cron = spec.Cron
+		if job.NextRun = cron.Next(now.In(location)); job.NextRun.IsZero() {
+			return nil, fmt.Errorf("cron expression %q never matches", spec.Cron)
+		}
+	case !spec.At.IsZero():
+		if spec.At.Before(now.Add(-LateGrace)) {
+			return nil, fmt.Errorf("%s is in the past", spec.At.Format(time.RFC3339))
+		}
+		job.At = spec.At.Format(time.RFC3339)
+		job.NextRun = spec.At
+	default:
+		return nil, fmt.Errorf("a time or a cron schedule is required")
+	}
+
+	s.jobs[job.ID] = job
+	if err := s.saveLocked(); err != nil {
+		delete(s.jobs, job.ID)
+		return nil, err
+	}
+	copied := *job
+	return &copied, nil
+}
+
+// List returns the jobs by next run, scheduled ones first; finished ones only when all is set
+func (s *Store) List(all bool) []Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	jobs := []Job{}
+	for _, job := range s.jobs {
+		if all || !job.done() {
+			jobs = append(jobs, *job)
+		}
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		if jobs[i].done() != jobs[j].done() {
+			return !jobs[i].done()
+		}
+		if !jobs[i].NextRun.Equal(jobs[j].NextRun) {
+			return jobs[i].NextRun.Before(jobs[j].NextRun)
+		}
+		return jobs[i].Created.Before(jobs[j].Created)
+	})
+	return jobs
+}
+
+// Cancel stops a scheduled job and returns it
+func (s *Store) Cancel(id string) (*Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job, ok := s.jobs[id]
+	if !ok {
+		return nil, fmt.Errorf("no scheduled message with ID %s", id)
+	}
+	if job.done() {
+		return nil, fmt.Errorf("scheduled message %s is already %s", id, job.Status)
+	}
+	job.Status = StatusCancelled
+	job.Finished = s.now()
+	job.NextRun = time.Time{}
+	if err := s.saveLocked(); err != nil {
+		return nil, err
+	}
+	copied := *job
+	return &copied, nil
+}
+
+// RunDue posts the messages of due jobs, each with the client clients returns
+// for its profile. The store is saved after every post, so a crash never sends
+// a run again; when a save fails the remaining jobs wait for the next call. It
+// returns the number posted; failures are recorded on their jobs and reported
+// in the error.
+func (s *Store) RunDue(clients ClientFunc) (int, error) {
+	s.runMu.Lock()
+	defer s.runMu.Unlock()
+
+	now := s.now()
+	s.mu.Lock()
+	var due []*Job
+	changed := false
+	for id, job := range s.jobs {
+		if job.done() {
+			if now.Sub(job.Finished) > keepFinished {
+				delete(s.jobs, id)
+				changed = true
+			}
+			continue
+		}
+		if !job.NextRun.After(now) {
+			due = append(due, job)
+		}
+	}
+	// Missed runs are settled before anything is posted
+	var send []*Job
+	for _, job := range due {
+		if job.Attempts == 0 && now.Sub(job.NextRun) > LateGrace && job.CatchUp == CatchUpSkip {
+			s.skipMissed(job, now)
+			changed = true
+			continue
+		}
+		send = append(send, job)
+	}
+	sort.Slice(send, func(i, j int) bool { return send[i].NextRun.Before(send[j].NextRun) })
+	if changed {
+		if err := s.saveLocked(); err != nil {
+			s.mu.Unlock()
+			return 0, err
+		}
+	}
+	s.mu.Unlock()
+
+	sent := 0
+	var errs []error
+	for _, job := range send {
+		s.mu.Lock()
+		body, profile := job.Message, job.Profile
+		s.mu.Unlock()
+		var message map[string]interface{}
+		client, err := clients(profile)
+		if err == nil {
+			message, err = client.Post("/messages", body)
+		}
+
+		s.mu.Lock()
+		job.Attempts++
+		if err != nil {
+			job.LastError = err.Error()
+			errs = append(errs, fmt.Errorf("scheduled message %s: %w", job.ID, err))
+			if job.Attempts >= maxAttempts {
+				s.advance(job, now)
+				if job.At != "" {
+					job.Status, job.Finished = StatusFailed, now
+				}
+			}
+			saveErr := s.saveLocked()
+			s.mu.Unlock()
+			if saveErr != nil {
+				errs = append(errs, saveErr)
+				break
+			}
+			continue
+		}
+		sent++
+		job.Runs++
+		job.LastRun = s.now()
+		job.LastMessageID, _ = message["id"].(string)
+		job.LastError = ""
+		s.advance(job, now)
+		// A job cancelled while it was being posted stays cancelled
+		if job.At != "" && job.Status == StatusScheduled {
+			job.Status, job.Finished = StatusSent, job.LastRun
+		}
+		saveErr := s.saveLocked()
+		s.mu.Unlock()
+		if saveErr != nil {
+			errs = append(errs, saveErr)
+			break
+		}
+	}
+
+	if len(errs) > 0 {
+		return sent, fmt.Errorf("scheduled messages failed: %w", errors.Join(errs...))
+	}
+	return sent, nil
+}
+
+// skipMissed drops the runs of job missed before now; s.mu must be held
+func (s *Store) skipMissed(job *Job, now time.Time) {
+	if job.At != "" {
+		job.Missed++
+		job.Status, job.Finished, job.NextRun = StatusMissed, now, time.Time{}
+		return
+	}
+	cron, err := ParseCron(job.Cron)
+	if err != nil {
+		return
+	}
+	location := s.jobLocation(job)
+	for !job.NextRun.IsZero() && now.Sub(job.NextRun) > LateGrace {
+		job.Missed++
+		job.NextRun = cron.Next(job.NextRun.In(location))
+	}
+	s.finishIfNoNextRun(job, now)
+}
+
+// advance moves a recurring job to its first run after now, so a catch-up posts
+// once however many runs were missed; s.mu must be held
+func (s *Store) advance(job *Job, now time.Time) {
+	job.Attempts = 0
+	if job.Cron == "" {
+		job.NextRun = time.Time{}
+		return
+	}
+	cron, err := ParseCron(job.Cron)
+	if err != nil {
+		job.Status, job.Finished, job.LastError = StatusFailed, now, err.Error()
+		return
+	}
+	location := s.jobLocation(job)
+	for next := cron.Next(job.NextRun.In(location)); ; next = cron.Next(next) {
+		if next.IsZero() || next.After(now) {
+			job.NextRun = next
+			break
+		}
+		job.Missed++
+	}
+	s.finishIfNoNextRun(job, now)
+}
+
+// finishIfNoNextRun ends a recurring job whose schedule has no more runs
+func (s *Store) finishIfNoNextRun(job *Job, now time.Time) {
+	if job.NextRun.IsZero() {
+		job.Status, job.Finished = StatusSent, now
+	}
+}
+
+func (s *Store) jobLocation(job *Job) *time.Location {
+	if location, err := time.LoadLocation(job.Timezone); err == nil {
+		return location
+	}
+	return s.location
+}
+
+// Run posts due jobs at once and then every TickInterval until ctx is done, logging failures
+func (s *Store) Run(ctx context.Context, clients ClientFunc) {
+	ticker := time.NewTicker(TickInterval)
+	defer ticker.Stop()
+	for {
+		if n, err := s.RunDue(clients); err != nil {
+			log.Printf("%v", err)
+		} else if n > 0 {
+			log.Printf("Posted %d scheduled messages", n)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func newID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// saveLocked writes the store file atomically; s.mu must be held
+func (s *Store) saveLocked() error {
+	data, err := json.MarshalIndent(storeFile{Version: storeVersion, Jobs: s.jobs}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode scheduled jobs: %w", err)
+	}
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create scheduled jobs directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, filepath.Base(s.path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write scheduled jobs: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write scheduled jobs: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write scheduled jobs: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to write scheduled jobs: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,282 @@
+package scheduler
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/raja-aiml/webex-mcp-server/internal/config"
+	"github.com/raja-aiml/webex-mcp-server/internal/fakewebex"
+	"github.com/raja-aiml/webex-mcp-server/internal/testutil"
+	"github.com/raja-aiml/webex-mcp-server/internal/webex"
+)
+
+func TestCron(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("no time zone database")
+	}
+	friday := time.Date(2026, 10, 16, 10, 7, 0, 0, time.UTC)
+
+	tests := []struct {
+		expr    string
+		from    time.Time
+		want    time.Time
+		wantErr string
+	}{
+		{expr: "0 9 * * mon-fri", from: friday, want: time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)},
+		{expr: "*/15 * * * *", from: friday, want: time.Date(2026, 10, 16, 10, 15, 0, 0, time.UTC)},
+		{expr: "0 0 1,15 * *", from: friday, want: time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 9 13 * fri", from: friday, want: time.Date(2026, 10, 23, 9, 0, 0, 0, time.UTC)},
+		{expr: "0 12 * jan-mar/2 *", from: friday, want: time.Date(2027, 1, 1, 12, 0, 0, 0, time.UTC)},
+		{expr: "@hourly", from: friday, want: time.Date(2026, 10, 16, 11, 0, 0, 0, time.UTC)},
+		{expr: "0 0 * * 7", from: friday, want: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)},
+		// 02:30 does not exist on the day clocks go forward
+		{expr: "30 2 * * *", from: time.Date(2026, 3, 7, 12, 0, 0, 0, newYork), want: time.Date(2026, 3, 9, 2, 30, 0, 0, newYork)},
+		{expr: "0 9 * * *", from: time.Date(2026, 3, 7, 12, 0, 0, 0, newYork), want: time.Date(2026, 3, 8, 9, 0, 0, 0, newYork)},
+		{expr: "0 0 30 2 *", from: friday},
+		{expr: "61 * * * *", wantErr: "outside 0-59"},
+		{expr: "* * *", wantErr: "must have 5 fields"},
+		{expr: "5-1 * * * *", wantErr: "backwards"},
+		{expr: "0 9 * * funday", wantErr: "invalid value"},
+		{expr: "*/0 * * * *", wantErr: "invalid step"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			cron, err := ParseCron(tt.expr)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("ParseCron() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := cron.Next(tt.from); !got.Equal(tt.want) {
+				t.Errorf("Next(%s) = %s, want %s", tt.from, got, tt.want)
+			}
+		})
+	}
+}
+
+func newStore(t *testing.T) (*fakewebex.Server, webex.HTTPClient, *Store, *time.Time, string) {
+	t.Helper()
+	fake, baseURL := testutil.NewFakeWebex(t)
+	client, err := webex.NewClientWithConfig(&config.Config{WebexAPIKey: "token", WebexAPIBaseURL: baseURL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	room, _ := fake.Add(fakewebex.Rooms, fakewebex.Item{"title": "Ops"})
+	path := filepath.Join(t.TempDir(), "schedule", "jobs.json")
+	store, err := Open(path, time.UTC, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, 10, 16, 8, 30, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+	return fake, client, store, &now, room["id"].(string)
+}
+
+// fixed returns client for every profile, recording the profiles asked for
+func fixed(client webex.HTTPClient, profiles *[]string) ClientFunc {
+	return func(profile string) (webex.HTTPClient, error) {
+		if profiles != nil {
+			*profiles = append(*profiles, profile)
+		}
+		return client, nil
+	}
+}
+
+func TestStoreAdd(t *testing.T) {
+	_, _, store, now, roomID := newStore(t)
+	message := Message{RoomID: roomID, Text: "hi"}
+
+	tests := []struct {
+		name    string
+		spec    Spec
+		wantErr string
+	}{
+		{name: "no recipient", spec: Spec{Message: Message{Text: "hi"}, At: now.Add(time.Hour)}, wantErr: "a room or a person"},
+		{name: "no text", spec: Spec{Message: Message{RoomID: roomID}, At: now.Add(time.Hour)}, wantErr: "text or markdown"},
+		{name: "no time", spec: Spec{Message: message}, wantErr: "a time or a cron schedule is required"},
+		{name: "both", spec: Spec{Message: message, At: now.Add(time.Hour), Cron: "@daily"}, wantErr: "not both"},
+		{name: "past", spec: Spec{Message: message, At: now.Add(-time.Hour)}, wantErr: "in the past"},
+		{name: "bad zone", spec: Spec{Message: message, Cron: "@daily", Timezone: "Mars/Olympus"}, wantErr: "unknown timezone"},
+		{name: "bad policy", spec: Spec{Message: message, Cron: "@daily", CatchUp: "all"}, wantErr: "catch-up policy"},
+		{name: "never", spec: Spec{Message: message, Cron: "0 0 31 2 *"}, wantErr: "never matches"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := store.Add(tt.spec); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Add() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	// A cron schedule is read in the job's zone, where 08:30 UTC is past 09:00
+	job, err := store.Add(Spec{Message: message, Cron: "0 9 * * *", Timezone: "Europe/Berlin"})
+	if err != nil {
+		t.Skipf("Add() = %v; no time zone database?", err)
+	}
+	if want := time.Date(2026, 10, 17, 7, 0, 0, 0, time.UTC); !job.NextRun.Equal(want) || job.CatchUp != CatchUpOnce || job.Timezone != "Europe/Berlin" {
+		t.Errorf("job = %+v, want next run %s", job, want)
+	}
+	if jobs := store.List(false); len(jobs) != 1 {
+		t.Errorf("List() = %+v; only the valid job should be kept", jobs)
+	}
+}
+
+func TestStoreRunDue(t *testing.T) {
+	fake, client, store, now, roomID := newStore(t)
+	at := now.Add(time.Hour)
+	once, err := store.Add(Spec{Message: Message{RoomID: roomID, Markdown: "**later**"}, At: at})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hourly, _ := store.Add(Spec{Message: Message{RoomID: roomID, Text: "hourly"}, Cron: "0 * * * *", Profile: "bot"})
+	skipped, _ := store.Add(Spec{Message: Message{RoomID: roomID, Text: "skipped"}, Cron: "0 * * * *", CatchUp: CatchUpSkip})
+	cancelled, _ := store.Add(Spec{Message: Message{RoomID: roomID, Text: "cancelled"}, At: at})
+	if _, err := store.Cancel(cancelled.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Cancel(cancelled.ID); err == nil || !strings.Contains(err.Error(), "already cancelled") {
+		t.Errorf("second Cancel() = %v", err)
+	}
+
+	var profiles []string
+	clients := fixed(client, &profiles)
+	if n, err := store.RunDue(clients); n != 0 || err != nil {
+		t.Fatalf("RunDue() before anything is due = %d, %v", n, err)
+	}
+
+	// The server was down for five hours; the store is read back from disk
+	reopened, err := Open(store.path, time.UTC, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	*now = now.Add(5 * time.Hour)
+	reopened.now = store.now
+	if n, err := reopened.RunDue(clients); n != 2 || err != nil {
+		t.Fatalf("RunDue() after downtime = %d, %v", n, err)
+	}
+	// Each job posts as the profile it was scheduled with, kept across the restart
+	if strings.Join(profiles, ",") != "bot," {
+		t.Errorf("clients asked for profiles %q", profiles)
+	}
+
+	jobs := map[string]Job{}
+	for _, job := range reopened.List(true) {
+		jobs[job.ID] = job
+	}
+	next := time.Date(2026, 10, 16, 14, 0, 0, 0, time.UTC)
+	if job := jobs[once.ID]; job.Status != StatusSent || job.Runs != 1 || job.LastMessageID == "" {
+		t.Errorf("one-off job = %+v", job)
+	}
+	if job := jobs[hourly.ID]; job.Status != StatusScheduled || job.Runs != 1 || job.Missed != 4 || !job.NextRun.Equal(next) {
+		t.Errorf("catch-up once job = %+v", job)
+	}
+	if job := jobs[skipped.ID]; job.Runs != 0 || job.Missed != 5 || !job.NextRun.Equal(next) {
+		t.Errorf("catch-up skip job = %+v", job)
+	}
+	if job := jobs[cancelled.ID]; job.Status != StatusCancelled || job.Runs != 0 {
+		t.Errorf("cancelled job = %+v", job)
+	}
+	// Due runs are posted oldest first
+	var posted []string
+	for _, m := range fake.List(fakewebex.Messages) {
+		text, _ := m["text"].(string)
+		markdown, _ := m["markdown"].(string)
+		posted = append(posted, markdown+text)
+	}
+	if strings.Join(posted, ",") != "hourly,**later**" {
+		t.Errorf("posted %v", posted)
+	}
+	if jobs := reopened.List(false); len(jobs) != 2 || jobs[0].ID != hourly.ID && jobs[0].ID != skipped.ID {
+		t.Errorf("List(false) = %+v", jobs)
+	}
+}
+
+func TestStoreRunDueFailures(t *testing.T) {
+	fake, client, store, now, roomID := newStore(t)
+	job, _ := store.Add(Spec{Message: Message{RoomID: roomID, Text: "flaky"}, At: now.Add(time.Minute)})
+	*now = now.Add(time.Minute)
+	fake.InjectFault(fakewebex.Fault{Path: "/messages", Status: http.StatusInternalServerError, Times: maxAttempts})
+
+	// Each tick tries again, even once the run is later than LateGrace
+	for i := 1; i <= maxAttempts; i++ {
+		if n, err := store.RunDue(fixed(client, nil)); n != 0 || err == nil {
+			t.Fatalf("RunDue() attempt %d = %d, %v", i, n, err)
+		}
+		*now = now.Add(LateGrace)
+	}
+	got := store.List(true)[0]
+	if got.ID != job.ID || got.Status != StatusFailed || got.LastError == "" || !got.NextRun.IsZero() {
+		t.Errorf("job = %+v", got)
+	}
+
+	// A profile that can no longer post fails the run like a failed post
+	removed, _ := store.Add(Spec{Message: Message{RoomID: roomID, Text: "as bot"}, At: now.Add(time.Minute), Profile: "bot"})
+	*now = now.Add(time.Minute)
+	unknown := func(profile string) (webex.HTTPClient, error) { return nil, fmt.Errorf("unknown profile %q", profile) }
+	if n, err := store.RunDue(unknown); n != 0 || err == nil || !strings.Contains(err.Error(), `unknown profile "bot"`) {
+		t.Errorf("RunDue() with an unknown profile = %d, %v", n, err)
+	}
+	for _, got := range store.List(false) {
+		if got.ID == removed.ID && (got.Attempts != 1 || got.Profile != "bot") {
+			t.Errorf("job = %+v", got)
+		}
+	}
+}
+
+func TestStoreRunDueSavesEachPost(t *testing.T) {
+	fake, client, store, now, roomID := newStore(t)
+	var jobs []*Job
+	for _, text := range []string{"first", "second", "third"} {
+		job, err := store.Add(Spec{Message: Message{RoomID: roomID, Text: text}, At: now.Add(time.Minute)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		*now = now.Add(time.Second)
+		jobs = append(jobs, job)
+	}
+	*now = now.Add(time.Minute)
+
+	// Before the second post the first is already on disk; then the store can no longer be written
+	path := store.path
+	calls := 0
+	clients := func(profile string) (webex.HTTPClient, error) {
+		calls++
+		if calls == 2 {
+			saved, err := Open(path, time.UTC, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := saved.List(true); got[len(got)-1].ID != jobs[0].ID || got[len(got)-1].Status != StatusSent {
+				t.Errorf("saved before the second post = %+v", got)
+			}
+			blocker := filepath.Join(t.TempDir(), "file")
+			if err := os.WriteFile(blocker, nil, 0600); err != nil {
+				t.Fatal(err)
+			}
+			store.path = filepath.Join(blocker, "jobs.json")
+		}
+		return client, nil
+	}
+	if n, err := store.RunDue(clients); n != 2 || err == nil || !strings.Contains(err.Error(), "scheduled jobs") {
+		t.Fatalf("RunDue() = %d, %v; want two posts and a save error", n, err)
+	}
+	// The third job waits for a store that can record it
+	if posted := fake.List(fakewebex.Messages); len(posted) != 2 || calls != 2 {
+		t.Errorf("posted %d messages with %d clients", len(posted), calls)
+	}
+	for _, job := range store.List(false) {
+		if job.ID != jobs[2].ID {
+			t.Errorf("job %s (%s) is still scheduled", job.ID, job.Message.Text)
+		}
+	}
+}
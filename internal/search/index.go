@@ -0,0 +1,215 @@
+// Package search keeps a local full-text index of messages copied from Webex
+// rooms, since Webex offers bots no way to search message content.
+package search
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// FileName is the index file kept in the search directory
+const FileName = "index.json"
+
+// indexVersion is bumped when the file layout changes
+const indexVersion = 1
+
+// Document is one indexed message
+type Document struct {
+	ID          string    `json:"id"`
+	RoomID      string    `json:"roomId"`
+	ParentID    string    `json:"parentId,omitempty"`
+	PersonID    string    `json:"personId,omitempty"`
+	PersonEmail string    `json:"personEmail,omitempty"`
+	Sender      string    `json:"sender,omitempty"`
+	Text        string    `json:"text"`
+	Created     time.Time `json:"created"`
+}
+
+// Room is the sync state of an indexed room
+type Room struct {
+	Title    string    `json:"title,omitempty"`
+	Newest   time.Time `json:"newest"`   // Creation time of the newest indexed message
+	LastSync time.Time `json:"lastSync"` // When the room was last synced
+}
+
+// Index holds the documents of every synced room with an inverted index of
+// their terms. It is kept in memory and saved to a single file.
+type Index struct {
+	path string
+
+	mu       sync.RWMutex
+	docs     map[string]*Document
+	postings map[string]map[string]int // Term to document ID to occurrences
+	rooms    map[string]*Room
+}
+
+type indexFile struct {
+	Version   int              `json:"version"`
+	Rooms     map[string]*Room `json:"rooms"`
+	Documents []*Document      `json:"documents"`
+}
+
+var (
+	sharedMu sync.Mutex
+	shared   = map[string]*Index{}
+)
+
+// Shared returns the index kept in dir, opening it on first use, so the sync
+// worker and the tools of one process work on the same index
+func Shared(dir string) (*Index, error) {
+	sharedMu.Lock()
+	defer sharedMu.Unlock()
+
+	if index, ok := shared[dir]; ok {
+		return index, nil
+	}
+	index, err := Open(dir)
+	if err != nil {
+		return nil, err
+	}
+	shared[dir] = index
+	return index, nil
+}
+
+// Open loads the index kept in dir, starting an empty one when there is none yet
+func Open(dir string) (*Index, error) {
+	index := &Index{
+		path:     filepath.Join(dir, FileName),
+		docs:     map[string]*Document{},
+		postings: map[string]map[string]int{},
+		rooms:    map[string]*Room{},
+	}
+
+	data, err := os.ReadFile(index.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return index, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read search index: %w", err)
+	}
+	var file indexFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse search index %s: %w", index.path, err)
+	}
+	if file.Version != indexVersion {
+		return nil, fmt.Errorf("search index %s has version %d, want %d; delete it to rebuild", index.path, file.Version, indexVersion)
+	}
+	for id, room := range file.Rooms {
+		index.rooms[id] = room
+	}
+	for _, doc := range file.Documents {
+		index.add(doc)
+	}
+	return index, nil
+}
+
+// Save writes the index to its file, replacing the previous one atomically
+func (x *Index) Save() error {
+	x.mu.RLock()
+	file := indexFile{Version: indexVersion, Rooms: x.rooms, Documents: make([]*Document, 0, len(x.docs))}
+	for _, doc := range x.docs {
+		file.Documents = append(file.Documents, doc)
+	}
+	data, err := json.Marshal(file)
+	x.mu.RUnlock()
+	if err != nil {
+		return fmt.Errorf("failed to encode search index: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(x.path), 0700); err != nil {
+		return fmt.Errorf("failed to create search directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(x.path), FileName+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write search index: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write search index: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write search index: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), x.path); err != nil {
+		return fmt.Errorf("failed to write search index: %w", err)
+	}
+	return nil
+}
+
+// Add indexes documents of a room and records the sync; documents already in
+// the index are replaced. It returns how many documents were new.
+func (x *Index) Add(roomID, title string, docs []*Document, synced time.Time) int {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	room := x.rooms[roomID]
+	if room == nil {
+		room = &Room{}
+		x.rooms[roomID] = room
+	}
+	if title != "" {
+		room.Title = title
+	}
+	room.LastSync = synced
+
+	added := 0
+	for _, doc := range docs {
+		if _, exists := x.docs[doc.ID]; exists {
+			x.remove(doc.ID)
+		} else {
+			added++
+		}
+		x.add(doc)
+		if doc.Created.After(room.Newest) {
+			room.Newest = doc.Created
+		}
+	}
+	return added
+}
+
+// Room returns the sync state of a room
+func (x *Index) Room(roomID string) (Room, bool) {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	room, ok := x.rooms[roomID]
+	if !ok {
+		return Room{}, false
+	}
+	return *room, true
+}
+
+// Len returns the number of indexed documents
+func (x *Index) Len() int {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	return len(x.docs)
+}
+
+func (x *Index) add(doc *Document) {
+	x.docs[doc.ID] = doc
+	for _, term := range tokenize(doc.Text) {
+		postings := x.postings[term]
+		if postings == nil {
+			postings = map[string]int{}
+			x.postings[term] = postings
+		}
+		postings[doc.ID]++
+	}
+}
+
+func (x *Index) remove(id string) {
+	doc := x.docs[id]
+	for _, term := range tokenize(doc.Text) {
+		delete(x.postings[term], id)
+		if len(x.postings[term]) == 0 {
+			delete(x.postings, term)
+		}
+	}
+	delete(x.docs, id)
+}
@@ -0,0 +1,262 @@
+package search
+
+import (
+	"encoding/base64"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// DefaultMaxResults bounds Search when Query.Max is not set
+const DefaultMaxResults = 20
+
+// snippetRadius is the number of characters kept on each side of a match
+const snippetRadius = 80
+
+// Query selects indexed messages. Text holds words that must all appear;
+// a word ending in * matches any word it starts, and "quoted phrases" must
+// appear as written. With no text, matching messages are listed newest first.
+type Query struct {
+	Text   string
+	RoomID string
+	Sender string    // Part of the sender's name or email, or their person ID
+	From   time.Time // Only messages created at or after From
+	To     time.Time // Only messages created before To
+	Max    int       // 0 means DefaultMaxResults
+}
+
+// Result is a matching message
+type Result struct {
+	MessageID   string    `json:"messageId"`
+	RoomID      string    `json:"roomId"`
+	RoomTitle   string    `json:"roomTitle,omitempty"`
+	ParentID    string    `json:"parentId,omitempty"`
+	Sender      string    `json:"sender,omitempty"`
+	PersonEmail string    `json:"personEmail,omitempty"`
+	Created     time.Time `json:"created"`
+	Snippet     string    `json:"snippet"`
+	Link        string    `json:"link,omitempty"`
+	Score       float64   `json:"score"`
+}
+
+// Results is the outcome of a search
+type Results struct {
+	Total   int      `json:"total"` // Matches before Max was applied
+	Results []Result `json:"results"`
+}
+
+// Search returns the best matches for q, ties broken by the newest message
+func (x *Index) Search(q Query) Results {
+	terms, phrases := parseQuery(q.Text)
+	max := q.Max
+	if max <= 0 {
+		max = DefaultMaxResults
+	}
+
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	scores := map[string]float64{}
+	if len(terms) == 0 {
+		for id := range x.docs {
+			scores[id] = 0
+		}
+	} else {
+		for i, term := range terms {
+			matches := x.match(term)
+			next := map[string]float64{}
+			idf := math.Log(1 + float64(len(x.docs))/float64(1+len(matches)))
+			for id, count := range matches {
+				if score, ok := scores[id]; ok || i == 0 {
+					next[id] = score + float64(count)*idf
+				}
+			}
+			scores = next
+		}
+	}
+
+	var results []Result
+	for id, score := range scores {
+		doc := x.docs[id]
+		if !x.matchesFilters(doc, q) || !containsPhrases(doc.Text, phrases) {
+			continue
+		}
+		results = append(results, Result{
+			MessageID:   doc.ID,
+			RoomID:      doc.RoomID,
+			RoomTitle:   x.roomTitle(doc.RoomID),
+			ParentID:    doc.ParentID,
+			Sender:      doc.Sender,
+			PersonEmail: doc.PersonEmail,
+			Created:     doc.Created,
+			Snippet:     snippet(doc.Text, append(phrases, terms...)),
+			Link:        Link(doc.RoomID, doc.ID),
+			Score:       math.Round(score*100) / 100,
+		})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Created.After(results[j].Created)
+	})
+
+	total := len(results)
+	if len(results) > max {
+		results = results[:max]
+	}
+	if results == nil {
+		results = []Result{}
+	}
+	return Results{Total: total, Results: results}
+}
+
+// match returns the occurrences of term, or of every term it is a prefix of
+func (x *Index) match(term string) map[string]int {
+	prefix, ok := strings.CutSuffix(term, "*")
+	if !ok {
+		return x.postings[term]
+	}
+	matches := map[string]int{}
+	for candidate, postings := range x.postings {
+		if strings.HasPrefix(candidate, prefix) {
+			for id, count := range postings {
+				matches[id] += count
+			}
+		}
+	}
+	return matches
+}
+
+func (x *Index) matchesFilters(doc *Document, q Query) bool {
+	if q.RoomID != "" && doc.RoomID != q.RoomID {
+		return false
+	}
+	if !q.From.IsZero() && doc.Created.Before(q.From) {
+		return false
+	}
+	if !q.To.IsZero() && !doc.Created.Before(q.To) {
+		return false
+	}
+	if q.Sender != "" {
+		sender := strings.ToLower(q.Sender)
+		if doc.PersonID != q.Sender &&
+			!strings.Contains(strings.ToLower(doc.PersonEmail), sender) &&
+			!strings.Contains(strings.ToLower(doc.Sender), sender) {
+			return false
+		}
+	}
+	return true
+}
+
+func (x *Index) roomTitle(roomID string) string {
+	if room := x.rooms[roomID]; room != nil {
+		return room.Title
+	}
+	return ""
+}
+
+// tokenize splits text into lowercase words of letters and digits
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// parseQuery splits a query into terms and quoted phrases. Phrase words are
+// also terms, so the index narrows the candidates before phrases are checked.
+func parseQuery(text string) (terms, phrases []string) {
+	parts := strings.Split(text, `"`)
+	for i, part := range parts {
+		if i%2 == 1 {
+			if phrase := strings.Join(tokenize(part), " "); phrase != "" {
+				phrases = append(phrases, phrase)
+				terms = append(terms, tokenize(part)...)
+			}
+			continue
+		}
+		for _, field := range strings.Fields(part) {
+			wildcard := strings.HasSuffix(field, "*")
+			for _, term := range tokenize(field) {
+				terms = append(terms, term)
+			}
+			if wildcard && len(terms) > 0 {
+				terms[len(terms)-1] += "*"
+			}
+		}
+	}
+	return terms, phrases
+}
+
+func containsPhrases(text string, phrases []string) bool {
+	if len(phrases) == 0 {
+		return true
+	}
+	normalized := " " + strings.Join(tokenize(text), " ") + " "
+	for _, phrase := range phrases {
+		if !strings.Contains(normalized, " "+phrase+" ") {
+			return false
+		}
+	}
+	return true
+}
+
+// snippet returns the text around the first match of any of the terms, or the
+// start of the text when none is found
+func snippet(text string, terms []string) string {
+	lower := strings.ToLower(text)
+	at := -1
+	for _, term := range terms {
+		term = strings.TrimSuffix(term, "*")
+		if i := strings.Index(lower, term); i >= 0 && (at < 0 || i < at) {
+			at = i
+		}
+	}
+	if at < 0 {
+		at = 0
+	}
+
+	runes := []rune(text)
+	center := len([]rune(text[:min(at, len(text))]))
+	start := max(0, center-snippetRadius)
+	end := min(len(runes), center+snippetRadius)
+	s := strings.Join(strings.Fields(string(runes[start:end])), " ")
+	if start > 0 {
+		s = "…" + s
+	}
+	if end < len(runes) {
+		s += "…"
+	}
+	return s
+}
+
+// Link returns a link that opens the message in the Webex app. Webex IDs are
+// base64 encodings of URIs ending in a UUID; other IDs get no link.
+func Link(roomID, messageID string) string {
+	room, ok := uuidOf(roomID)
+	if !ok {
+		return ""
+	}
+	message, ok := uuidOf(messageID)
+	if !ok {
+		return ""
+	}
+	return "webexteams://im?space=" + room + "&message=" + message
+}
+
+func uuidOf(id string) (string, bool) {
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(id, "="))
+	if err != nil {
+		if decoded, err = base64.RawStdEncoding.DecodeString(strings.TrimRight(id, "=")); err != nil {
+			return "", false
+		}
+	}
+	uri := string(decoded)
+	i := strings.LastIndex(uri, "/")
+	if !strings.HasPrefix(uri, "ciscospark://") || i < 0 || i == len(uri)-1 {
+		return "", false
+	}
+	return uri[i+1:], true
+}
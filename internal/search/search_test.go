@@ -0,0 +1,191 @@
+package search
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/raja-aiml/webex-mcp-server/internal/config"
+	"github.com/raja-aiml/webex-mcp-server/internal/fakewebex"
+	"github.com/raja-aiml/webex-mcp-server/internal/testutil"
+	"github.com/raja-aiml/webex-mcp-server/internal/webex"
+)
+
+var start = time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+type fixture struct {
+	fake   *fakewebex.Server
+	syncer *Syncer
+	rooms  map[string]string
+	alice  string
+}
+
+// newFixture seeds two rooms and syncs them into a new index
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	fake, baseURL := testutil.NewFakeWebex(t)
+	clock := start
+	fake.SetClock(func() time.Time {
+		clock = clock.Add(time.Minute)
+		return clock
+	})
+
+	alice, err := fake.Add(fakewebex.People, fakewebex.Item{"displayName": "Alice Example", "emails": []interface{}{"alice@example.com"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &fixture{fake: fake, rooms: map[string]string{}, alice: alice["id"].(string)}
+	for _, title := range []string{"Incidents", "Planning"} {
+		room, err := fake.Add(fakewebex.Rooms, fakewebex.Item{"title": title})
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.rooms[title] = room["id"].(string)
+	}
+	f.post(t, "Incidents", f.alice, "The database outage started at 09:10 after the failover")
+	f.post(t, "Incidents", "", "Outages like this need a postmortem")
+	f.post(t, "Planning", "", "Plan the Q3 launch; no outage expected")
+	f.post(t, "Planning", f.alice, "Database migration moves to **Friday**")
+
+	client, err := webex.NewClientWithConfig(&config.Config{WebexAPIKey: "token", WebexAPIBaseURL: baseURL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	index, err := Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.syncer = &Syncer{Index: index, Client: client, Rooms: []string{f.rooms["Incidents"], f.rooms["Planning"]}}
+	if n, err := f.syncer.SyncOnce(); err != nil || n != 4 {
+		t.Fatalf("SyncOnce() = %d, %v; want 4 messages", n, err)
+	}
+	return f
+}
+
+func (f *fixture) post(t *testing.T, room, personID, text string) {
+	t.Helper()
+	fields := fakewebex.Item{"roomId": f.rooms[room], "markdown": text, "text": strings.ReplaceAll(text, "**", "")}
+	var err error
+	if personID != "" {
+		_, err = f.fake.PostAs(personID, fields)
+	} else {
+		_, err = f.fake.Add(fakewebex.Messages, fields)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	f := newFixture(t)
+	index := f.syncer.Index
+
+	tests := []struct {
+		name  string
+		query Query
+		want  []string // Leading words of the matching messages, best first
+	}{
+		{name: "word", query: Query{Text: "outage"}, want: []string{"Plan", "The"}},
+		{name: "every word", query: Query{Text: "database outage"}, want: []string{"The"}},
+		{name: "prefix", query: Query{Text: "outage*"}, want: []string{"Plan", "Outages", "The"}},
+		{name: "phrase", query: Query{Text: `"database migration"`}, want: []string{"Database"}},
+		{name: "phrase words in another order", query: Query{Text: `"migration database"`}},
+		{name: "room", query: Query{Text: "outage", RoomID: f.rooms["Incidents"]}, want: []string{"The"}},
+		{name: "sender name", query: Query{Text: "database", Sender: "alice"}, want: []string{"Database", "The"}},
+		{name: "sender email", query: Query{Sender: "fake"}, want: []string{"Plan", "Outages"}},
+		{name: "no text lists the newest", query: Query{Max: 2}, want: []string{"Database", "Plan"}},
+		{name: "unknown word", query: Query{Text: "kubernetes"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := index.Search(tt.query)
+			var got []string
+			for _, result := range results.Results {
+				got = append(got, strings.Fields(result.Snippet)[0])
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Search(%+v) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+
+	result := index.Search(Query{Text: "failover"}).Results[0]
+	if result.RoomTitle != "Incidents" || result.Sender != "Alice Example" || result.PersonEmail != "alice@example.com" {
+		t.Errorf("result = %+v", result)
+	}
+	if !strings.HasPrefix(result.Link, "webexteams://im?space=") || !strings.Contains(result.Link, "&message=") {
+		t.Errorf("link = %q", result.Link)
+	}
+
+	// Dates bound the results; messages were created a minute apart
+	all := index.Search(Query{Sender: "alice"}).Results
+	if got := index.Search(Query{Sender: "alice", From: all[0].Created}); got.Total != 1 {
+		t.Errorf("from the newest message found %d, want 1", got.Total)
+	}
+	if got := index.Search(Query{Sender: "alice", To: all[0].Created}); got.Total != 1 || got.Results[0].MessageID != all[1].MessageID {
+		t.Errorf("to the newest message found %+v", got)
+	}
+}
+
+func TestSnippet(t *testing.T) {
+	long := strings.Repeat("filler ", 40) + "the outage began" + strings.Repeat(" filler", 40)
+	got := snippet(long, []string{"outage"})
+	if !strings.HasPrefix(got, "…") || !strings.HasSuffix(got, "…") || !strings.Contains(got, "the outage began") {
+		t.Errorf("snippet = %q", got)
+	}
+	if got := snippet("short text", []string{"missing"}); got != "short text" {
+		t.Errorf("snippet without a match = %q", got)
+	}
+}
+
+func TestSyncIncremental(t *testing.T) {
+	f := newFixture(t)
+
+	requests := f.fake.Requests()
+	if n, err := f.syncer.SyncOnce(); err != nil || n != 0 {
+		t.Errorf("second SyncOnce() = %d, %v; want no new messages", n, err)
+	}
+	if made := f.fake.Requests() - requests; made > 6 {
+		t.Errorf("an idle sync made %d requests", made)
+	}
+
+	f.post(t, "Incidents", f.alice, "Root cause: an expired certificate")
+	if n, err := f.syncer.SyncOnce(); err != nil || n != 1 {
+		t.Fatalf("SyncOnce() after a new message = %d, %v; want 1", n, err)
+	}
+	if got := f.syncer.Index.Search(Query{Text: "certificate"}); got.Total != 1 {
+		t.Errorf("new message not searchable: %+v", got)
+	}
+
+	// The saved index is read back with its rooms and documents
+	reopened, err := Open(filepath.Dir(f.syncer.Index.path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	room, ok := reopened.Room(f.rooms["Incidents"])
+	if reopened.Len() != 5 || !ok || room.Title != "Incidents" || room.Newest.IsZero() {
+		t.Errorf("reopened index has %d documents, room %+v", reopened.Len(), room)
+	}
+	info, err := os.Stat(f.syncer.Index.path)
+	if err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("index file mode = %v, %v, want 0600", info.Mode().Perm(), err)
+	}
+
+	f.syncer.Rooms = append(f.syncer.Rooms, "missing")
+	if _, err := f.syncer.SyncOnce(); err == nil || !strings.Contains(err.Error(), "room missing") {
+		t.Errorf("SyncOnce() with an unknown room = %v", err)
+	}
+}
+
+func TestOpenRejectsOtherVersions(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte(`{"version": 99}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Open(dir); err == nil || !strings.Contains(err.Error(), "version 99") {
+		t.Errorf("Open() error = %v", err)
+	}
+}
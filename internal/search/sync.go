@@ -0,0 +1,114 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/raja-aiml/webex-mcp-server/internal/transcript"
+	"github.com/raja-aiml/webex-mcp-server/internal/webex"
+)
+
+// Backfill is the number of messages indexed from a room's history on its first sync
+const Backfill = 1000
+
+// Syncer copies new messages from rooms into an index
+type Syncer struct {
+	Index  *Index
+	Client webex.HTTPClient
+	Rooms  []string
+	Now    func() time.Time // Defaults to time.Now
+}
+
+// SyncOnce indexes the messages created in each room since its last sync and
+// saves the index. It returns the number of new messages; a room that fails
+// is reported in the error while the others are still synced.
+func (s *Syncer) SyncOnce() (int, error) {
+	now := time.Now
+	if s.Now != nil {
+		now = s.Now
+	}
+
+	added := 0
+	var errs []error
+	for _, roomID := range s.Rooms {
+		n, err := s.syncRoom(roomID, now())
+		if err != nil {
+			errs = append(errs, fmt.Errorf("room %s: %w", roomID, err))
+			continue
+		}
+		added += n
+	}
+	if added > 0 {
+		if err := s.Index.Save(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return added, fmt.Errorf("search sync failed: %w", errors.Join(errs...))
+	}
+	return added, nil
+}
+
+// syncRoom pages back to the newest indexed message. Messages created at the
+// same instant as it are fetched again and replaced, so none are missed.
+func (s *Syncer) syncRoom(roomID string, now time.Time) (int, error) {
+	room, err := s.Client.Get("/rooms/"+roomID, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get room: %w", err)
+	}
+	title, _ := room["title"].(string)
+
+	state, known := s.Index.Room(roomID)
+	from, limit := time.Time{}, Backfill
+	if known && !state.Newest.IsZero() {
+		// Paging continues however far back the newest indexed message is, so a
+		// long gap between syncs leaves no hole in the index
+		from, limit = state.Newest, math.MaxInt
+	}
+	messages, _, err := transcript.ListMessages(s.Client, map[string]string{"roomId": roomID}, from, limit)
+	if err != nil {
+		return 0, err
+	}
+	transcript.ResolveSenders(s.Client, messages)
+
+	docs := make([]*Document, 0, len(messages))
+	for _, message := range messages {
+		text := message.Text
+		if text == "" {
+			text = message.Markdown
+		}
+		docs = append(docs, &Document{
+			ID:          message.ID,
+			RoomID:      roomID,
+			ParentID:    message.ParentID,
+			PersonID:    message.PersonID,
+			PersonEmail: message.PersonEmail,
+			Sender:      message.Sender,
+			Text:        text,
+			Created:     message.Created,
+		})
+	}
+	return s.Index.Add(roomID, title, docs, now), nil
+}
+
+// Run syncs at once and then every interval until ctx is done, logging failures
+func (s *Syncer) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if n, err := s.SyncOnce(); err != nil {
+			log.Printf("%v", err)
+		} else if n > 0 {
+			log.Printf("Search index: added %d messages", n)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package secrets
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// KeyringService is the service name secrets are stored under in the OS keyring
+const KeyringService = "webex-mcp-server"
+
+// keyringLookup is replaceable in tests
+var keyringLookup = lookupKeyring
+
+// lookupKeyring reads a secret from the OS keyring using the platform's command-line
+// tool: the macOS Keychain via security, or the Secret Service (GNOME Keyring,
+// KWallet) via secret-tool on Linux. This avoids cgo and extra dependencies.
+func lookupKeyring(name string) (string, error) {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("security", "find-generic-password", "-s", KeyringService, "-a", name, "-w")
+	case "linux", "freebsd", "openbsd", "netbsd":
+		cmd = exec.Command("secret-tool", "lookup", "service", KeyringService, "account", name)
+	default:
+		return "", fmt.Errorf("OS keyring is not supported on %s", runtime.GOOS)
+	}
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("keyring lookup for %q failed: %v %s", name, err, strings.TrimSpace(stderr.String()))
+	}
+	value := strings.TrimRight(string(out), "\r\n")
+	if value == "" {
+		return "", fmt.Errorf("keyring entry %q not found", name)
+	}
+	return value, nil
+}
@@ -0,0 +1,70 @@
+package secrets
+
+import (
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Placeholder replaces secret values in redacted text
+const Placeholder = "[REDACTED]"
+
+// minSecretLength keeps very short values from turning unrelated text into placeholders
+const minSecretLength = 6
+
+var (
+	knownMu sync.RWMutex
+	known   = map[string]bool{}
+)
+
+// Register marks values as secrets so Redact and redacting writers mask them.
+// Values stay registered for the life of the process, so rotated tokens remain masked.
+func Register(values ...string) {
+	knownMu.Lock()
+	defer knownMu.Unlock()
+	for _, value := range values {
+		if len(value) >= minSecretLength {
+			known[value] = true
+		}
+	}
+}
+
+// Redact replaces every registered secret in s with Placeholder
+func Redact(s string) string {
+	knownMu.RLock()
+	defer knownMu.RUnlock()
+	if len(known) == 0 {
+		return s
+	}
+
+	// Replace longer secrets first so a secret containing another is fully masked
+	values := make([]string, 0, len(known))
+	for value := range known {
+		values = append(values, value)
+	}
+	sort.Slice(values, func(i, j int) bool { return len(values[i]) > len(values[j]) })
+
+	for _, value := range values {
+		s = strings.ReplaceAll(s, value, Placeholder)
+	}
+	return s
+}
+
+// redactingWriter masks registered secrets in each write
+type redactingWriter struct {
+	w io.Writer
+}
+
+// NewRedactingWriter wraps w so registered secrets are masked before being written.
+// Redaction works per Write call, which matches how loggers emit whole lines.
+func NewRedactingWriter(w io.Writer) io.Writer {
+	return &redactingWriter{w: w}
+}
+
+func (r *redactingWriter) Write(p []byte) (int, error) {
+	if _, err := io.WriteString(r.w, Redact(string(p))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
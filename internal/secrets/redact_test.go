@@ -0,0 +1,42 @@
+package secrets
+
+import (
+	"bytes"
+	"log"
+	"testing"
+)
+
+func TestRedact(t *testing.T) {
+	Register("tok-123456789", "tok-123456789-extended", "short")
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "no secrets", input: "nothing to hide", want: "nothing to hide"},
+		{name: "token", input: "Authorization: Bearer tok-123456789", want: "Authorization: Bearer [REDACTED]"},
+		{name: "longer secret wins", input: "tok-123456789-extended", want: "[REDACTED]"},
+		{name: "short values are not registered", input: "short answer", want: "short answer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Redact(tt.input); got != tt.want {
+				t.Errorf("Redact() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactingWriter(t *testing.T) {
+	Register("writer-secret-value")
+
+	var buf bytes.Buffer
+	logger := log.New(NewRedactingWriter(&buf), "", 0)
+	logger.Printf("calling Webex with token %s", "writer-secret-value")
+
+	if got := buf.String(); got != "calling Webex with token [REDACTED]\n" {
+		t.Errorf("log output = %q", got)
+	}
+}
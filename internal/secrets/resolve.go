@@ -0,0 +1,73 @@
+package secrets
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Reference prefixes understood by Resolve
+const (
+	filePrefix    = "file:"
+	keyringPrefix = "keyring:"
+	storePrefix   = "secret:"
+)
+
+// StoreOpener opens the encrypted secrets store on first use
+type StoreOpener func() (*Store, error)
+
+// Resolve expands a secret reference; any other value is returned unchanged.
+//
+//	file:/run/secrets/webex_token   contents of a file
+//	keyring:webex-token             OS keyring entry under KeyringService
+//	secret:webex_token              entry in the encrypted secrets file
+func Resolve(value string, openStore StoreOpener) (string, error) {
+	switch {
+	case strings.HasPrefix(value, filePrefix):
+		return ReadFile(strings.TrimPrefix(value, filePrefix))
+	case strings.HasPrefix(value, keyringPrefix):
+		return keyringLookup(strings.TrimPrefix(value, keyringPrefix))
+	case strings.HasPrefix(value, storePrefix):
+		name := strings.TrimPrefix(value, storePrefix)
+		if openStore == nil {
+			return "", fmt.Errorf("secret %q referenced but no secrets file is configured", name)
+		}
+		store, err := openStore()
+		if err != nil {
+			return "", err
+		}
+		secret, ok := store.Get(name)
+		if !ok {
+			return "", fmt.Errorf("secret %q not found in secrets file", name)
+		}
+		return secret, nil
+	default:
+		return value, nil
+	}
+}
+
+// ReadFile reads a secret from a file, trimming the trailing newline that
+// Docker and Kubernetes secret files usually carry
+func ReadFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret file: %w", err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// Env returns the value of key, or the contents of the file named by key_FILE
+// when key itself is unset. This is the convention used by Docker and Kubernetes secrets.
+func Env(key string) (string, error) {
+	if value := os.Getenv(key); value != "" {
+		return value, nil
+	}
+	if path := os.Getenv(key + "_FILE"); path != "" {
+		value, err := ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("%s_FILE: %w", key, err)
+		}
+		return value, nil
+	}
+	return "", nil
+}
@@ -0,0 +1,87 @@
+package secrets
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/raja-aiml/webex-mcp-server/internal/testutil"
+)
+
+func TestResolve(t *testing.T) {
+	dir := t.TempDir()
+	tokenFile := filepath.Join(dir, "token")
+	if err := os.WriteFile(tokenFile, []byte("from-file\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := OpenStore(filepath.Join(dir, "secrets.json"), "pass")
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.Set("webex_token", "from-store")
+	openStore := func() (*Store, error) { return store, nil }
+
+	original := keyringLookup
+	keyringLookup = func(name string) (string, error) {
+		if name == "webex" {
+			return "from-keyring", nil
+		}
+		return "", fmt.Errorf("keyring entry %q not found", name)
+	}
+	defer func() { keyringLookup = original }()
+
+	tests := []struct {
+		name      string
+		value     string
+		openStore StoreOpener
+		want      string
+		wantErr   bool
+	}{
+		{name: "plain value", value: "plain-token", want: "plain-token"},
+		{name: "file", value: "file:" + tokenFile, want: "from-file"},
+		{name: "missing file", value: "file:" + filepath.Join(dir, "missing"), wantErr: true},
+		{name: "keyring", value: "keyring:webex", want: "from-keyring"},
+		{name: "missing keyring entry", value: "keyring:other", wantErr: true},
+		{name: "secrets file", value: "secret:webex_token", openStore: openStore, want: "from-store"},
+		{name: "missing secret", value: "secret:other", openStore: openStore, wantErr: true},
+		{name: "no secrets file", value: "secret:webex_token", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Resolve(tt.value, tt.openStore)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Resolve() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Resolve() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnv(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "key")
+	if err := os.WriteFile(path, []byte("file-value\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	defer testutil.SetEnv(t, "SECRETS_TEST_KEY", "")()
+	defer testutil.SetEnv(t, "SECRETS_TEST_KEY_FILE", path)()
+	if got, err := Env("SECRETS_TEST_KEY"); err != nil || got != "file-value" {
+		t.Errorf("Env() with _FILE = %q, %v; want file-value", got, err)
+	}
+
+	os.Setenv("SECRETS_TEST_KEY", "direct-value")
+	if got, err := Env("SECRETS_TEST_KEY"); err != nil || got != "direct-value" {
+		t.Errorf("Env() with value = %q, %v; want direct-value", got, err)
+	}
+
+	os.Setenv("SECRETS_TEST_KEY", "")
+	os.Setenv("SECRETS_TEST_KEY_FILE", path+".missing")
+	if _, err := Env("SECRETS_TEST_KEY"); err == nil {
+		t.Error("Env() with missing _FILE expected error, got nil")
+	}
+}
@@ -0,0 +1,177 @@
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+const (
+	storeVersion = 1
+	kdfName      = "pbkdf2-sha256"
+	// kdfIterations follows the OWASP recommendation for PBKDF2-HMAC-SHA256
+	kdfIterations = 600000
+	keyLength     = 32
+	saltLength    = 16
+)
+
+// ErrWrongPassphrase is returned when a store cannot be decrypted
+var ErrWrongPassphrase = errors.New("secrets file could not be decrypted: wrong passphrase or corrupted file")
+
+// Store is a local file of named secrets encrypted with a passphrase.
+// The file holds a JSON envelope; secret values never touch disk unencrypted.
+type Store struct {
+	path       string
+	passphrase string
+	values     map[string]string
+}
+
+// envelope is the on-disk representation of a Store
+type envelope struct {
+	Version    int    `json:"version"`
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// OpenStore decrypts the store at path. A missing file yields an empty store that Save will create.
+func OpenStore(path, passphrase string) (*Store, error) {
+	if passphrase == "" {
+		return nil, fmt.Errorf("a passphrase is required to open the secrets file")
+	}
+	store := &Store{path: path, passphrase: passphrase, values: make(map[string]string)}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return store, nil
+		}
+		return nil, fmt.Errorf("failed to read secrets file: %w", err)
+	}
+
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, fmt.Errorf("failed to parse secrets file %s: %w", path, err)
+	}
+	if env.Version != storeVersion || env.KDF != kdfName {
+		return nil, fmt.Errorf("unsupported secrets file format (version %d, kdf %q)", env.Version, env.KDF)
+	}
+
+	gcm, err := newGCM(passphrase, env.Salt, env.Iterations)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := gcm.Open(nil, env.Nonce, env.Ciphertext, nil)
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+	if err := json.Unmarshal(plaintext, &store.values); err != nil {
+		return nil, fmt.Errorf("failed to decode secrets: %w", err)
+	}
+	return store, nil
+}
+
+// Get returns the named secret
+func (s *Store) Get(name string) (string, bool) {
+	value, ok := s.values[name]
+	return value, ok
+}
+
+// Set adds or replaces a secret; call Save to persist it
+func (s *Store) Set(name, value string) {
+	s.values[name] = value
+}
+
+// Delete removes a secret; call Save to persist the removal
+func (s *Store) Delete(name string) bool {
+	_, ok := s.values[name]
+	delete(s.values, name)
+	return ok
+}
+
+// Names returns the stored secret names, sorted
+func (s *Store) Names() []string {
+	names := make([]string, 0, len(s.values))
+	for name := range s.values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Save encrypts the store with a fresh salt and nonce and writes it atomically with mode 0600
+func (s *Store) Save() error {
+	plaintext, err := json.Marshal(s.values)
+	if err != nil {
+		return err
+	}
+
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return err
+	}
+	gcm, err := newGCM(s.passphrase, salt, kdfIterations)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(envelope{
+		Version:    storeVersion,
+		KDF:        kdfName,
+		Iterations: kdfIterations,
+		Salt:       salt,
+		Nonce:      nonce,
+		Ciphertext: gcm.Seal(nil, nonce, plaintext, nil),
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), ".secrets-*")
+	if err != nil {
+		return fmt.Errorf("failed to write secrets file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write secrets file: %w", err)
+	}
+	if err := tmp.Chmod(0600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+// newGCM derives the AES-256-GCM cipher for a passphrase and salt
+func newGCM(passphrase string, salt []byte, iterations int) (cipher.AEAD, error) {
+	if len(salt) == 0 || iterations <= 0 {
+		return nil, fmt.Errorf("secrets file is missing key derivation parameters")
+	}
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, iterations, keyLength)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,84 @@
+package secrets
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestStore_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secrets.json")
+
+	store, err := OpenStore(path, "correct horse")
+	if err != nil {
+		t.Fatalf("OpenStore() on missing file error = %v", err)
+	}
+	if len(store.Names()) != 0 {
+		t.Errorf("new store Names() = %v, want empty", store.Names())
+	}
+
+	store.Set("webex_token", "super-secret-token")
+	store.Set("other", "value")
+	if err := store.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("file mode = %v, want 0600", info.Mode().Perm())
+	}
+	data, _ := os.ReadFile(path)
+	if strings.Contains(string(data), "super-secret-token") {
+		t.Error("secrets file contains the plaintext value")
+	}
+
+	reopened, err := OpenStore(path, "correct horse")
+	if err != nil {
+		t.Fatalf("OpenStore() error = %v", err)
+	}
+	if got, ok := reopened.Get("webex_token"); !ok || got != "super-secret-token" {
+		t.Errorf("Get() = %q, %v; want super-secret-token, true", got, ok)
+	}
+	if got := strings.Join(reopened.Names(), ","); got != "other,webex_token" {
+		t.Errorf("Names() = %s, want other,webex_token", got)
+	}
+
+	if _, err := OpenStore(path, "wrong"); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("OpenStore() with wrong passphrase error = %v, want ErrWrongPassphrase", err)
+	}
+}
+
+func TestOpenStore_Errors(t *testing.T) {
+	dir := t.TempDir()
+	garbage := filepath.Join(dir, "garbage.json")
+	if err := os.WriteFile(garbage, []byte("not json"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	unsupported := filepath.Join(dir, "unsupported.json")
+	if err := os.WriteFile(unsupported, []byte(`{"version":9,"kdf":"scrypt"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		path       string
+		passphrase string
+	}{
+		{name: "empty passphrase", path: filepath.Join(dir, "missing.json"), passphrase: ""},
+		{name: "invalid JSON", path: garbage, passphrase: "pass"},
+		{name: "unsupported format", path: unsupported, passphrase: "pass"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := OpenStore(tt.path, tt.passphrase); err == nil {
+				t.Error("OpenStore() expected error, got nil")
+			}
+		})
+	}
+}
@@ -3,7 +3,9 @@ package server
 import (
 	"fmt"
 	"log"
+	"maps"
 	"os"
+	"sync"
 
 	"github.com/joho/godotenv"
 	"github.com/raja-aiml/webex-mcp-server/internal/config"
@@ -11,22 +13,8 @@ import (
 
 // InitializeConfig loads environment variables and validates configuration
 func InitializeConfig(envPath string) error {
-	// Load environment variables from specified path or default
-	if envPath != "" {
-		if err := godotenv.Load(envPath); err != nil {
-			// Don't fail if .env file is missing - can use system env vars
-			if !os.IsNotExist(err) {
-				return fmt.Errorf("error loading .env file from %s: %w", envPath, err)
-			}
-		}
-	} else {
-		// Try loading from default location
-		if err := godotenv.Load(); err != nil {
-			// Log warning but don't fail
-			if !os.IsNotExist(err) {
-				log.Printf("Warning: error loading .env file: %v", err)
-			}
-		}
+	if err := LoadEnvFile(envPath); err != nil {
+		return err
 	}
 
 	// Validate configuration
@@ -36,3 +24,113 @@ func InitializeConfig(envPath string) error {
 
 	return nil
 }
+
+// envFileKeys records variables whose values came from the .env file rather than the
+// process environment, so a reload can update them without overriding real environment values.
+var (
+	envFileMu   sync.Mutex
+	envFileKeys = map[string]bool{}
+)
+
+// LoadEnvFile loads environment variables from the .env file at envPath,
+// or from the current directory when envPath is empty. A missing file is not an error.
+// Variables already present in the process environment are left untouched.
+func LoadEnvFile(envPath string) error {
+	values, err := readEnvFile(envPath)
+	if err != nil {
+		if envPath != "" {
+			return fmt.Errorf("error loading .env file from %s: %w", envPath, err)
+		}
+		// Log warning but don't fail
+		log.Printf("Warning: error loading .env file: %v", err)
+		return nil
+	}
+
+	envFileMu.Lock()
+	defer envFileMu.Unlock()
+	for key, value := range values {
+		if _, exists := os.LookupEnv(key); exists && !envFileKeys[key] {
+			continue
+		}
+		os.Setenv(key, value)
+		envFileKeys[key] = true
+	}
+	return nil
+}
+
+// ReloadEnvFile re-reads the .env file, updating and removing the variables it
+// previously provided. Variables set by the real process environment still win.
+// The returned function puts back the variables as they were before the reload,
+// for when the configuration they make is rejected.
+func ReloadEnvFile(envPath string) (func(), error) {
+	values, err := readEnvFile(envPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reloading .env file: %w", err)
+	}
+
+	envFileMu.Lock()
+	defer envFileMu.Unlock()
+	previous := map[string]*string{}
+	previousKeys := maps.Clone(envFileKeys)
+	remember := func(key string) {
+		if _, ok := previous[key]; ok {
+			return
+		}
+		if value, ok := os.LookupEnv(key); ok {
+			previous[key] = &value
+		} else {
+			previous[key] = nil
+		}
+	}
+
+	for key := range envFileKeys {
+		if _, ok := values[key]; !ok {
+			remember(key)
+			os.Unsetenv(key)
+			delete(envFileKeys, key)
+		}
+	}
+	for key, value := range values {
+		if _, exists := os.LookupEnv(key); exists && !envFileKeys[key] {
+			continue
+		}
+		remember(key)
+		os.Setenv(key, value)
+		envFileKeys[key] = true
+	}
+
+	restore := func() {
+		envFileMu.Lock()
+		defer envFileMu.Unlock()
+		for key, value := range previous {
+			if value == nil {
+				os.Unsetenv(key)
+			} else {
+				os.Setenv(key, *value)
+			}
+		}
+		envFileKeys = previousKeys
+	}
+	return restore, nil
+}
+
+// EnvFilePath returns the .env path that LoadEnvFile reads for envPath
+func EnvFilePath(envPath string) string {
+	if envPath == "" {
+		return ".env"
+	}
+	return envPath
+}
+
+// readEnvFile parses the .env file, treating a missing file as empty
+func readEnvFile(envPath string) (map[string]string, error) {
+	values, err := godotenv.Read(EnvFilePath(envPath))
+	if err != nil {
+		// Don't fail if .env file is missing - can use system env vars
+		if os.IsNotExist(err) {
+			return map[string]string{}, nil
+		}
+		return nil, err
+	}
+	return values, nil
+}
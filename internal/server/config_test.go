@@ -2,6 +2,7 @@ package server
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/raja-aiml/webex-mcp-server/internal/config"
@@ -83,3 +84,64 @@ func TestInitializeConfig_LoadsEnvFile(t *testing.T) {
 	// Cleanup
 	os.Unsetenv("TEST_ENV_VAR")
 }
+
+func TestReloadEnvFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	write := func(content string) {
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cleanupReal := testutil.SetEnv(t, "WEBEX_TEST_REAL", "from-process")
+	defer cleanupReal()
+	defer os.Unsetenv("WEBEX_TEST_FILE")
+	defer os.Unsetenv("WEBEX_TEST_REMOVED")
+
+	write("WEBEX_TEST_FILE=one\nWEBEX_TEST_REMOVED=gone-soon\nWEBEX_TEST_REAL=from-file\n")
+	if err := LoadEnvFile(path); err != nil {
+		t.Fatalf("LoadEnvFile() error = %v", err)
+	}
+	if got := os.Getenv("WEBEX_TEST_FILE"); got != "one" {
+		t.Errorf("WEBEX_TEST_FILE = %q, want one", got)
+	}
+	if got := os.Getenv("WEBEX_TEST_REAL"); got != "from-process" {
+		t.Errorf("WEBEX_TEST_REAL = %q, process environment must win", got)
+	}
+
+	write("WEBEX_TEST_FILE=two\nWEBEX_TEST_REAL=from-file\n")
+	restore, err := ReloadEnvFile(path)
+	if err != nil {
+		t.Fatalf("ReloadEnvFile() error = %v", err)
+	}
+	if got := os.Getenv("WEBEX_TEST_FILE"); got != "two" {
+		t.Errorf("WEBEX_TEST_FILE after reload = %q, want two", got)
+	}
+	if _, exists := os.LookupEnv("WEBEX_TEST_REMOVED"); exists {
+		t.Error("WEBEX_TEST_REMOVED should be unset after it was removed from the file")
+	}
+	if got := os.Getenv("WEBEX_TEST_REAL"); got != "from-process" {
+		t.Errorf("WEBEX_TEST_REAL after reload = %q, process environment must win", got)
+	}
+
+	// Restoring undoes the reload, so a rejected configuration leaves nothing behind
+	restore()
+	if got := os.Getenv("WEBEX_TEST_FILE"); got != "one" {
+		t.Errorf("WEBEX_TEST_FILE after restore = %q, want one", got)
+	}
+	if got := os.Getenv("WEBEX_TEST_REMOVED"); got != "gone-soon" {
+		t.Errorf("WEBEX_TEST_REMOVED after restore = %q, want gone-soon", got)
+	}
+	if got := os.Getenv("WEBEX_TEST_REAL"); got != "from-process" {
+		t.Errorf("WEBEX_TEST_REAL after restore = %q, want from-process", got)
+	}
+
+	// The restored variables still count as coming from the file
+	write("WEBEX_TEST_REAL=from-file\n")
+	if _, err := ReloadEnvFile(path); err != nil {
+		t.Fatalf("ReloadEnvFile() error = %v", err)
+	}
+	if _, exists := os.LookupEnv("WEBEX_TEST_FILE"); exists {
+		t.Error("WEBEX_TEST_FILE should be unset after it was removed from the file")
+	}
+}
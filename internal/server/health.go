@@ -0,0 +1,171 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/raja-aiml/webex-mcp-server/internal/config"
+	"github.com/raja-aiml/webex-mcp-server/internal/handlers"
+	"github.com/raja-aiml/webex-mcp-server/internal/webex"
+)
+
+// TokenCheckTTL is how long the result of a token check is reused
+const TokenCheckTTL = time.Minute
+
+// TokenCheckTimeout bounds how long a probe waits for a token check; a slower
+// check keeps running and its result serves the probes after it
+const TokenCheckTimeout = 5 * time.Second
+
+// TokenStatus is the result of checking the Webex token with GET /people/me
+type TokenStatus struct {
+	Valid       bool      `json:"valid"`
+	DisplayName string    `json:"displayName,omitempty"`
+	Email       string    `json:"email,omitempty"`
+	Type        string    `json:"type,omitempty"` // person or bot
+	OrgID       string    `json:"orgId,omitempty"`
+	Error       string    `json:"error,omitempty"`
+	CheckedAt   time.Time `json:"checkedAt"`
+}
+
+// Readiness decides whether the server can serve tool calls: the configuration
+// must load, the Webex token must be accepted and at least one tool registered
+type Readiness struct {
+	toolSet *ToolSet
+
+	now       func() time.Time
+	newClient func(*config.Config) (webex.HTTPClient, error)
+
+	timeout time.Duration
+
+	mu          sync.Mutex // Never held across a request
+	token       *TokenStatus
+	tokenValue  string        // Token the cached status belongs to
+	checking    chan struct{} // Closed when the running check finishes, so probes share one request
+	checkingFor string        // Token the running check is for
+}
+
+// NewReadiness creates a readiness check for the tools in toolSet
+func NewReadiness(toolSet *ToolSet) *Readiness {
+	return &Readiness{
+		toolSet:   toolSet,
+		now:       time.Now,
+		newClient: webex.NewClientWithConfig,
+		timeout:   TokenCheckTimeout,
+	}
+}
+
+// Check runs the readiness checks; it implements handlers.ReadinessFunc
+func (r *Readiness) Check(ctx context.Context) handlers.Readiness {
+	readiness := handlers.Readiness{Checks: map[string]interface{}{}}
+
+	cfg, err := config.Load()
+	if err != nil {
+		readiness.Reasons = append(readiness.Reasons, fmt.Sprintf("configuration: %v", err))
+	} else {
+		token := r.checkToken(ctx, cfg)
+		readiness.Checks["token"] = token
+		if !token.Valid {
+			readiness.Reasons = append(readiness.Reasons, "webex token: "+token.Error)
+		}
+	}
+
+	toolCount := 0
+	if r.toolSet != nil {
+		toolCount = len(r.toolSet.Names())
+	}
+	readiness.Checks["tools"] = toolCount
+	if toolCount == 0 {
+		readiness.Reasons = append(readiness.Reasons, "no tools are registered")
+	}
+
+	readiness.Ready = len(readiness.Reasons) == 0
+	return readiness
+}
+
+// checkToken returns the cached token status, refreshing it when stale or when the
+// token changed. A refresh that takes longer than the timeout or the probe's own
+// deadline is left running, and the stale status is served meanwhile if there is one.
+func (r *Readiness) checkToken(ctx context.Context, cfg *config.Config) TokenStatus {
+	r.mu.Lock()
+	now := r.now()
+	if r.token != nil && r.tokenValue == cfg.WebexAPIKey && now.Sub(r.token.CheckedAt) < TokenCheckTTL {
+		status := *r.token
+		r.mu.Unlock()
+		return status
+	}
+	if r.checking == nil || r.checkingFor != cfg.WebexAPIKey {
+		r.checking, r.checkingFor = make(chan struct{}), cfg.WebexAPIKey
+		go r.refreshToken(cfg, now, r.checking)
+	}
+	done := r.checking
+	r.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.token != nil && r.tokenValue == cfg.WebexAPIKey {
+		return *r.token
+	}
+	return TokenStatus{Error: fmt.Sprintf("Webex did not answer GET /people/me within %s", r.timeout), CheckedAt: now}
+}
+
+// refreshToken checks the token with GET /people/me and caches the result, unless
+// the token changed while it ran
+func (r *Readiness) refreshToken(cfg *config.Config, now time.Time, done chan struct{}) {
+	defer close(done)
+
+	status := TokenStatus{CheckedAt: now}
+	client, err := r.newClient(cfg)
+	if err == nil {
+		var me map[string]interface{}
+		me, err = client.Get("/people/me", nil)
+		if err == nil {
+			status.Valid = true
+			status.DisplayName, _ = me["displayName"].(string)
+			status.Type, _ = me["type"].(string)
+			status.OrgID, _ = me["orgId"].(string)
+			if emails, ok := me["emails"].([]interface{}); ok && len(emails) > 0 {
+				status.Email, _ = emails[0].(string)
+			}
+		}
+	}
+	if err != nil {
+		status.Error = describeTokenError(err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.checking == done {
+		r.checking = nil
+	}
+	if r.checkingFor != cfg.WebexAPIKey {
+		return
+	}
+	r.token = &status
+	r.tokenValue = cfg.WebexAPIKey
+}
+
+// describeTokenError explains a failed token check; Webex reports neither scopes nor
+// expiry for a token, so they are inferred from the status code
+func describeTokenError(err error) string {
+	var apiErr *webex.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusUnauthorized:
+			return "rejected by Webex (expired, revoked or malformed)"
+		case http.StatusForbidden:
+			return "missing the scope needed to read /people/me (spark:people_read)"
+		}
+	}
+	return err.Error()
+}
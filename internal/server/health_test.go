@@ -0,0 +1,130 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/raja-aiml/webex-mcp-server/internal/config"
+	"github.com/raja-aiml/webex-mcp-server/internal/testutil"
+	"github.com/raja-aiml/webex-mcp-server/internal/tools"
+)
+
+func TestReadiness_Check(t *testing.T) {
+	status := http.StatusOK
+	requests := 0
+	webexServer := testutil.MockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/people/me" {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+		if status != http.StatusOK {
+			testutil.JSONResponse(w, status, map[string]interface{}{"message": "denied"})
+			return
+		}
+		testutil.JSONResponse(w, status, map[string]interface{}{
+			"displayName": "Test Bot",
+			"emails":      []string{"bot@webex.bot"},
+			"type":        "bot",
+		})
+	})
+	defer webexServer.Close()
+
+	cleanups := []func(){
+		testutil.SetEnv(t, "WEBEX_PUBLIC_WORKSPACE_API_KEY", "token"),
+		testutil.SetEnv(t, "WEBEX_API_BASE_URL", webexServer.URL),
+	}
+	defer func() {
+		for _, cleanup := range cleanups {
+			cleanup()
+		}
+		config.ResetForTesting()
+	}()
+	config.ResetForTesting()
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test-server", Version: "1.0.0"}, &mcp.ServerOptions{HasTools: true})
+	registry := tools.NewRegistry()
+	registry.Register(&mockTool{name: "some_tool", description: "A tool", schemaType: "jsonschema"})
+	toolSet := registerTools(server, registry)
+
+	readiness := NewReadiness(toolSet)
+	now := time.Now()
+	readiness.now = func() time.Time { return now }
+
+	got := readiness.Check(context.Background())
+	if !got.Ready {
+		t.Fatalf("Check() = %+v, want ready", got)
+	}
+	token := got.Checks["token"].(TokenStatus)
+	if token.DisplayName != "Test Bot" || token.Email != "bot@webex.bot" || token.Type != "bot" {
+		t.Errorf("token status = %+v", token)
+	}
+	if got.Checks["tools"] != 1 {
+		t.Errorf("tools check = %v, want 1", got.Checks["tools"])
+	}
+
+	// A revoked token is noticed only once the cached result expires
+	status = http.StatusUnauthorized
+	if got := readiness.Check(context.Background()); !got.Ready || requests != 1 {
+		t.Errorf("Check() within TTL = %+v after %d requests, want the cached result", got, requests)
+	}
+	now = now.Add(TokenCheckTTL)
+	got = readiness.Check(context.Background())
+	if got.Ready || len(got.Reasons) != 1 || !strings.Contains(got.Reasons[0], "expired, revoked") {
+		t.Errorf("Check() with a rejected token = %+v", got)
+	}
+
+	// Removing every tool makes the server unready as well
+	toolSet.Apply(tools.NewRegistry())
+	status = http.StatusForbidden
+	now = now.Add(TokenCheckTTL)
+	got = readiness.Check(context.Background())
+	if got.Ready || len(got.Reasons) != 2 || !strings.Contains(got.Reasons[0], "spark:people_read") || got.Reasons[1] != "no tools are registered" {
+		t.Errorf("Check() = %+v, want missing scope and no tools", got)
+	}
+}
+
+func TestReadiness_SlowTokenCheck(t *testing.T) {
+	release := make(chan struct{})
+	webexServer := testutil.MockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		testutil.JSONResponse(w, http.StatusOK, map[string]interface{}{"displayName": "Test Bot"})
+	})
+	defer webexServer.Close()
+
+	cleanups := []func(){
+		testutil.SetEnv(t, "WEBEX_PUBLIC_WORKSPACE_API_KEY", "token"),
+		testutil.SetEnv(t, "WEBEX_API_BASE_URL", webexServer.URL),
+	}
+	defer func() {
+		for _, cleanup := range cleanups {
+			cleanup()
+		}
+		config.ResetForTesting()
+	}()
+	config.ResetForTesting()
+
+	readiness := NewReadiness(nil)
+	readiness.timeout = 20 * time.Millisecond
+
+	// A probe gives up on a slow check instead of waiting for Webex
+	got := readiness.Check(context.Background())
+	token := got.Checks["token"].(TokenStatus)
+	if token.Valid || !strings.Contains(token.Error, "did not answer") {
+		t.Errorf("token status = %+v, want a timeout", token)
+	}
+
+	// The check keeps running and its result serves later probes
+	close(release)
+	deadline := time.Now().Add(time.Second)
+	for !token.Valid && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+		token = readiness.Check(context.Background()).Checks["token"].(TokenStatus)
+	}
+	if !token.Valid || token.DisplayName != "Test Bot" {
+		t.Errorf("token status = %+v, want the finished check", token)
+	}
+}
@@ -0,0 +1,129 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/auth"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/raja-aiml/webex-mcp-server/internal/config"
+	"github.com/raja-aiml/webex-mcp-server/internal/ratelimit"
+	"github.com/raja-aiml/webex-mcp-server/internal/webex"
+)
+
+// clientNameKey is the TokenInfo.Extra key holding the authenticated client name
+const clientNameKey = "client"
+
+// callLimits holds the token buckets applied to tool calls
+type callLimits struct {
+	config    config.LimitsConfig
+	perClient *ratelimit.Limiter
+	perTool   *ratelimit.Limiter            // Shared by tools without an override, keyed by tool name
+	overrides map[string]*ratelimit.Limiter // Tools with their own rate
+}
+
+func newCallLimits(cfg config.LimitsConfig) *callLimits {
+	limits := &callLimits{
+		config:    cfg,
+		perClient: ratelimit.NewLimiter(cfg.PerClient),
+		perTool:   ratelimit.NewLimiter(cfg.PerTool),
+		overrides: make(map[string]*ratelimit.Limiter, len(cfg.Tools)),
+	}
+	for name, rate := range cfg.Tools {
+		limits.overrides[name] = ratelimit.NewLimiter(rate)
+	}
+	return limits
+}
+
+// allow checks the client and tool buckets and describes the limit that was hit
+func (l *callLimits) allow(client, tool string) (string, time.Duration, bool) {
+	if l == nil {
+		return "", 0, true
+	}
+	if ok, wait := l.perClient.Allow(client); !ok {
+		return "client " + client, wait, false
+	}
+	limiter := l.perTool
+	if override, ok := l.overrides[tool]; ok {
+		limiter = override
+	}
+	if ok, wait := limiter.Allow(tool); !ok {
+		return "tool " + tool, wait, false
+	}
+	return "", 0, true
+}
+
+// SetLimits applies per-client and per-tool rate limits to tool calls and caps
+// concurrent Webex requests. Buckets and request slots are kept when the limits
+// are unchanged, so a reload does not hand every client a fresh burst.
+func (ts *ToolSet) SetLimits(cfg config.LimitsConfig) {
+	webex.SetMaxInFlight(cfg.MaxInFlight, cfg.MaxWait)
+
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	if ts.limits != nil && reflect.DeepEqual(ts.limits.config, cfg) {
+		return
+	}
+	ts.limits = newCallLimits(cfg)
+}
+
+// clientID identifies the caller for per-client limits: the authenticated client
+// name when HTTP clients are configured, otherwise the MCP session
+func clientID(request *mcp.CallToolRequest) string {
+	if request.Extra != nil && request.Extra.TokenInfo != nil {
+		if name, ok := request.Extra.TokenInfo.Extra[clientNameKey].(string); ok {
+			return name
+		}
+	}
+	if request.Session != nil && request.Session.ID() != "" {
+		return "session " + request.Session.ID()
+	}
+	return "anonymous"
+}
+
+// rateLimitedResult is the tool error returned when a limit is exceeded.
+// The retry hint is repeated in _meta.retryAfterSeconds for clients that back off automatically.
+func rateLimitedResult(scope string, retryAfter time.Duration) *mcp.CallToolResult {
+	seconds := math.Max(1, math.Ceil(retryAfter.Seconds()))
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{
+			Text: fmt.Sprintf("Rate limit exceeded for %s. Retry after %.0f seconds.", scope, seconds),
+		}},
+		IsError: true,
+		Meta:    mcp.Meta{"retryAfterSeconds": seconds},
+	}
+}
+
+// clientAuth requires a configured client bearer token on MCP requests when clients
+// are configured. It reads the current configuration so reloads take effect immediately.
+func clientAuth(next http.Handler) http.Handler {
+	protected := auth.RequireBearerToken(verifyClientToken, nil)(next)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cfg, err := config.Load()
+		if err != nil || len(cfg.Clients) == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+		protected.ServeHTTP(w, r)
+	})
+}
+
+func verifyClientToken(ctx context.Context, token string) (*auth.TokenInfo, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	name, ok := cfg.ClientByToken(token)
+	if !ok {
+		return nil, auth.ErrInvalidToken
+	}
+	return &auth.TokenInfo{
+		// Static tokens do not expire; the SDK requires an expiration
+		Expiration: time.Now().Add(time.Hour),
+		Extra:      map[string]any{clientNameKey: name},
+	}, nil
+}
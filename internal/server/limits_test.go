@@ -0,0 +1,157 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/raja-aiml/webex-mcp-server/internal/config"
+	"github.com/raja-aiml/webex-mcp-server/internal/handlers"
+	"github.com/raja-aiml/webex-mcp-server/internal/ratelimit"
+	"github.com/raja-aiml/webex-mcp-server/internal/testutil"
+	"github.com/raja-aiml/webex-mcp-server/internal/tools"
+	"github.com/raja-aiml/webex-mcp-server/internal/webex"
+)
+
+func newLimitedServer(t *testing.T, limits config.LimitsConfig) *mcp.Server {
+	t.Helper()
+	server := mcp.NewServer(&mcp.Implementation{Name: "test-server", Version: "1.0.0"}, &mcp.ServerOptions{HasTools: true})
+	registry := tools.NewRegistry()
+	registry.Register(&mockTool{name: "slow_tool", description: "Limited", schemaType: "jsonschema", executeResp: "ok"})
+	registry.Register(&mockTool{name: "fast_tool", description: "Less limited", schemaType: "jsonschema", executeResp: "ok"})
+	toolSet := registerTools(server, registry)
+	toolSet.SetLimits(limits)
+	t.Cleanup(func() { webex.SetMaxInFlight(0, 0) })
+	return server
+}
+
+func callTool(t *testing.T, session *mcp.ClientSession, name string) *mcp.CallToolResult {
+	t.Helper()
+	result, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: name, Arguments: map[string]any{}})
+	if err != nil {
+		t.Fatalf("CallTool(%s) error = %v", name, err)
+	}
+	return result
+}
+
+func TestToolSet_RateLimits(t *testing.T) {
+	server := newLimitedServer(t, config.LimitsConfig{
+		PerTool: ratelimit.Rate{PerSecond: 0.01, Burst: 3},
+		Tools:   map[string]ratelimit.Rate{"slow_tool": {PerSecond: 0.01, Burst: 1}},
+	})
+	session, _ := connectTestClient(t, server)
+
+	if result := callTool(t, session, "slow_tool"); result.IsError {
+		t.Fatal("first call was rate limited")
+	}
+	result := callTool(t, session, "slow_tool")
+	if !result.IsError {
+		t.Fatal("second call to slow_tool was not rate limited")
+	}
+	text := result.Content[0].(*mcp.TextContent).Text
+	if !strings.Contains(text, "tool slow_tool") || !strings.Contains(text, "Retry after 100 seconds") {
+		t.Errorf("rate limit message = %q", text)
+	}
+	if result.Meta["retryAfterSeconds"] != float64(100) {
+		t.Errorf("_meta.retryAfterSeconds = %v, want 100", result.Meta["retryAfterSeconds"])
+	}
+
+	for i := 0; i < 3; i++ {
+		if result := callTool(t, session, "fast_tool"); result.IsError {
+			t.Fatalf("fast_tool call %d was rate limited", i+1)
+		}
+	}
+}
+
+func TestToolSet_PerClientLimits(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := "auth:\n  token: webex-token\nclients:\n  - name: alice\n    token: alice-token-value\n  - name: bob\n    token: bob-token-value\n"
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	cleanup := testutil.SetEnv(t, "WEBEX_PUBLIC_WORKSPACE_API_KEY", "")
+	defer func() {
+		cleanup()
+		config.ResetForTesting()
+	}()
+	config.SetOptions(config.Options{File: path})
+	if _, err := config.Load(); err != nil {
+		t.Fatalf("config.Load() error = %v", err)
+	}
+
+	server := newLimitedServer(t, config.LimitsConfig{PerClient: ratelimit.Rate{PerSecond: 0.01, Burst: 1}})
+	httpServer := httptest.NewServer(handlers.SetupHTTPHandlers(server, "test", "1.0.0", nil, clientAuth))
+	// Cleanups run last-in first-out, so sessions close before the server
+	t.Cleanup(httpServer.Close)
+
+	resp, err := http.Post(httpServer.URL, "application/json", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("request without a client token: status = %d, want 401", resp.StatusCode)
+	}
+
+	connect := func(token string) *mcp.ClientSession {
+		transport := mcp.NewStreamableClientTransport(httpServer.URL, &mcp.StreamableClientTransportOptions{
+			HTTPClient: &http.Client{Transport: bearerTransport{token: token}, Timeout: 10 * time.Second},
+		})
+		client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, nil)
+		session, err := client.Connect(context.Background(), transport, nil)
+		if err != nil {
+			t.Fatalf("Connect() error = %v", err)
+		}
+		t.Cleanup(func() { session.Close() })
+		return session
+	}
+
+	alice := connect("alice-token-value")
+	if result := callTool(t, alice, "slow_tool"); result.IsError {
+		t.Fatal("alice's first call was rate limited")
+	}
+	result := callTool(t, alice, "fast_tool")
+	if !result.IsError || !strings.Contains(result.Content[0].(*mcp.TextContent).Text, "client alice") {
+		t.Errorf("alice's second call = %+v, want client rate limit", result.Content[0])
+	}
+
+	// A second session of the same client shares its bucket, other clients do not
+	if result := callTool(t, connect("alice-token-value"), "fast_tool"); !result.IsError {
+		t.Error("new alice session got a fresh bucket")
+	}
+	if result := callTool(t, connect("bob-token-value"), "fast_tool"); result.IsError {
+		t.Error("bob was limited by alice's calls")
+	}
+}
+
+func TestCreateToolHandler_BusyWebex(t *testing.T) {
+	tool := &mockTool{name: "busy_tool", executeErr: fmt.Errorf("list failed: %w", &webex.BusyError{RetryAfter: 2 * time.Second})}
+
+	result, err := createToolHandler(tool)(context.Background(), &mcp.CallToolRequest{
+		Params: &mcp.CallToolParams{Name: tool.name, Arguments: map[string]any{}},
+	})
+	if err != nil {
+		t.Fatalf("handler() error = %v", err)
+	}
+	if !result.IsError || result.Meta["retryAfterSeconds"] != float64(2) {
+		t.Errorf("result = %+v, want rate limit error with a 2s retry hint", result)
+	}
+}
+
+// bearerTransport adds a client token to every request
+type bearerTransport struct {
+	token string
+}
+
+func (b bearerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+b.token)
+	return http.DefaultTransport.RoundTrip(req)
+}
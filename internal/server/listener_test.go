@@ -0,0 +1,245 @@
+package server
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/raja-aiml/webex-mcp-server/internal/config"
+)
+
+// testCert is a certificate and key written as PEM files
+type testCert struct {
+	certFile, keyFile string
+	cert              *x509.Certificate
+	key               *ecdsa.PrivateKey
+}
+
+// writeTestCert creates a certificate signed by parent, or a self-signed CA when parent is nil
+func writeTestCert(t *testing.T, dir, name string, parent *testCert, isClient bool) *testCert {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	serial, _ := rand.Int(rand.Reader, big.NewInt(1<<62))
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	if isClient {
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	}
+
+	signer, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.ExtKeyUsage = nil
+		template.BasicConstraintsValid = true
+		template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tc := &testCert{
+		certFile: filepath.Join(dir, name+".pem"),
+		keyFile:  filepath.Join(dir, name+"-key.pem"),
+		cert:     cert,
+		key:      key,
+	}
+	if err := os.WriteFile(tc.certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(tc.keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return tc
+}
+
+func TestCertReloader(t *testing.T) {
+	dir := t.TempDir()
+	first := writeTestCert(t, dir, "server", nil, false)
+
+	reloader, err := newCertReloader(first.certFile, first.keyFile)
+	if err != nil {
+		t.Fatalf("newCertReloader() error = %v", err)
+	}
+	cert, _ := reloader.GetCertificate(nil)
+	if cert.Leaf.SerialNumber.Cmp(first.cert.SerialNumber) != 0 {
+		t.Fatal("GetCertificate() did not return the initial certificate")
+	}
+
+	// Rotate the certificate in place, with a later modification time
+	second := writeTestCert(t, dir, "server", nil, false)
+	later := time.Now().Add(time.Minute)
+	os.Chtimes(second.certFile, later, later)
+	os.Chtimes(second.keyFile, later, later)
+
+	cert, _ = reloader.GetCertificate(nil)
+	if cert.Leaf.SerialNumber.Cmp(second.cert.SerialNumber) != 0 {
+		t.Error("GetCertificate() did not pick up the rotated certificate")
+	}
+
+	// A broken rotation keeps the previous certificate
+	os.WriteFile(second.certFile, []byte("garbage"), 0600)
+	os.Chtimes(second.certFile, later.Add(time.Minute), later.Add(time.Minute))
+	cert, err = reloader.GetCertificate(nil)
+	if err != nil || cert.Leaf.SerialNumber.Cmp(second.cert.SerialNumber) != 0 {
+		t.Errorf("GetCertificate() after a broken rotation = %v, %v; want the previous certificate", cert, err)
+	}
+}
+
+func TestNewServerTLSConfig_ClientCertificates(t *testing.T) {
+	dir := t.TempDir()
+	ca := writeTestCert(t, dir, "ca", nil, false)
+	serverCert := writeTestCert(t, dir, "server", ca, false)
+	clientCert := writeTestCert(t, dir, "client", ca, true)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	clientPair, err := tls.LoadX509KeyPair(clientCert.certFile, clientCert.keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		clientAuth string
+		sendCert   bool
+		wantErr    bool
+	}{
+		{name: "required and presented", clientAuth: config.ClientAuthRequire, sendCert: true},
+		{name: "required but missing", clientAuth: config.ClientAuthRequire, wantErr: true},
+		{name: "optional and missing", clientAuth: config.ClientAuthOptional},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tlsConfig, err := newServerTLSConfig(config.ServerTLSConfig{
+				CertFile:     serverCert.certFile,
+				KeyFile:      serverCert.keyFile,
+				ClientCAFile: ca.certFile,
+				ClientAuth:   tt.clientAuth,
+			})
+			if err != nil {
+				t.Fatalf("newServerTLSConfig() error = %v", err)
+			}
+
+			listener, err := listen(config.ServerConfig{Addr: "127.0.0.1:0"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			httpServer := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("ok"))
+			})}
+			go httpServer.Serve(tls.NewListener(listener, tlsConfig))
+			defer httpServer.Close()
+
+			clientTLS := &tls.Config{RootCAs: roots}
+			if tt.sendCert {
+				clientTLS.Certificates = []tls.Certificate{clientPair}
+			}
+			client := &http.Client{Transport: &http.Transport{TLSClientConfig: clientTLS}, Timeout: 5 * time.Second}
+			resp, err := client.Get("https://" + listener.Addr().String())
+			if resp != nil {
+				resp.Body.Close()
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GET error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRunHTTPServerWithConfig_UnixSocket(t *testing.T) {
+	// Socket paths are limited to about 100 bytes, so avoid the long test temp dir
+	dir, err := os.MkdirTemp("", "mcp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	socket := filepath.Join(dir, "mcp.sock")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	mcpServer := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	go func() {
+		done <- RunHTTPServerWithConfig(ctx, config.ServerConfig{Addr: "unix://" + socket, SocketMode: "0660"}, mcpServer, nil, "test", "1.0.0")
+	}()
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", socket)
+		},
+	}}
+	var resp *http.Response
+	for i := 0; i < 50; i++ {
+		if resp, err = client.Get("http://unix/health"); err == nil {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatalf("GET /health over the socket error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status = %d, want 200", resp.StatusCode)
+	}
+
+	info, err := os.Stat(socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0660 {
+		t.Errorf("socket permissions = %v, want 0660", info.Mode().Perm())
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("RunHTTPServerWithConfig() error = %v", err)
+	}
+	if _, err := os.Stat(socket); !os.IsNotExist(err) {
+		t.Errorf("socket file not removed on shutdown: %v", err)
+	}
+}
+
+func TestListen_RefusesNonSocketFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "not-a-socket")
+	if err := os.WriteFile(path, []byte("data"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := listen(config.ServerConfig{Addr: "unix://" + path}); err == nil {
+		t.Error("listen() expected error for a regular file, got nil")
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("regular file was removed: %v", err)
+	}
+}
@@ -3,12 +3,16 @@ package server
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 
 	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/raja-aiml/webex-mcp-server/internal/config"
+	"github.com/raja-aiml/webex-mcp-server/internal/secrets"
 	"github.com/raja-aiml/webex-mcp-server/internal/tools"
+	"github.com/raja-aiml/webex-mcp-server/internal/webex"
 )
 
 // CreateMCPServer creates and configures the MCP server with tools
@@ -36,6 +40,24 @@ func CreateMCPServerWithMode(name, version string, useAllTools bool) (*mcp.Serve
 		return nil, fmt.Errorf("failed to load tools: %w", err)
 	}
 
+	server, _ := newMCPServer(name, version, toolRegistry)
+	return server, nil
+}
+
+// CreateMCPServerFromConfig creates the MCP server with the tool selection from cfg.
+// The returned ToolSet applies reloaded registries to the running server.
+func CreateMCPServerFromConfig(name, version string, cfg *config.Config) (*mcp.Server, *ToolSet, error) {
+	toolRegistry, err := tools.LoadConfiguredTools(cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load tools: %w", err)
+	}
+	server, toolSet := newMCPServer(name, version, toolRegistry)
+	toolSet.SetLimits(cfg.Limits)
+	return server, toolSet, nil
+}
+
+// newMCPServer creates the MCP server and registers every tool in the registry
+func newMCPServer(name, version string, toolRegistry *tools.Registry) (*mcp.Server, *ToolSet) {
 	// Create MCP server with proper options
 	server := mcp.NewServer(&mcp.Implementation{
 		Name:    name,
@@ -45,16 +67,20 @@ func CreateMCPServerWithMode(name, version string, useAllTools bool) (*mcp.Serve
 		InitializedHandler: func(ctx context.Context, req *mcp.InitializedRequest) {
 			log.Printf("[%s v%s] MCP session initialized successfully", name, version)
 		},
+		// Tools can be added on reload, so advertise the capability even when none are loaded
+		HasTools: true,
 	})
 
 	// Register all tools with the server
-	registerTools(server, toolRegistry)
+	toolSet := registerTools(server, toolRegistry)
 
 	// Log loaded tools count
 	log.Printf("Loaded %d tools", len(toolRegistry.GetTools()))
 
-	return server, nil
-} // convertToolSchema converts various schema formats to jsonschema.Schema
+	return server, toolSet
+}
+
+// convertToolSchema converts various schema formats to jsonschema.Schema
 func convertToolSchema(tool tools.Tool) (*jsonschema.Schema, error) {
 	schemaInterface := tool.GetInputSchema()
 
@@ -98,75 +124,99 @@ func createToolHandler(tool tools.Tool) mcp.ToolHandler {
 			}, nil
 		}
 
-		// Execute the tool with raw arguments
-		result, err := tool.Execute(argsJSON)
+		return ExecuteToolContext(withProgress(ctx, request), tool, argsJSON), nil
+	}
+}
+
+// withProgress forwards the tool's progress reports as notifications when the client sent a progress token
+func withProgress(ctx context.Context, request *mcp.CallToolRequest) context.Context {
+	if request.Session == nil || request.Params == nil {
+		return ctx
+	}
+	token := request.Params.GetProgressToken()
+	if token == nil {
+		return ctx
+	}
+	return tools.WithProgress(ctx, func(progress, total float64, message string) {
+		err := request.Session.NotifyProgress(ctx, &mcp.ProgressNotificationParams{
+			ProgressToken: token,
+			Progress:      progress,
+			Total:         total,
+			Message:       message,
+		})
 		if err != nil {
-			// Return error as tool result per MCP spec
-			// Be specific about the error type
-			errorMessage := fmt.Sprintf("Tool execution failed: %v", err)
-			if err.Error() == "404 Not Found" {
-				errorMessage = "Resource not found. Please verify the ID or name."
-			} else if err.Error() == "401 Unauthorized" {
-				errorMessage = "Authentication failed. Please check your API credentials."
-			}
+			log.Printf("Failed to send progress for %s: %v", request.Params.Name, err)
+		}
+	})
+}
 
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{
-					&mcp.TextContent{
-						Text: errorMessage,
-					},
+// ExecuteTool runs a tool with raw JSON arguments and formats the outcome as an MCP result.
+// Failures are reported in the result, as the MCP spec requires, rather than as an error.
+func ExecuteTool(tool tools.Tool, argsJSON json.RawMessage) *mcp.CallToolResult {
+	return ExecuteToolContext(context.Background(), tool, argsJSON)
+}
+
+// ExecuteToolContext is ExecuteTool for a call whose context the tool may watch
+func ExecuteToolContext(ctx context.Context, tool tools.Tool, argsJSON json.RawMessage) *mcp.CallToolResult {
+	var result interface{}
+	var err error
+	if executor, ok := tool.(tools.ContextExecutor); ok {
+		result, err = executor.ExecuteContext(ctx, argsJSON)
+	} else {
+		result, err = tool.Execute(argsJSON)
+	}
+	var busy *webex.BusyError
+	if errors.As(err, &busy) {
+		return rateLimitedResult("Webex requests", busy.RetryAfter)
+	}
+	if err != nil {
+		// Return error as tool result per MCP spec
+		// Be specific about the error type
+		errorMessage := fmt.Sprintf("Tool execution failed: %s", secrets.Redact(err.Error()))
+		if err.Error() == "404 Not Found" {
+			errorMessage = "Resource not found. Please verify the ID or name."
+		} else if err.Error() == "401 Unauthorized" {
+			errorMessage = "Authentication failed. Please check your API credentials."
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: errorMessage,
 				},
-				IsError: true,
-			}, nil
+			},
+			IsError: true,
 		}
+	}
 
-		// Handle different result types
-		var content []mcp.Content
+	// Handle different result types
+	var content []mcp.Content
 
-		switch v := result.(type) {
-		case nil:
-			// Empty result (e.g., from DELETE operations)
+	switch v := result.(type) {
+	case nil:
+		// Empty result (e.g., from DELETE operations)
+		content = []mcp.Content{
+			&mcp.TextContent{
+				Text: "Operation completed successfully",
+			},
+		}
+	case string:
+		content = []mcp.Content{
+			&mcp.TextContent{
+				Text: v,
+			},
+		}
+	case map[string]interface{}:
+		// Check if it's a simple success response
+		if success, ok := v["success"].(bool); ok && success && len(v) == 1 {
 			content = []mcp.Content{
 				&mcp.TextContent{
 					Text: "Operation completed successfully",
 				},
 			}
-		case string:
-			content = []mcp.Content{
-				&mcp.TextContent{
-					Text: v,
-				},
-			}
-		case map[string]interface{}:
-			// Check if it's a simple success response
-			if success, ok := v["success"].(bool); ok && success && len(v) == 1 {
-				content = []mcp.Content{
-					&mcp.TextContent{
-						Text: "Operation completed successfully",
-					},
-				}
-			} else {
-				// Format as JSON for complex objects
-				resultJSON, err := json.MarshalIndent(v, "", "  ")
-				if err != nil {
-					return &mcp.CallToolResult{
-						Content: []mcp.Content{
-							&mcp.TextContent{
-								Text: fmt.Sprintf("Failed to format result: %v", err),
-							},
-						},
-						IsError: true,
-					}, nil
-				}
-				content = []mcp.Content{
-					&mcp.TextContent{
-						Text: string(resultJSON),
-					},
-				}
-			}
-		default:
-			// Default to JSON serialization
-			resultJSON, err := json.MarshalIndent(result, "", "  ")
+		} else {
+			// Format as JSON for complex objects
+			resultJSON, err := json.MarshalIndent(v, "", "  ")
 			if err != nil {
 				return &mcp.CallToolResult{
 					Content: []mcp.Content{
@@ -175,7 +225,7 @@ func createToolHandler(tool tools.Tool) mcp.ToolHandler {
 						},
 					},
 					IsError: true,
-				}, nil
+				}
 			}
 			content = []mcp.Content{
 				&mcp.TextContent{
@@ -183,48 +233,72 @@ func createToolHandler(tool tools.Tool) mcp.ToolHandler {
 				},
 			}
 		}
+	default:
+		// Default to JSON serialization
+		resultJSON, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{
+						Text: fmt.Sprintf("Failed to format result: %v", err),
+					},
+				},
+				IsError: true,
+			}
+		}
+		content = []mcp.Content{
+			&mcp.TextContent{
+				Text: string(resultJSON),
+			},
+		}
+	}
 
-		// Return successful result
-		return &mcp.CallToolResult{
-			Content: content,
-			IsError: false,
-		}, nil
+	// Return successful result
+	return &mcp.CallToolResult{
+		Content: content,
+		IsError: false,
 	}
 }
 
 // registerTools registers all tools from the registry with the MCP server
-func registerTools(server *mcp.Server, registry *tools.Registry) {
-	allTools := registry.GetTools()
-
-	for _, tool := range allTools {
-		// Validate tool name for MCP compliance
-		if err := ValidateToolName(tool.Name()); err != nil {
-			log.Printf("Skipping tool %s: %v", tool.Name(), err)
-			continue
-		}
+func registerTools(server *mcp.Server, registry *tools.Registry) *ToolSet {
+	toolSet := newToolSet(server)
+	toolSet.Apply(registry)
+	return toolSet
+}
 
-		// Validate tool description for MCP compliance
-		if err := ValidateToolDescription(tool.Description()); err != nil {
-			log.Printf("Skipping tool %s: %v", tool.Name(), err)
-			continue
-		}
+// toolDefinition validates a tool and builds its MCP definition, logging tools it skips
+func toolDefinition(tool tools.Tool) (*mcp.Tool, bool) {
+	definition, err := ToolDefinition(tool)
+	if err != nil {
+		log.Printf("Skipping tool %s: %v", tool.Name(), err)
+		return nil, false
+	}
+	return definition, true
+}
 
-		// Convert schema
-		schema, err := convertToolSchema(tool)
-		if err != nil {
-			log.Printf("Failed to convert schema for tool %s: %v", tool.Name(), err)
-			continue
-		}
+// ToolDefinition validates a tool and builds the definition advertised by tools/list
+func ToolDefinition(tool tools.Tool) (*mcp.Tool, error) {
+	// Validate tool name for MCP compliance
+	if err := ValidateToolName(tool.Name()); err != nil {
+		return nil, err
+	}
 
-		// Create MCP tool definition
-		mcpTool := &mcp.Tool{
-			Name:        tool.Name(),
-			Description: tool.Description(),
-			InputSchema: schema,
-		}
+	// Validate tool description for MCP compliance
+	if err := ValidateToolDescription(tool.Description()); err != nil {
+		return nil, err
+	}
 
-		// Create and add handler
-		handler := createToolHandler(tool)
-		server.AddTool(mcpTool, handler)
+	// Convert schema
+	schema, err := convertToolSchema(tool)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert schema: %w", err)
 	}
+
+	// Create MCP tool definition
+	return &mcp.Tool{
+		Name:        tool.Name(),
+		Description: tool.Description(),
+		InputSchema: schema,
+	}, nil
 }
@@ -4,12 +4,16 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/raja-aiml/webex-mcp-server/internal/secrets"
 	"github.com/raja-aiml/webex-mcp-server/internal/testutil"
 	"github.com/raja-aiml/webex-mcp-server/internal/tools"
+	"github.com/raja-aiml/webex-mcp-server/internal/webex"
 )
 
 func TestCreateMCPServer(t *testing.T) {
@@ -215,6 +219,26 @@ func TestCreateToolHandler(t *testing.T) {
 	}
 }
 
+func TestCreateToolHandler_RedactsSecrets(t *testing.T) {
+	secrets.Register("handler-secret-token")
+	tool := &mockTool{
+		name:       "test-tool",
+		executeErr: fmt.Errorf("request with token handler-secret-token failed"),
+	}
+
+	result, err := createToolHandler(tool)(context.Background(), &mcp.CallToolRequest{
+		Params: &mcp.CallToolParams{Name: tool.name, Arguments: map[string]any{}},
+	})
+	if err != nil {
+		t.Fatalf("handler() error = %v", err)
+	}
+
+	text := result.Content[0].(*mcp.TextContent).Text
+	if strings.Contains(text, "handler-secret-token") || !strings.Contains(text, "[REDACTED]") {
+		t.Errorf("error message not redacted: %q", text)
+	}
+}
+
 func TestRegisterTools(t *testing.T) {
 	// Create a test server
 	server := mcp.NewServer(&mcp.Implementation{
@@ -270,3 +294,62 @@ func (b *badSchemaTool) GetInputSchema() interface{} {
 	// Return something that will fail JSON marshalling
 	return make(chan int)
 }
+
+func TestCreateToolHandler_Progress(t *testing.T) {
+	server := mcp.NewServer(&mcp.Implementation{Name: "test-server", Version: "1.0.0"}, &mcp.ServerOptions{HasTools: true})
+	registry := tools.NewRegistry()
+	registry.Register(tools.NewContextTool("wait", "Reports progress",
+		tools.SimpleSchema("Wait.", map[string]*jsonschema.Schema{}, nil),
+		func(ctx context.Context, params *struct{}, client webex.HTTPClient) (interface{}, error) {
+			tools.ReportProgress(ctx, 1, 2, "halfway")
+			tools.ReportProgress(ctx, 2, 2, "done")
+			return "finished", nil
+		}))
+	registerTools(server, registry)
+
+	progress := make(chan *mcp.ProgressNotificationParams, 10)
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(context.Background(), serverTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { serverSession.Close() })
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, &mcp.ClientOptions{
+		ProgressNotificationHandler: func(_ context.Context, req *mcp.ProgressNotificationClientRequest) {
+			progress <- req.Params
+		},
+	})
+	session, err := client.Connect(context.Background(), clientTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { session.Close() })
+
+	// Without a progress token nothing is sent
+	if _, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: "wait"}); err != nil {
+		t.Fatal(err)
+	}
+	// SetProgressToken loses the token when Meta is nil
+	params := &mcp.CallToolParams{Name: "wait", Meta: mcp.Meta{}}
+	params.SetProgressToken("call-1")
+	result, err := session.CallTool(context.Background(), params)
+	if err != nil || result.IsError {
+		t.Fatalf("CallTool() = %+v, %v", result, err)
+	}
+
+	for _, want := range []string{"halfway", "done"} {
+		select {
+		case got := <-progress:
+			if got.ProgressToken != "call-1" || got.Message != want || got.Total != 2 {
+				t.Errorf("progress = %+v, want %q", got, want)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("no %q progress notification", want)
+		}
+	}
+	select {
+	case got := <-progress:
+		t.Errorf("unexpected progress %+v", got)
+	default:
+	}
+}
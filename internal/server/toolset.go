@@ -0,0 +1,138 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/raja-aiml/webex-mcp-server/internal/tools"
+)
+
+// ToolSet tracks the tools registered on a running MCP server so that a new
+// registry can be applied as a diff. Handlers resolve the tool implementation
+// at call time, so replacing an implementation whose definition is unchanged
+// (for example after a token change) does not notify clients.
+type ToolSet struct {
+	server *mcp.Server
+
+	mu     sync.RWMutex
+	tools  map[string]tools.Tool
+	defs   map[string][]byte // Advertised definition per tool, for change detection
+	limits *callLimits
+}
+
+// ReloadResult describes how a registry changed the advertised tool list
+type ReloadResult struct {
+	Added   []string
+	Removed []string
+	Updated []string // Tools whose description or schema changed
+}
+
+// Changed reports whether clients were sent a tools/list_changed notification
+func (r ReloadResult) Changed() bool {
+	return len(r.Added) > 0 || len(r.Removed) > 0 || len(r.Updated) > 0
+}
+
+func newToolSet(server *mcp.Server) *ToolSet {
+	return &ToolSet{
+		server: server,
+		tools:  make(map[string]tools.Tool),
+		defs:   make(map[string][]byte),
+	}
+}
+
+// Apply makes the server expose exactly the valid tools in registry.
+// The MCP server notifies connected clients with notifications/tools/list_changed
+// whenever a tool is added, removed or redefined.
+func (ts *ToolSet) Apply(registry *tools.Registry) ReloadResult {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	var result ReloadResult
+	next := make(map[string]tools.Tool)
+
+	for _, tool := range registry.GetTools() {
+		mcpTool, ok := toolDefinition(tool)
+		if !ok {
+			continue
+		}
+		def, err := json.Marshal(mcpTool)
+		if err != nil {
+			log.Printf("Skipping tool %s: %v", tool.Name(), err)
+			continue
+		}
+
+		name := tool.Name()
+		next[name] = tool
+		previous, exists := ts.defs[name]
+		switch {
+		case !exists:
+			result.Added = append(result.Added, name)
+		case string(previous) != string(def):
+			result.Updated = append(result.Updated, name)
+		default:
+			continue
+		}
+		ts.defs[name] = def
+		ts.server.AddTool(mcpTool, ts.handler(name))
+	}
+
+	for name := range ts.defs {
+		if _, ok := next[name]; !ok {
+			result.Removed = append(result.Removed, name)
+			delete(ts.defs, name)
+		}
+	}
+	if len(result.Removed) > 0 {
+		ts.server.RemoveTools(result.Removed...)
+	}
+
+	ts.tools = next
+	sort.Strings(result.Added)
+	sort.Strings(result.Removed)
+	sort.Strings(result.Updated)
+	return result
+}
+
+// Names returns the names of the tools currently exposed, sorted
+func (ts *ToolSet) Names() []string {
+	ts.mu.RLock()
+	defer ts.mu.RUnlock()
+
+	names := make([]string, 0, len(ts.tools))
+	for name := range ts.tools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// handler returns an MCP handler that dispatches to the current implementation of name
+func (ts *ToolSet) handler(name string) mcp.ToolHandler {
+	return func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ts.mu.RLock()
+		tool, ok := ts.tools[name]
+		limits := ts.limits
+		ts.mu.RUnlock()
+		if !ok {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{&mcp.TextContent{Text: "Tool " + name + " is no longer available"}},
+				IsError: true,
+			}, nil
+		}
+		client := clientID(request)
+		if scope, wait, ok := limits.allow(client, name); !ok {
+			log.Printf("Rate limit exceeded for %s calling %s", scope, name)
+			return rateLimitedResult(scope, wait), nil
+		}
+		// The calls of a batch count against the same limits as calls made directly
+		ctx = tools.WithCallLimit(ctx, func(tool string) (string, time.Duration, bool) {
+			return limits.allow(client, tool)
+		})
+		return createToolHandler(tool)(ctx, request)
+	}
+}
@@ -0,0 +1,115 @@
+package server
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/raja-aiml/webex-mcp-server/internal/tools"
+)
+
+// connectTestClient connects an in-memory MCP client and counts tools/list_changed notifications
+func connectTestClient(t *testing.T, server *mcp.Server) (*mcp.ClientSession, chan struct{}) {
+	t.Helper()
+	ctx := context.Background()
+	notifications := make(chan struct{}, 100)
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		t.Fatalf("server.Connect() error = %v", err)
+	}
+	t.Cleanup(func() { serverSession.Close() })
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, &mcp.ClientOptions{
+		ToolListChangedHandler: func(context.Context, *mcp.ToolListChangedRequest) {
+			notifications <- struct{}{}
+		},
+	})
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client.Connect() error = %v", err)
+	}
+	t.Cleanup(func() { session.Close() })
+	return session, notifications
+}
+
+func listToolNames(t *testing.T, session *mcp.ClientSession) []string {
+	t.Helper()
+	result, err := session.ListTools(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("ListTools() error = %v", err)
+	}
+	var names []string
+	for _, tool := range result.Tools {
+		names = append(names, tool.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func drain(ch chan struct{}) int {
+	count := 0
+	for {
+		select {
+		case <-ch:
+			count++
+		case <-time.After(100 * time.Millisecond):
+			return count
+		}
+	}
+}
+
+func TestToolSet_Apply(t *testing.T) {
+	server := mcp.NewServer(&mcp.Implementation{Name: "test-server", Version: "1.0.0"}, &mcp.ServerOptions{HasTools: true})
+
+	first := tools.NewRegistry()
+	first.Register(&mockTool{name: "keep_tool", description: "Kept", schemaType: "jsonschema", executeResp: "old"})
+	first.Register(&mockTool{name: "drop_tool", description: "Dropped", schemaType: "jsonschema"})
+	first.Register(&mockTool{name: "Invalid-Name", description: "Skipped", schemaType: "jsonschema"})
+
+	toolSet := registerTools(server, first)
+	if got := strings.Join(toolSet.Names(), ","); got != "drop_tool,keep_tool" {
+		t.Fatalf("Names() = %s, want drop_tool,keep_tool", got)
+	}
+
+	session, notifications := connectTestClient(t, server)
+	drain(notifications)
+
+	// Same definitions with a new implementation: no list change, calls use the new tool
+	same := tools.NewRegistry()
+	same.Register(&mockTool{name: "keep_tool", description: "Kept", schemaType: "jsonschema", executeResp: "new"})
+	same.Register(&mockTool{name: "drop_tool", description: "Dropped", schemaType: "jsonschema"})
+	if result := toolSet.Apply(same); result.Changed() {
+		t.Errorf("Apply() with identical definitions = %+v, want no change", result)
+	}
+	if n := drain(notifications); n != 0 {
+		t.Errorf("got %d list_changed notifications, want 0", n)
+	}
+	callResult, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: "keep_tool"})
+	if err != nil {
+		t.Fatalf("CallTool() error = %v", err)
+	}
+	if text := callResult.Content[0].(*mcp.TextContent).Text; text != "new" {
+		t.Errorf("CallTool() = %q, want the replacement implementation", text)
+	}
+
+	// Add, remove and redefine
+	next := tools.NewRegistry()
+	next.Register(&mockTool{name: "keep_tool", description: "Kept with a new description", schemaType: "jsonschema"})
+	next.Register(&mockTool{name: "new_tool", description: "Added", schemaType: "legacy"})
+	result := toolSet.Apply(next)
+
+	if strings.Join(result.Added, ",") != "new_tool" || strings.Join(result.Removed, ",") != "drop_tool" || strings.Join(result.Updated, ",") != "keep_tool" {
+		t.Errorf("Apply() = %+v", result)
+	}
+	if n := drain(notifications); n == 0 {
+		t.Error("expected tools/list_changed notifications after the tool set changed")
+	}
+	if got := strings.Join(listToolNames(t, session), ","); got != "keep_tool,new_tool" {
+		t.Errorf("client sees tools %s, want keep_tool,new_tool", got)
+	}
+}
@@ -2,22 +2,47 @@ package server
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"log/slog"
 	"net/http"
+	"net/url"
 	"os"
 	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/raja-aiml/webex-mcp-server/internal/config"
 	"github.com/raja-aiml/webex-mcp-server/internal/handlers"
+	"github.com/raja-aiml/webex-mcp-server/internal/secrets"
+	"github.com/raja-aiml/webex-mcp-server/internal/webhooks"
 )
 
 // RunHTTPServer starts the HTTP server with context support
 func RunHTTPServer(ctx context.Context, httpAddr string, server *mcp.Server, serviceName, version string) error {
-	mux := handlers.SetupHTTPHandlers(server, serviceName, version)
+	return RunHTTPServerWithConfig(ctx, config.ServerConfig{Addr: httpAddr}, server, nil, serviceName, version)
+}
+
+// RunHTTPServerWithConfig starts the HTTP server on the TCP address or unix socket in cfg,
+// terminating TLS when a certificate is configured. ready backs /readyz and may be nil.
+func RunHTTPServerWithConfig(ctx context.Context, cfg config.ServerConfig, server *mcp.Server, ready handlers.ReadinessFunc, serviceName, version string) error {
+	mux := handlers.SetupHTTPHandlers(server, serviceName, version, ready, clientAuth)
+	if err := mountWebhookReceiver(mux); err != nil {
+		return err
+	}
+
+	tlsConfig, err := newServerTLSConfig(cfg.TLS)
+	if err != nil {
+		return err
+	}
+
+	listener, err := listen(cfg)
+	if err != nil {
+		return err
+	}
 
 	httpServer := &http.Server{
-		Addr:              httpAddr,
 		Handler:           mux,
+		TLSConfig:         tlsConfig,
 		ReadTimeout:       10 * time.Second,
 		ReadHeaderTimeout: 2 * time.Second,
 		WriteTimeout:      15 * time.Second,
@@ -25,11 +50,23 @@ func RunHTTPServer(ctx context.Context, httpAddr string, server *mcp.Server, ser
 		MaxHeaderBytes:    1 << 20, // 1 MB
 	}
 
+	scheme := "http"
+	if tlsConfig != nil {
+		scheme = "https"
+	}
+
 	// Start server in goroutine
 	errChan := make(chan error, 1)
 	go func() {
-		log.Printf("[%s v%s] MCP server listening at %s", serviceName, version, httpAddr)
-		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.Printf("[%s v%s] MCP server listening at %s (%s)", serviceName, version, cfg.Addr, scheme)
+		var err error
+		if tlsConfig != nil {
+			// Certificates come from TLSConfig.GetCertificate
+			err = httpServer.ServeTLS(listener, "", "")
+		} else {
+			err = httpServer.Serve(listener)
+		}
+		if err != nil && err != http.ErrServerClosed {
 			errChan <- err
 		}
 	}()
@@ -46,12 +83,37 @@ func RunHTTPServer(ctx context.Context, httpAddr string, server *mcp.Server, ser
 	}
 }
 
+// mountWebhookReceiver serves Webex webhook notifications at the configured path,
+// outside the client authentication that guards the MCP endpoints; Webex signs
+// them instead. Changes to the path take effect after a restart.
+func mountWebhookReceiver(mux *http.ServeMux) error {
+	cfg, err := config.Load()
+	if err != nil || cfg.Webhooks.Path == "" {
+		return nil
+	}
+	probe := &http.Request{Method: http.MethodPost, URL: &url.URL{Path: cfg.Webhooks.Path}}
+	if _, pattern := mux.Handler(probe); pattern != "/" {
+		return fmt.Errorf("webhooks.path %s is already served by %s", cfg.Webhooks.Path, pattern)
+	}
+
+	startupSecret := cfg.Webhooks.Secret
+	mux.Handle(cfg.Webhooks.Path, webhooks.NewReceiver(webhooks.Default, func() string {
+		current, err := config.Load()
+		if err != nil {
+			return startupSecret
+		}
+		return current.Webhooks.Secret
+	}))
+	log.Printf("Receiving Webex webhooks at %s", cfg.Webhooks.Path)
+	return nil
+}
+
 // RunStdioServer starts the server in stdio mode with context support
 func RunStdioServer(ctx context.Context, server *mcp.Server, serviceName, version string) error {
 	var transport mcp.Transport = mcp.NewStdioTransport()
 
-	if os.Getenv("MCP_DEBUG") == "true" {
-		transport = mcp.NewLoggingTransport(transport, os.Stderr)
+	if os.Getenv("MCP_DEBUG") == "true" || slog.Default().Enabled(ctx, slog.LevelDebug) {
+		transport = mcp.NewLoggingTransport(transport, secrets.NewRedactingWriter(os.Stderr))
 		log.Printf("[%s v%s] MCP debug logging enabled", serviceName, version)
 	}
 
@@ -59,12 +121,14 @@ func RunStdioServer(ctx context.Context, server *mcp.Server, serviceName, versio
 	return server.Run(ctx, transport)
 }
 
-// RunSSEServer starts the server in SSE mode
+// RunSSEServer starts the server in SSE mode. The HTTP server always serves both
+// transports, so this differs from RunHTTPServer only in its default address and logging.
 func RunSSEServer(ctx context.Context, httpAddr string, server *mcp.Server, serviceName, version string) error {
 	if httpAddr == "" {
-		httpAddr = ":3001"
+		httpAddr = ":" + config.DefaultPort
 	}
 
-	log.Printf("[%s v%s] Starting SSE server at %s", serviceName, version, httpAddr)
+	log.Printf("[%s v%s] Starting SSE server at %s (stream %s, messages %s; streamable HTTP on /)",
+		serviceName, version, httpAddr, handlers.SSEPath, handlers.MessagesPath)
 	return RunHTTPServer(ctx, httpAddr, server, serviceName, version)
 }
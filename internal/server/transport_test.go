@@ -3,10 +3,16 @@ package server
 import (
 	"context"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/raja-aiml/webex-mcp-server/internal/config"
+	"github.com/raja-aiml/webex-mcp-server/internal/handlers"
+	"github.com/raja-aiml/webex-mcp-server/internal/testutil"
+	"github.com/raja-aiml/webex-mcp-server/internal/webhooks"
 )
 
 func TestRunHTTPServer(t *testing.T) {
@@ -71,6 +77,62 @@ func TestRunHTTPServer_HealthCheck(t *testing.T) {
 	}
 }
 
+func TestMountWebhookReceiver(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		wantErr string
+	}{
+		{name: "not configured"},
+		{name: "own path", path: "/webhooks/webex"},
+		{name: "taken by a probe", path: "/healthz", wantErr: "already served by /healthz"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cleanups := []func(){
+				testutil.SetEnv(t, "WEBEX_PUBLIC_WORKSPACE_API_KEY", "token"),
+				testutil.SetEnv(t, "MCP_SERVER_MODE", "http"),
+				testutil.SetEnv(t, "WEBEX_WEBHOOK_PATH", tt.path),
+				testutil.SetEnv(t, "WEBEX_WEBHOOK_SECRET", "hook-secret"),
+			}
+			defer func() {
+				for _, cleanup := range cleanups {
+					cleanup()
+				}
+				config.ResetForTesting()
+			}()
+			config.ResetForTesting()
+
+			server := mcp.NewServer(&mcp.Implementation{Name: "test-server", Version: "1.0.0"}, nil)
+			mux := handlers.SetupHTTPHandlers(server, "test", "1.0.0", nil, clientAuth)
+			err := mountWebhookReceiver(mux)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("mountWebhookReceiver() = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.path == "" {
+				return
+			}
+
+			body := `{"resource":"attachmentActions","event":"created","data":{}}`
+			for signature, want := range map[string]int{webhooks.Sign("hook-secret", []byte(body)): http.StatusNoContent, "forged": http.StatusForbidden} {
+				req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(body))
+				req.Header.Set(webhooks.SignatureHeader, signature)
+				rec := httptest.NewRecorder()
+				mux.ServeHTTP(rec, req)
+				if rec.Code != want {
+					t.Errorf("signature %q: status = %d, want %d", signature, rec.Code, want)
+				}
+			}
+		})
+	}
+}
+
 func TestRunStdioServer(t *testing.T) {
 	// Skip this test as it interferes with stdin/stdout
 	t.Skip("Skipping stdio test - interferes with test runner stdin/stdout")
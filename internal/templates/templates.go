@@ -0,0 +1,276 @@
+// Package templates renders the message templates in a directory: Go
+// text/template files for the Markdown of a message, optionally paired with a
+// card. Every variable a template needs is checked before anything is sent.
+package templates
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/raja-aiml/webex-mcp-server/internal/cards"
+)
+
+// File suffixes of the two parts of a template
+const (
+	MarkdownSuffix = ".md"
+	CardSuffix     = ".card.json"
+)
+
+// MaxMarkdownLength is the longest message Webex accepts, in bytes
+const MaxMarkdownLength = 7439
+
+var (
+	templateName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+	// A leading {{/* comment */}} describes the template
+	leadingComment = regexp.MustCompile(`^\s*\{\{-?\s*/\*\s*((?s).*?)\s*\*/\s*-?\}\}`)
+)
+
+// Template describes a template in the directory
+type Template struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Markdown    bool     `json:"markdown"` // Has a NAME.md part
+	Card        bool     `json:"card"`     // Has a NAME.card.json part
+	Required    []string `json:"required"` // Variables every message needs
+	Optional    []string `json:"optional"` // Variables only used inside if, with or range
+}
+
+// Message is a rendered template
+type Message struct {
+	Markdown string      `json:"markdown,omitempty"`
+	Card     interface{} `json:"card,omitempty"`
+}
+
+// MissingError lists the required variables a render was not given
+type MissingError struct {
+	Template string
+	Missing  []string
+}
+
+func (e *MissingError) Error() string {
+	return fmt.Sprintf("template %s is missing variables: %s", e.Template, strings.Join(e.Missing, ", "))
+}
+
+// loaded is a parsed template
+type loaded struct {
+	Template
+	markdown *template.Template
+	card     interface{}
+	ranged   map[string]bool
+}
+
+// List returns the templates in dir, sorted by name
+func List(dir string) ([]Template, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list message templates: %w", err)
+	}
+	var names []string
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), CardSuffix)
+		if !ok {
+			name, ok = strings.CutSuffix(entry.Name(), MarkdownSuffix)
+		}
+		if ok && !entry.IsDir() && templateName.MatchString(name) && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	templates := []Template{}
+	for _, name := range names {
+		t, err := load(dir, name)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, t.Template)
+	}
+	return templates, nil
+}
+
+// Render fills the template called name with data. A required variable missing
+// from data, or null, fails the render with a MissingError naming all of them;
+// a missing key inside a variable fails it too.
+func Render(dir, name string, data map[string]interface{}) (*Message, error) {
+	t, err := load(dir, name)
+	if err != nil {
+		return nil, err
+	}
+	var missing []string
+	for _, variable := range t.Required {
+		if value, ok := data[variable]; !ok || value == nil {
+			missing = append(missing, variable)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, &MissingError{Template: name, Missing: missing}
+	}
+
+	message := &Message{}
+	if t.markdown != nil {
+		// Optional variables read as empty, so {{if .link}} and {{range .items}} work without them
+		values := make(map[string]interface{}, len(data)+len(t.Optional))
+		for _, variable := range t.Optional {
+			if t.ranged[variable] {
+				values[variable] = nil
+			} else {
+				values[variable] = ""
+			}
+		}
+		for key, value := range data {
+			values[key] = value
+		}
+		var out bytes.Buffer
+		if err := t.markdown.Execute(&out, values); err != nil {
+			return nil, fmt.Errorf("failed to render template %s: %w", name, err)
+		}
+		message.Markdown = strings.TrimSpace(out.String())
+		if len(message.Markdown) > MaxMarkdownLength {
+			return nil, fmt.Errorf("template %s rendered %d bytes of Markdown, more than the %d Webex accepts", name, len(message.Markdown), MaxMarkdownLength)
+		}
+	}
+	if t.card != nil {
+		card, err := cards.Expand(t.card, data)
+		if err != nil {
+			return nil, fmt.Errorf("template %s: %w", name, err)
+		}
+		if err := cards.Validate(card); err != nil {
+			return nil, fmt.Errorf("template %s: %w", name, err)
+		}
+		message.Card = card
+	}
+	return message, nil
+}
+
+// load reads and parses both parts of the template called name
+func load(dir, name string) (*loaded, error) {
+	if !templateName.MatchString(name) {
+		return nil, fmt.Errorf("invalid template name %q: use letters, digits, - and _", name)
+	}
+	t := &loaded{Template: Template{Name: name, Required: []string{}, Optional: []string{}}}
+	v := &variables{required: map[string]bool{}, optional: map[string]bool{}, ranged: map[string]bool{}}
+
+	source, err := os.ReadFile(filepath.Join(dir, name+MarkdownSuffix))
+	switch {
+	case err == nil:
+		t.Markdown = true
+		if m := leadingComment.FindSubmatch(source); m != nil {
+			t.Description = string(m[1])
+		}
+		if t.markdown, err = template.New(name).Option("missingkey=error").Parse(string(source)); err != nil {
+			return nil, fmt.Errorf("failed to parse template %s: %w", name, err)
+		}
+		v.collect(t.markdown.Tree.Root, true, false)
+	case !errors.Is(err, os.ErrNotExist):
+		return nil, fmt.Errorf("failed to read template %s: %w", name, err)
+	}
+
+	source, err = os.ReadFile(filepath.Join(dir, name+CardSuffix))
+	switch {
+	case err == nil:
+		t.Card = true
+		if err := json.Unmarshal(source, &t.card); err != nil {
+			return nil, fmt.Errorf("failed to parse card of template %s: %w", name, err)
+		}
+		// Card variables are always required; ${a.b} needs a
+		for _, variable := range cards.Variables(t.card) {
+			root, _, _ := strings.Cut(variable, ".")
+			v.required[root] = true
+		}
+	case !errors.Is(err, os.ErrNotExist):
+		return nil, fmt.Errorf("failed to read card of template %s: %w", name, err)
+	}
+	if !t.Markdown && !t.Card {
+		return nil, fmt.Errorf("message template %q not found", name)
+	}
+
+	for variable := range v.required {
+		t.Required = append(t.Required, variable)
+	}
+	for variable := range v.optional {
+		if !v.required[variable] {
+			t.Optional = append(t.Optional, variable)
+		}
+	}
+	t.ranged = v.ranged
+	sort.Strings(t.Required)
+	sort.Strings(t.Optional)
+	return t, nil
+}
+
+// variables collects the top-level variables a template reads
+type variables struct {
+	required, optional map[string]bool
+	ranged             map[string]bool // Read as the list of a range
+}
+
+// collect records the variables node reads. rootDot is whether dot is the
+// template data there; conditional is whether the node may not run.
+func (v *variables) collect(node parse.Node, rootDot, conditional bool) {
+	record := func(name string) {
+		if conditional {
+			v.optional[name] = true
+		} else {
+			v.required[name] = true
+		}
+	}
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			v.collect(child, rootDot, conditional)
+		}
+	case *parse.ActionNode:
+		v.collect(n.Pipe, rootDot, conditional)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			for _, arg := range cmd.Args {
+				v.collect(arg, rootDot, conditional)
+			}
+		}
+	case *parse.ChainNode:
+		v.collect(n.Node, rootDot, conditional)
+	case *parse.FieldNode:
+		if rootDot {
+			record(n.Ident[0])
+		}
+	case *parse.VariableNode:
+		if n.Ident[0] == "$" && len(n.Ident) > 1 {
+			record(n.Ident[1])
+		}
+	case *parse.IfNode:
+		v.collect(n.Pipe, rootDot, true)
+		v.collect(n.List, rootDot, true)
+		v.collect(n.ElseList, rootDot, true)
+	case *parse.WithNode:
+		v.collect(n.Pipe, rootDot, true)
+		v.collect(n.List, false, true)
+		v.collect(n.ElseList, rootDot, true)
+	case *parse.RangeNode:
+		if len(n.Pipe.Cmds) == 1 && len(n.Pipe.Cmds[0].Args) == 1 {
+			if field, ok := n.Pipe.Cmds[0].Args[0].(*parse.FieldNode); ok && rootDot {
+				v.ranged[field.Ident[0]] = true
+			}
+		}
+		v.collect(n.Pipe, rootDot, true)
+		v.collect(n.List, false, true)
+		v.collect(n.ElseList, rootDot, true)
+	case *parse.TemplateNode:
+		v.collect(n.Pipe, rootDot, conditional)
+	}
+}
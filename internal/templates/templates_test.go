@@ -0,0 +1,124 @@
+package templates
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeTemplates(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestList(t *testing.T) {
+	dir := writeTemplates(t, map[string]string{
+		"incident.md": `{{/* Announce an incident */}}
+**{{.severity}}**: {{.title}}
+{{if .link}}[Details]({{.link}}){{end}}
+{{range .steps}}- {{.}} ({{$.owner}})
+{{end}}`,
+		"incident.card.json": `{"type": "AdaptiveCard", "version": "1.3", "body": [{"type": "TextBlock", "text": "${title} at ${service.name}"}]}`,
+		"survey.card.json":   `{"type": "AdaptiveCard", "version": "1.3", "body": []}`,
+		"notes.txt":          "not a template",
+	})
+
+	list, err := List(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 2 || list[0].Name != "incident" || list[1].Name != "survey" {
+		t.Fatalf("List() = %+v", list)
+	}
+	incident := list[0]
+	if incident.Description != "Announce an incident" || !incident.Markdown || !incident.Card {
+		t.Errorf("incident = %+v", incident)
+	}
+	if got := strings.Join(incident.Required, ","); got != "service,severity,title" {
+		t.Errorf("required = %s", got)
+	}
+	if got := strings.Join(incident.Optional, ","); got != "link,owner,steps" {
+		t.Errorf("optional = %s", got)
+	}
+	if survey := list[1]; survey.Markdown || !survey.Card || len(survey.Required) != 0 {
+		t.Errorf("survey = %+v", survey)
+	}
+}
+
+func TestRender(t *testing.T) {
+	dir := writeTemplates(t, map[string]string{
+		"release.md": `{{/* Release notes */}}
+## {{.product}} {{.version}}
+{{range .changes}}- {{.}}
+{{end}}{{with .link}}[Notes]({{.}}){{end}}`,
+		"release.card.json":  `{"type": "AdaptiveCard", "version": "1.3", "body": [{"type": "TextBlock", "text": "${product} ${version}"}]}`,
+		"broken.md":          "{{.a",
+		"bad-card.card.json": `{"type": "AdaptiveCard", "version": "1.3", "body": "${items}"}`,
+		"long.md":            "{{.text}}",
+		"nested.md":          "{{.release.version}} by {{.owner}}",
+	})
+
+	tests := []struct {
+		name         string
+		template     string
+		data         map[string]interface{}
+		wantMarkdown string
+		wantErr      string
+	}{
+		{
+			name:         "all variables",
+			template:     "release",
+			data:         map[string]interface{}{"product": "Sync", "version": "2.1", "changes": []interface{}{"Faster", "Smaller"}, "link": "https://example.com"},
+			wantMarkdown: "## Sync 2.1\n- Faster\n- Smaller\n[Notes](https://example.com)",
+		},
+		{
+			name:         "optional variables left out",
+			template:     "release",
+			data:         map[string]interface{}{"product": "Sync", "version": "2.1"},
+			wantMarkdown: "## Sync 2.1",
+		},
+		{name: "missing variables", template: "release", data: map[string]interface{}{"changes": []interface{}{}}, wantErr: "template release is missing variables: product, version"},
+		{name: "null variable", template: "nested", data: map[string]interface{}{"release": map[string]interface{}{"version": "2.1"}, "owner": nil}, wantErr: "template nested is missing variables: owner"},
+		{name: "missing nested key", template: "nested", data: map[string]interface{}{"release": map[string]interface{}{}, "owner": "ops"}, wantErr: `map has no entry for key "version"`},
+		{name: "parse error", template: "broken", wantErr: "failed to parse template broken"},
+		{name: "invalid card", template: "bad-card", data: map[string]interface{}{"items": "none"}, wantErr: "body: must be an array"},
+		{name: "too long", template: "long", data: map[string]interface{}{"text": strings.Repeat("x", MaxMarkdownLength+1)}, wantErr: "more than the 7439"},
+		{name: "unknown template", template: "other", wantErr: `message template "other" not found`},
+		{name: "path outside the directory", template: "../release", wantErr: "invalid template name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message, err := Render(dir, tt.template, tt.data)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Render() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if message.Markdown != tt.wantMarkdown {
+				t.Errorf("markdown = %q, want %q", message.Markdown, tt.wantMarkdown)
+			}
+			body := message.Card.(map[string]interface{})["body"].([]interface{})
+			if text := body[0].(map[string]interface{})["text"]; text != "Sync 2.1" {
+				t.Errorf("card text = %q", text)
+			}
+		})
+	}
+
+	_, err := Render(dir, "release", nil)
+	var missing *MissingError
+	if !errors.As(err, &missing) || strings.Join(missing.Missing, ",") != "product,version" {
+		t.Errorf("Render() error = %v, want a MissingError", err)
+	}
+}
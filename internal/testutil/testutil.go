@@ -6,6 +6,8 @@ import (
 	"net/http/httptest"
 	"os"
 	"testing"
+
+	"github.com/raja-aiml/webex-mcp-server/internal/fakewebex"
 )
 
 // SetEnv sets an environment variable and returns a cleanup function
@@ -75,3 +77,12 @@ func AssertJSON(t *testing.T, expected, actual string) {
 		t.Errorf("JSON mismatch:\nExpected:\n%s\nActual:\n%s", expectedJSON, actualJSON)
 	}
 }
+
+// NewFakeWebex starts a fake Webex API that is closed when the test ends and returns its base URL
+func NewFakeWebex(t *testing.T) (*fakewebex.Server, string) {
+	t.Helper()
+	fake := fakewebex.New()
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	return fake, server.URL + "/v1"
+}
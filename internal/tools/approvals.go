@@ -0,0 +1,255 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/raja-aiml/webex-mcp-server/internal/cardresponse"
+	"github.com/raja-aiml/webex-mcp-server/internal/cards"
+	"github.com/raja-aiml/webex-mcp-server/internal/config"
+	"github.com/raja-aiml/webex-mcp-server/internal/webex"
+)
+
+// Decisions an approver can submit on an approval card
+const (
+	DecisionApprove = "approve"
+	DecisionDeny    = "deny"
+)
+
+// maxFactLength bounds an argument value shown on an approval card
+const maxFactLength = 300
+
+// Approval records who allowed a gated call; it is returned with the tool's result
+type Approval struct {
+	Tool          string    `json:"tool"`
+	ApprovedBy    string    `json:"approvedBy"` // Person ID
+	ApproverEmail string    `json:"approverEmail,omitempty"`
+	ApproverName  string    `json:"approverName,omitempty"`
+	ApprovedAt    time.Time `json:"approvedAt"`
+	Comment       string    `json:"comment,omitempty"`
+	CardMessageID string    `json:"cardMessageId"`
+}
+
+// WithApprovals returns a registry whose tools named in cfg.Tools only run after
+// one of the approvers approves the call on a card in the approvals room
+func WithApprovals(registry *Registry, cfg config.ApprovalsConfig) (*Registry, error) {
+	if len(cfg.Tools) == 0 {
+		return registry, nil
+	}
+
+	wrapped := NewRegistry()
+	for _, tool := range registry.GetTools() {
+		if containsName(cfg.Tools, tool.Name()) {
+			tool = &approvalTool{Tool: tool}
+		}
+		if err := wrapped.Register(tool); err != nil {
+			return nil, err
+		}
+	}
+	return wrapped, nil
+}
+
+// approvalTool asks for approval in Webex before running the tool it wraps.
+// The approvals settings are read on every call so reloads take effect at once.
+type approvalTool struct {
+	Tool
+}
+
+// Description tells the caller that calls wait for a person
+func (t *approvalTool) Description() string {
+	return t.Tool.Description() + " Each call waits until an approver approves it in Webex."
+}
+
+// Execute implements the Tool interface
+func (t *approvalTool) Execute(args json.RawMessage) (interface{}, error) {
+	return t.ExecuteContext(context.Background(), args)
+}
+
+// ExecuteContext runs the tool once the call is approved, returning its result with the approval
+func (t *approvalTool) ExecuteContext(ctx context.Context, args json.RawMessage) (interface{}, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	client, err := getDefaultClient()
+	if err != nil {
+		return nil, err
+	}
+	approval, err := requestApproval(ctx, client, cfg, t.Name(), args)
+	if err != nil {
+		return nil, err
+	}
+
+	var result interface{}
+	if tool, ok := t.Tool.(ContextExecutor); ok {
+		result, err = tool.ExecuteContext(ctx, args)
+	} else {
+		result, err = t.Tool.Execute(args)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"result": result, "approval": approval}, nil
+}
+
+// ExecuteWithMap implements the Tool interface
+func (t *approvalTool) ExecuteWithMap(args map[string]interface{}) (interface{}, error) {
+	return ExecuteWithMapBase(t, args)
+}
+
+// requestApproval posts an approval card for the call and waits for an approver to answer it
+func requestApproval(ctx context.Context, client webex.HTTPClient, cfg *config.Config, tool string, args json.RawMessage) (*Approval, error) {
+	approvers, err := approverIDs(client, cfg.Approvals.Approvers)
+	if err != nil {
+		return nil, err
+	}
+	card, err := approvalCard(tool, args, cfg.Approvals.Approvers)
+	if err != nil {
+		return nil, err
+	}
+	message, err := client.Post("/messages", map[string]interface{}{
+		"roomId":      cfg.Approvals.Room,
+		"markdown":    fmt.Sprintf("Approval needed to run **%s**. Open this message in a Webex app to approve or deny it.", tool),
+		"attachments": []interface{}{cards.Attachment(card)},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to post the approval card: %w", err)
+	}
+	cardID, _ := message["id"].(string)
+
+	response, err := awaitApprover(ctx, client, cfg, cardID, approvers)
+	if errors.Is(err, cardresponse.ErrTimeout) {
+		replyTo(client, cfg.Approvals.Room, cardID, fmt.Sprintf("Nobody answered within %s, so **%s** did not run.", cfg.Approvals.Timeout, tool))
+		return nil, fmt.Errorf("%s was not approved within %s", tool, cfg.Approvals.Timeout)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait for approval: %w", err)
+	}
+
+	approver := response.PersonEmail
+	if approver == "" {
+		approver = response.PersonID
+	}
+	decision, _ := response.Inputs["decision"].(string)
+	comment, _ := response.Inputs["comment"].(string)
+	switch decision {
+	case DecisionApprove:
+		replyTo(client, cfg.Approvals.Room, cardID, fmt.Sprintf("Approved by %s; running **%s**.", approver, tool))
+		return &Approval{
+			Tool:          tool,
+			ApprovedBy:    response.PersonID,
+			ApproverEmail: response.PersonEmail,
+			ApproverName:  response.PersonName,
+			ApprovedAt:    response.Created,
+			Comment:       comment,
+			CardMessageID: cardID,
+		}, nil
+	case DecisionDeny:
+		replyTo(client, cfg.Approvals.Room, cardID, fmt.Sprintf("Denied by %s; **%s** did not run.", approver, tool))
+		if comment != "" {
+			return nil, fmt.Errorf("%s was denied by %s: %s", tool, approver, comment)
+		}
+		return nil, fmt.Errorf("%s was denied by %s", tool, approver)
+	default:
+		return nil, fmt.Errorf("approval card for %s was answered with unknown decision %q", tool, decision)
+	}
+}
+
+// awaitApprover waits for an approver's answer on the card. The answer is
+// checked again here, so the decision never rests on anyone but an approver
+// having submitted this card.
+func awaitApprover(ctx context.Context, client webex.HTTPClient, cfg *config.Config, cardID string, approvers []string) (*cardresponse.Response, error) {
+	deadline := time.Now().Add(cfg.Approvals.Timeout)
+	for {
+		timeout := time.Until(deadline)
+		if timeout <= 0 {
+			return nil, cardresponse.ErrTimeout
+		}
+		response, err := cardresponse.Await(ctx, client, cardresponse.Options{
+			MessageID: cardID,
+			PersonIDs: approvers,
+			Timeout:   timeout,
+			Hub:       cardresponse.HubFor(cfg),
+			Progress: func(waited, _ time.Duration) {
+				ReportProgress(ctx, (cfg.Approvals.Timeout - timeout + waited).Seconds(), cfg.Approvals.Timeout.Seconds(), "Waiting for approval in Webex")
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if slices.Contains(approvers, response.PersonID) && response.MessageID == cardID {
+			return response, nil
+		}
+		log.Printf("[Approvals] Ignoring a response to approval card %s by %s, who is not an approver", cardID, response.PersonID)
+	}
+}
+
+// approverIDs turns the configured approvers, IDs or email addresses, into person IDs
+func approverIDs(client webex.HTTPClient, approvers []string) ([]string, error) {
+	ids := make([]string, 0, len(approvers))
+	for _, approver := range approvers {
+		if !strings.Contains(approver, "@") {
+			ids = append(ids, approver)
+			continue
+		}
+		id, err := names.PersonByEmail(client, approver)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up approver %s: %w", approver, err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// approvalCard shows the tool and its arguments with approve and deny buttons
+func approvalCard(tool string, args json.RawMessage, approvers []string) (cards.Element, error) {
+	var params map[string]interface{}
+	if len(args) > 0 {
+		if err := json.Unmarshal(args, &params); err != nil {
+			return nil, fmt.Errorf("failed to parse arguments: %w", err)
+		}
+	}
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	facts := []cards.Fact{{Title: "Tool", Value: tool}}
+	for _, key := range keys {
+		value, ok := params[key].(string)
+		if !ok {
+			data, _ := json.Marshal(params[key])
+			value = string(data)
+		}
+		if runes := []rune(value); len(runes) > maxFactLength {
+			value = string(runes[:maxFactLength]) + "…"
+		}
+		facts = append(facts, cards.Fact{Title: key, Value: value})
+	}
+
+	return cards.Spec{
+		Title:  "Approval needed",
+		Text:   fmt.Sprintf("An agent wants to run %s with the arguments below. Only %s can answer.", tool, strings.Join(approvers, ", ")),
+		Facts:  facts,
+		Inputs: []cards.InputSpec{{ID: "comment", Label: "Comment", Placeholder: "Optional", Multiline: true}},
+		Actions: []cards.ActionSpec{
+			{Title: "Approve", Data: map[string]interface{}{"decision": DecisionApprove}},
+			{Title: "Deny", Data: map[string]interface{}{"decision": DecisionDeny}},
+		},
+	}.Build()
+}
+
+// replyTo records the outcome under the approval card; a failure only loses the note
+func replyTo(client webex.HTTPClient, roomID, parentID, markdown string) {
+	if _, err := client.Post("/messages", map[string]interface{}{"roomId": roomID, "parentId": parentID, "markdown": markdown}); err != nil {
+		log.Printf("Failed to record the approval outcome: %v", err)
+	}
+}
@@ -0,0 +1,149 @@
+package tools
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/raja-aiml/webex-mcp-server/internal/config"
+	"github.com/raja-aiml/webex-mcp-server/internal/fakewebex"
+	"github.com/raja-aiml/webex-mcp-server/internal/testutil"
+	"github.com/raja-aiml/webex-mcp-server/internal/webex"
+	"github.com/raja-aiml/webex-mcp-server/internal/webhooks"
+)
+
+func TestWithApprovals(t *testing.T) {
+	fake, baseURL := testutil.NewFakeWebex(t)
+	room, _ := fake.Add(fakewebex.Rooms, fakewebex.Item{"title": "Approvals"})
+	alice, _ := fake.Add(fakewebex.People, fakewebex.Item{"emails": []interface{}{"alice@example.com"}, "displayName": "Alice"})
+	bob, _ := fake.Add(fakewebex.People, fakewebex.Item{"emails": []interface{}{"bob@example.com"}, "displayName": "Bob"})
+	roomID, aliceID, bobID := room["id"].(string), alice["id"].(string), bob["id"].(string)
+
+	// Card responses arrive through the webhook receiver, published here by hand
+	cleanups := []func(){
+		testutil.SetEnv(t, "WEBEX_PUBLIC_WORKSPACE_API_KEY", "token"),
+		testutil.SetEnv(t, "WEBEX_API_BASE_URL", baseURL),
+		testutil.SetEnv(t, "MCP_SERVER_MODE", "http"),
+		testutil.SetEnv(t, "WEBEX_WEBHOOK_PATH", "/webhooks/webex"),
+		testutil.SetEnv(t, "WEBEX_WEBHOOK_SECRET", "hook-secret"),
+		testutil.SetEnv(t, "WEBEX_APPROVALS_ROOM", roomID),
+		testutil.SetEnv(t, "WEBEX_APPROVALS_APPROVERS", "alice@example.com"),
+		testutil.SetEnv(t, "WEBEX_APPROVALS_TOOLS", "delete_a_room"),
+		testutil.SetEnv(t, "WEBEX_APPROVALS_TIMEOUT", "300ms"),
+	}
+	defer func() {
+		for _, cleanup := range cleanups {
+			cleanup()
+		}
+		config.ResetForTesting()
+		ResetDefaultClient()
+	}()
+	config.ResetForTesting()
+	ResetDefaultClient()
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	runs := 0
+	registry := NewRegistry()
+	schema := SimpleSchema("Delete a room.", map[string]*jsonschema.Schema{"roomId": StringProperty("Room")}, nil)
+	registry.Register(NewSimpleTool("delete_a_room", "Delete a room.", schema, func(params map[string]interface{}, client webex.HTTPClient) (interface{}, error) {
+		runs++
+		return "deleted", nil
+	}))
+	registry.Register(NewSimpleTool("list_rooms", "List rooms.", schema, func(params map[string]interface{}, client webex.HTTPClient) (interface{}, error) {
+		return "rooms", nil
+	}))
+	wrapped, err := WithApprovals(registry, cfg.Approvals)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tool, _ := wrapped.GetTool("list_rooms"); tool.Description() != "List rooms." {
+		t.Errorf("ungated tool description = %q", tool.Description())
+	}
+	gated, _ := wrapped.GetTool("delete_a_room")
+	if !strings.Contains(gated.Description(), "approves it in Webex") {
+		t.Errorf("gated tool description = %q", gated.Description())
+	}
+
+	// answer waits for a card posted after the first skip messages and submits it as each person in turn
+	answer := func(skip int, inputs ...map[string]interface{}) {
+		go func() {
+			var cardID string
+			for cardID == "" {
+				time.Sleep(5 * time.Millisecond)
+				for _, m := range fake.List(fakewebex.Messages)[skip:] {
+					if m["attachments"] != nil {
+						cardID = m["id"].(string)
+					}
+				}
+			}
+			for _, in := range inputs {
+				personID := in["as"].(string)
+				// A forged notification claims the submission came from someone else
+				claimed, ok := in["claim"].(string)
+				if !ok {
+					claimed = personID
+				}
+				delete(in, "as")
+				delete(in, "claim")
+				action, err := fake.SubmitAs(personID, cardID, in)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				webhooks.Default.Publish(webhooks.Notification{Resource: "attachmentActions", Event: "created", Data: map[string]interface{}{
+					"id": action["id"], "messageId": cardID, "personId": claimed,
+				}})
+			}
+		}()
+	}
+
+	tests := []struct {
+		name    string
+		inputs  []map[string]interface{}
+		wantErr string
+		wantRun bool
+	}{
+		{
+			name:    "approved after a non-approver answers",
+			inputs:  []map[string]interface{}{{"as": bobID, "decision": "approve"}, {"as": aliceID, "decision": "approve", "comment": "ok"}},
+			wantRun: true,
+		},
+		{name: "denied", inputs: []map[string]interface{}{{"as": aliceID, "decision": "deny", "comment": "not today"}}, wantErr: "delete_a_room was denied by alice@example.com: not today"},
+		{name: "nobody answers", inputs: []map[string]interface{}{{"as": bobID, "decision": "approve"}}, wantErr: "was not approved within 300ms"},
+		{
+			name:    "forged notification from a non-approver",
+			inputs:  []map[string]interface{}{{"as": bobID, "claim": aliceID, "decision": "approve"}},
+			wantErr: "was not approved within 300ms",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runs = 0
+			answer(len(fake.List(fakewebex.Messages)), tt.inputs...)
+			result, err := gated.Execute(json.RawMessage(`{"roomId":"R1"}`))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Execute() error = %v, want %q", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if (runs == 1) != tt.wantRun {
+				t.Errorf("tool ran %d times, want run = %v", runs, tt.wantRun)
+			}
+			if !tt.wantRun {
+				return
+			}
+			out := result.(map[string]interface{})
+			approval := out["approval"].(*Approval)
+			if out["result"] != "deleted" || approval.ApprovedBy != aliceID || approval.ApproverEmail != "alice@example.com" || approval.Comment != "ok" {
+				t.Errorf("result = %v, approval = %+v", out["result"], approval)
+			}
+		})
+	}
+}
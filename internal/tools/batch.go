@@ -0,0 +1,391 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/raja-aiml/webex-mcp-server/internal/config"
+	"github.com/raja-aiml/webex-mcp-server/internal/secrets"
+	"github.com/raja-aiml/webex-mcp-server/internal/webex"
+)
+
+// BatchToolName is the name of the batch tool
+const BatchToolName = "batch"
+
+const (
+	// DefaultBatchConcurrency is how many calls of a batch run at once unless the caller says otherwise
+	DefaultBatchConcurrency = 4
+	// MaxBatchConcurrency bounds the concurrency a caller can ask for
+	MaxBatchConcurrency = 10
+	// MaxBatchCalls bounds the calls in one batch
+	MaxBatchCalls = 5000
+	// batchAttempts is how often a call is tried when Webex asks to slow down
+	batchAttempts = 4
+	// maxRetryWait bounds a single pause, whatever Retry-After says
+	maxRetryWait = time.Minute
+)
+
+// retryBackoff is the pause after a retryable failure without a Retry-After hint
+var retryBackoff = time.Second
+
+// readOnlyPrefixes name the tools that only read from Webex, so a call that failed
+// with a server error can be sent again without doing anything twice
+var readOnlyPrefixes = []string{"list_", "get_", "search_"}
+
+// CallLimitFunc checks the server's rate limits for one call of tool. When the call
+// is not allowed it names the limit that was hit and how long until it is.
+type CallLimitFunc func(tool string) (scope string, wait time.Duration, ok bool)
+
+type callLimitKey struct{}
+
+// WithCallLimit returns a context whose batch calls are each checked against limit
+func WithCallLimit(ctx context.Context, limit CallLimitFunc) context.Context {
+	return context.WithValue(ctx, callLimitKey{}, limit)
+}
+
+// Statuses of a call in a batch
+const (
+	BatchStatusOK      = "ok"
+	BatchStatusError   = "error"
+	BatchStatusSkipped = "skipped" // Not run because the batch was cancelled
+)
+
+// BatchCall is one tool invocation in a batch
+type BatchCall struct {
+	ID        string                 `json:"id,omitempty"` // Caller's label, echoed in the result
+	Tool      string                 `json:"tool"`
+	Arguments map[string]interface{} `json:"arguments,omitempty"`
+}
+
+// AddMembers adds every email address to every room
+type AddMembers struct {
+	RoomIDs     []string `json:"roomIds,omitempty"`
+	RoomTitles  []string `json:"roomTitles,omitempty"`
+	Emails      []string `json:"emails"`
+	IsModerator bool     `json:"isModerator,omitempty"`
+}
+
+// BatchParams are the arguments of the batch tool
+type BatchParams struct {
+	Calls          []BatchCall `json:"calls,omitempty"`
+	AddMembers     *AddMembers `json:"addMembers,omitempty"`
+	Concurrency    int         `json:"concurrency,omitempty"`
+	IncludeResults *bool       `json:"includeResults,omitempty"`
+}
+
+// BatchItem is the outcome of one call
+type BatchItem struct {
+	Index    int         `json:"index"`
+	ID       string      `json:"id,omitempty"`
+	Tool     string      `json:"tool"`
+	Status   string      `json:"status"`
+	Attempts int         `json:"attempts,omitempty"`
+	Result   interface{} `json:"result,omitempty"`
+	Error    string      `json:"error,omitempty"`
+}
+
+// BatchResult reports every call, and the failed ones in a form that can be sent again
+type BatchResult struct {
+	Total     int         `json:"total"`
+	Succeeded int         `json:"succeeded"`
+	Failed    int         `json:"failed"`
+	Skipped   int         `json:"skipped,omitempty"`
+	Items     []BatchItem `json:"items"`
+	Retry     []BatchCall `json:"retry,omitempty"` // Failed and skipped calls, to pass back as calls
+}
+
+// NewBatchTool creates the batch tool. Calls run through the tools the server is
+// configured with, so tool selection, profiles and approvals apply to each of them.
+func NewBatchTool() Tool {
+	call := ObjectProperty("A tool invocation.", map[string]*jsonschema.Schema{
+		"id":        StringProperty("Optional label echoed in the result."),
+		"tool":      RequiredStringProperty("Name of the tool to call."),
+		"arguments": ObjectProperty("Arguments of the tool, as they would be passed to it directly.", nil),
+	})
+	call.Required = []string{"tool"}
+	properties := map[string]*jsonschema.Schema{
+		"calls": ArrayProperty(fmt.Sprintf("Tool invocations to run, up to %d.", MaxBatchCalls), call),
+		"addMembers": ObjectProperty("Add every email address to every room, one create_a_membership call each.", map[string]*jsonschema.Schema{
+			"roomIds":     ArrayProperty("Room IDs.", StringProperty("Room ID.")),
+			"roomTitles":  ArrayProperty("Room titles, instead of or as well as IDs.", StringProperty("Room title.")),
+			"emails":      ArrayProperty("Email addresses of the people to add.", StringProperty("Email address.")),
+			"isModerator": BooleanProperty("Make them moderators."),
+		}),
+		"concurrency":    IntegerProperty(fmt.Sprintf("Calls run at once, default %d, at most %d.", DefaultBatchConcurrency, MaxBatchConcurrency)),
+		"includeResults": BooleanProperty("Return each call's result. Default true; set false for large batches to return only statuses."),
+	}
+	description := "Run many tool calls, or add many people to many rooms, with bounded concurrency. " +
+		"Each call counts against the server's rate limits as if it were made directly. " +
+		"When Webex rate limits a call the batch slows down and retries it; server errors are only retried for list_, get_ and search_ tools. " +
+		"Returns each call's status and a retry list holding only the calls that failed."
+	return &batchTool{ToolBase: NewToolBase(BatchToolName, description, SimpleSchema(description, properties, nil))}
+}
+
+// batchTool runs the calls of a batch through the configured registry. It does
+// not take a client of its own, so profiles are chosen per call.
+type batchTool struct {
+	ToolBase
+}
+
+// Execute implements the Tool interface
+func (t *batchTool) Execute(args json.RawMessage) (interface{}, error) {
+	return t.ExecuteContext(context.Background(), args)
+}
+
+// ExecuteWithMap implements the Tool interface
+func (t *batchTool) ExecuteWithMap(args map[string]interface{}) (interface{}, error) {
+	return ExecuteWithMapBase(t, args)
+}
+
+// ExecuteContext runs the batch, reporting progress as calls finish
+func (t *batchTool) ExecuteContext(ctx context.Context, args json.RawMessage) (interface{}, error) {
+	var params BatchParams
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, fmt.Errorf("invalid arguments format: %w. Please check the tool schema for required fields", err)
+	}
+	calls := append(params.Calls, params.AddMembers.calls()...)
+	if len(calls) == 0 {
+		return nil, fmt.Errorf("batch needs calls or addMembers")
+	}
+	if len(calls) > MaxBatchCalls {
+		return nil, fmt.Errorf("batch has %d calls, more than the %d allowed; split it", len(calls), MaxBatchCalls)
+	}
+	concurrency := params.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultBatchConcurrency
+	}
+	concurrency = min(concurrency, MaxBatchConcurrency, len(calls))
+
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	registry, err := LoadConfiguredTools(cfg)
+	if err != nil {
+		return nil, err
+	}
+	// Every tool is checked before any call runs, so a typo does not leave a batch half done
+	var unknown []string
+	for _, call := range calls {
+		if call.Tool == BatchToolName {
+			return nil, fmt.Errorf("batches cannot be nested")
+		}
+		if _, ok := registry.GetTool(call.Tool); !ok && !containsName(unknown, call.Tool) {
+			unknown = append(unknown, call.Tool)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("unknown or disabled tools in batch: %s", strings.Join(unknown, ", "))
+	}
+
+	items := runBatch(ctx, registry, calls, concurrency)
+
+	result := &BatchResult{Total: len(items), Items: items}
+	for i, item := range items {
+		switch item.Status {
+		case BatchStatusOK:
+			result.Succeeded++
+		case BatchStatusError:
+			result.Failed++
+		case BatchStatusSkipped:
+			result.Skipped++
+		}
+		if item.Status != BatchStatusOK {
+			result.Retry = append(result.Retry, calls[i])
+		}
+		if params.IncludeResults != nil && !*params.IncludeResults {
+			result.Items[i].Result = nil
+		}
+	}
+	return result, nil
+}
+
+// calls expands the request into one create_a_membership call per room and email
+func (m *AddMembers) calls() []BatchCall {
+	if m == nil {
+		return nil
+	}
+	var calls []BatchCall
+	rooms := func(field string, values []string) {
+		for _, room := range values {
+			for _, email := range m.Emails {
+				args := map[string]interface{}{field: room, "personEmail": email}
+				if m.IsModerator {
+					args["isModerator"] = true
+				}
+				calls = append(calls, BatchCall{ID: room + " " + email, Tool: "create_a_membership", Arguments: args})
+			}
+		}
+	}
+	rooms("roomId", m.RoomIDs)
+	rooms("roomTitle", m.RoomTitles)
+	return calls
+}
+
+// runBatch runs calls with at most concurrency at once and returns their outcomes in order
+func runBatch(ctx context.Context, registry *Registry, calls []BatchCall, concurrency int) []BatchItem {
+	items := make([]BatchItem, len(calls))
+	indexes := make(chan int)
+	gate := &retryGate{}
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		done int
+	)
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				items[i] = runCall(ctx, registry, gate, i, calls[i])
+				mu.Lock()
+				done++
+				ReportProgress(ctx, float64(done), float64(len(calls)), fmt.Sprintf("%d of %d calls done", done, len(calls)))
+				mu.Unlock()
+			}
+		}()
+	}
+	for i := range calls {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return items
+}
+
+// runCall runs one call once the server's rate limits allow it, retrying while
+// Webex asks to slow down
+func runCall(ctx context.Context, registry *Registry, gate *retryGate, index int, call BatchCall) BatchItem {
+	item := BatchItem{Index: index, ID: call.ID, Tool: call.Tool}
+	tool, _ := registry.GetTool(call.Tool)
+	args, err := json.Marshal(call.Arguments)
+	if err != nil {
+		item.Status, item.Error = BatchStatusError, fmt.Sprintf("failed to marshal arguments: %v", err)
+		return item
+	}
+	if call.Arguments == nil {
+		args = json.RawMessage("{}")
+	}
+
+	for {
+		if err := gate.wait(ctx); err != nil {
+			item.Status, item.Error = BatchStatusSkipped, fmt.Sprintf("not run: %v", err)
+			if item.Attempts > 0 {
+				item.Status = BatchStatusError
+			}
+			return item
+		}
+		if limit, ok := ctx.Value(callLimitKey{}).(CallLimitFunc); ok && limit != nil {
+			if scope, wait, ok := limit(call.Tool); !ok {
+				if wait > maxRetryWait {
+					item.Status, item.Error = BatchStatusError, fmt.Sprintf("rate limit exceeded for %s; retry in %s", scope, wait.Round(time.Second))
+					return item
+				}
+				gate.pause(wait)
+				continue
+			}
+		}
+		item.Attempts++
+		var result interface{}
+		if executor, ok := tool.(ContextExecutor); ok {
+			result, err = executor.ExecuteContext(ctx, args)
+		} else {
+			result, err = tool.Execute(args)
+		}
+		if err == nil {
+			item.Status, item.Result = BatchStatusOK, result
+			return item
+		}
+		wait, retryable := retryAfter(tool, err, item.Attempts)
+		if !retryable || item.Attempts >= batchAttempts {
+			item.Status, item.Error = BatchStatusError, secrets.Redact(err.Error())
+			return item
+		}
+		gate.pause(wait)
+	}
+}
+
+// retryAfter reports whether err means Webex wants the call again later, and how
+// much later. Only failures that left nothing done are retried: a call that was
+// never sent or was rate limited, or a server error from a tool that only reads.
+// Tools that need approval are never retried, as that would ask the approvers again.
+func retryAfter(tool Tool, err error, attempt int) (time.Duration, bool) {
+	if _, ok := tool.(*approvalTool); ok {
+		return 0, false
+	}
+	wait := time.Duration(attempt) * retryBackoff
+	var busy *webex.BusyError
+	if errors.As(err, &busy) {
+		return min(max(busy.RetryAfter, wait), maxRetryWait), true
+	}
+	var apiErr *webex.APIError
+	if !errors.As(err, &apiErr) {
+		return 0, false
+	}
+	switch apiErr.StatusCode {
+	case http.StatusTooManyRequests:
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		if !readOnly(tool.Name()) {
+			return 0, false
+		}
+	default:
+		return 0, false
+	}
+	if apiErr.RetryAfter > 0 {
+		wait = apiErr.RetryAfter
+	}
+	return min(wait, maxRetryWait), true
+}
+
+// readOnly reports whether the tool called name only reads from Webex
+func readOnly(name string) bool {
+	for _, prefix := range readOnlyPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// retryGate holds every worker of a batch back once Webex asks one of them to slow down
+type retryGate struct {
+	mu    sync.Mutex
+	until time.Time
+}
+
+// pause keeps calls from starting for d
+func (g *retryGate) pause(d time.Duration) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if until := time.Now().Add(d); until.After(g.until) {
+		g.until = until
+	}
+}
+
+// wait blocks until the gate is open or ctx is done
+func (g *retryGate) wait(ctx context.Context) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		g.mu.Lock()
+		d := time.Until(g.until)
+		g.mu.Unlock()
+		if d <= 0 {
+			return nil
+		}
+		select {
+		case <-time.After(d):
+		case <-ctx.Done():
+		}
+	}
+}
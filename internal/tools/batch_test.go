@@ -0,0 +1,207 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/raja-aiml/webex-mcp-server/internal/config"
+	"github.com/raja-aiml/webex-mcp-server/internal/fakewebex"
+	"github.com/raja-aiml/webex-mcp-server/internal/testutil"
+)
+
+func TestBatchTool(t *testing.T) {
+	fake, baseURL := testutil.NewFakeWebex(t)
+	room, _ := fake.Add(fakewebex.Rooms, fakewebex.Item{"title": "Launch"})
+	roomID := room["id"].(string)
+
+	cleanups := []func(){
+		testutil.SetEnv(t, "WEBEX_PUBLIC_WORKSPACE_API_KEY", "token"),
+		testutil.SetEnv(t, "WEBEX_API_BASE_URL", baseURL),
+	}
+	backoff := retryBackoff
+	retryBackoff = time.Millisecond
+	defer func() {
+		for _, cleanup := range cleanups {
+			cleanup()
+		}
+		retryBackoff = backoff
+		config.ResetForTesting()
+		ResetDefaultClient()
+	}()
+	config.ResetForTesting()
+	ResetDefaultClient()
+
+	message := func(text string) BatchCall {
+		return BatchCall{ID: text, Tool: "create_a_message", Arguments: map[string]interface{}{"roomId": roomID, "text": text}}
+	}
+	tests := []struct {
+		name          string
+		params        BatchParams
+		cancelled     bool
+		limit         CallLimitFunc
+		fault         *fakewebex.Fault
+		wantErr       string
+		wantOK        int
+		wantFailed    int
+		wantSkipped   int
+		wantAttempts  int
+		wantRetryIDs  []string
+		wantNoResults bool
+	}{
+		{
+			name:         "rate limited calls are retried",
+			params:       BatchParams{Calls: []BatchCall{message("one"), message("two"), message("three")}, Concurrency: 2},
+			fault:        &fakewebex.Fault{Path: "/messages", Status: http.StatusTooManyRequests, Times: 2},
+			wantOK:       3,
+			wantAttempts: 5,
+		},
+		{
+			name:         "server errors are not retried for tools that write",
+			params:       BatchParams{Calls: []BatchCall{message("once")}},
+			fault:        &fakewebex.Fault{Path: "/messages", Status: http.StatusServiceUnavailable, Times: 1},
+			wantFailed:   1,
+			wantAttempts: 1,
+			wantRetryIDs: []string{"once"},
+		},
+		{
+			name:         "server errors are retried for tools that only read",
+			params:       BatchParams{Calls: []BatchCall{{ID: "rooms", Tool: "list_rooms"}}},
+			fault:        &fakewebex.Fault{Path: "/rooms", Status: http.StatusServiceUnavailable, Times: 1},
+			wantOK:       1,
+			wantAttempts: 2,
+		},
+		{
+			name:         "calls wait for the server's rate limits",
+			params:       BatchParams{Calls: []BatchCall{message("slow"), message("slower")}},
+			limit:        limitTimes(3, time.Millisecond),
+			wantOK:       2,
+			wantAttempts: 2,
+		},
+		{
+			name:         "calls fail when the server's rate limit is far off",
+			params:       BatchParams{Calls: []BatchCall{message("never")}},
+			limit:        limitTimes(1, time.Hour),
+			wantFailed:   1,
+			wantRetryIDs: []string{"never"},
+		},
+		{
+			name: "only failures are returned for retry",
+			params: BatchParams{Calls: []BatchCall{
+				message("ok"),
+				{ID: "no room", Tool: "create_a_message", Arguments: map[string]interface{}{"text": "lost"}},
+				{Tool: "list_rooms"},
+			}},
+			wantOK:       2,
+			wantFailed:   1,
+			wantAttempts: 3,
+			wantRetryIDs: []string{"no room"},
+		},
+		{
+			name:          "results left out on request",
+			params:        BatchParams{Calls: []BatchCall{message("quiet")}, IncludeResults: new(bool)},
+			wantOK:        1,
+			wantAttempts:  1,
+			wantNoResults: true,
+		},
+		{
+			name:         "cancelled batch skips its calls",
+			params:       BatchParams{Calls: []BatchCall{message("late"), message("later")}},
+			cancelled:    true,
+			wantSkipped:  2,
+			wantRetryIDs: []string{"late", "later"},
+		},
+		{name: "empty", params: BatchParams{}, wantErr: "needs calls or addMembers"},
+		{
+			name:    "tools that are not enabled",
+			params:  BatchParams{AddMembers: &AddMembers{RoomTitles: []string{"Launch"}, Emails: []string{"alice@example.com"}}, Calls: []BatchCall{{Tool: "nope"}}},
+			wantErr: "unknown or disabled tools in batch: create_a_membership, nope",
+		},
+		{name: "nested", params: BatchParams{Calls: []BatchCall{{Tool: BatchToolName}}}, wantErr: "cannot be nested"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.fault != nil {
+				fake.InjectFault(*tt.fault)
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.cancelled {
+				cancel()
+			}
+			if tt.limit != nil {
+				ctx = WithCallLimit(ctx, tt.limit)
+			}
+			args, _ := json.Marshal(tt.params)
+
+			out, err := NewBatchTool().(ContextExecutor).ExecuteContext(ctx, args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("ExecuteContext() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			result := out.(*BatchResult)
+			if result.Succeeded != tt.wantOK || result.Failed != tt.wantFailed || result.Skipped != tt.wantSkipped || result.Total != len(tt.params.Calls) {
+				t.Errorf("result = %+v", result)
+			}
+			attempts := 0
+			for i, item := range result.Items {
+				attempts += item.Attempts
+				if item.Index != i || item.ID != tt.params.Calls[i].ID {
+					t.Errorf("item %d = %+v, out of order", i, item)
+				}
+				if item.Status == BatchStatusOK && (item.Result == nil) != tt.wantNoResults {
+					t.Errorf("item %d result = %v", i, item.Result)
+				}
+			}
+			if attempts != tt.wantAttempts {
+				t.Errorf("%d attempts, want %d", attempts, tt.wantAttempts)
+			}
+			var retryIDs []string
+			for _, call := range result.Retry {
+				retryIDs = append(retryIDs, call.ID)
+			}
+			if strings.Join(retryIDs, ",") != strings.Join(tt.wantRetryIDs, ",") {
+				t.Errorf("retry = %v, want %v", retryIDs, tt.wantRetryIDs)
+			}
+		})
+	}
+}
+
+// limitTimes refuses the first n calls, asking them to wait for wait
+func limitTimes(n int, wait time.Duration) CallLimitFunc {
+	var mu sync.Mutex
+	return func(tool string) (string, time.Duration, bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		if n == 0 {
+			return "", 0, true
+		}
+		n--
+		return "tool " + tool, wait, false
+	}
+}
+
+func TestAddMembersCalls(t *testing.T) {
+	calls := (&AddMembers{
+		RoomIDs:     []string{"R1"},
+		RoomTitles:  []string{"Launch"},
+		Emails:      []string{"a@example.com", "b@example.com"},
+		IsModerator: true,
+	}).calls()
+	if len(calls) != 4 {
+		t.Fatalf("%d calls, want 4", len(calls))
+	}
+	last := calls[3]
+	if last.Tool != "create_a_membership" || last.Arguments["roomTitle"] != "Launch" || last.Arguments["personEmail"] != "b@example.com" || last.Arguments["isModerator"] != true {
+		t.Errorf("last call = %+v", last)
+	}
+}
@@ -1,6 +1,7 @@
 package tools
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strconv"
@@ -12,30 +13,119 @@ import (
 // GenericTool provides a generic implementation for tools
 type GenericTool[T any] struct {
 	ToolBase
-	executor func(*T, webex.HTTPClient) (interface{}, error)
+	executor        func(*T, webex.HTTPClient) (interface{}, error)
+	contextExecutor func(context.Context, *T, webex.HTTPClient) (interface{}, error) // Used instead of executor when set
+	lookups         []nameLookup
 }
 
-// NewGenericTool creates a new generic tool
+// NewGenericTool creates a new generic tool. Arguments naming a room or person,
+// such as roomTitle, are added for its ID arguments.
 func NewGenericTool[T any](name, description string, schema *jsonschema.Schema, executor func(*T, webex.HTTPClient) (interface{}, error)) *GenericTool[T] {
+	schema, lookups := withNameLookups(schema)
 	return &GenericTool[T]{
 		ToolBase: NewToolBase(name, description, schema),
 		executor: executor,
+		lookups:  lookups,
 	}
 }
 
+// NewContextTool creates a generic tool whose executor receives the context of
+// the call, for tools that wait and should stop when the caller goes away
+func NewContextTool[T any](name, description string, schema *jsonschema.Schema, executor func(context.Context, *T, webex.HTTPClient) (interface{}, error)) *GenericTool[T] {
+	schema, lookups := withNameLookups(schema)
+	return &GenericTool[T]{
+		ToolBase:        NewToolBase(name, description, schema),
+		contextExecutor: executor,
+		lookups:         lookups,
+	}
+}
+
+// ClientExecutor is implemented by tools that can run against a caller-supplied client,
+// which is how a call selects a Webex profile
+type ClientExecutor interface {
+	ExecuteWithClient(args json.RawMessage, client webex.HTTPClient) (interface{}, error)
+}
+
+// ContextExecutor is implemented by tools that take the context of the call,
+// which carries cancellation and progress reporting
+type ContextExecutor interface {
+	ExecuteContext(ctx context.Context, args json.RawMessage) (interface{}, error)
+}
+
+// clientContextExecutor runs with both a caller-supplied client and the call's context
+type clientContextExecutor interface {
+	ExecuteWithClientContext(ctx context.Context, args json.RawMessage, client webex.HTTPClient) (interface{}, error)
+}
+
 // Execute implements the Tool interface
 func (t *GenericTool[T]) Execute(args json.RawMessage) (interface{}, error) {
+	return t.ExecuteContext(context.Background(), args)
+}
+
+// ExecuteContext runs the tool with its own client
+func (t *GenericTool[T]) ExecuteContext(ctx context.Context, args json.RawMessage) (interface{}, error) {
+	params, err := t.parseArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := t.ensureClient()
+	if err != nil {
+		return nil, fmt.Errorf("service initialization failed: %w. Please check your API credentials", err)
+	}
+
+	if params, err = t.resolveNames(args, params, client); err != nil {
+		return nil, err
+	}
+	return t.run(ctx, params, client)
+}
+
+// ExecuteWithClient runs the tool with the given client instead of its own
+func (t *GenericTool[T]) ExecuteWithClient(args json.RawMessage, client webex.HTTPClient) (interface{}, error) {
+	return t.ExecuteWithClientContext(context.Background(), args, client)
+}
+
+// ExecuteWithClientContext runs the tool with the given client and the call's context
+func (t *GenericTool[T]) ExecuteWithClientContext(ctx context.Context, args json.RawMessage, client webex.HTTPClient) (interface{}, error) {
+	params, err := t.parseArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	if params, err = t.resolveNames(args, params, client); err != nil {
+		return nil, err
+	}
+	return t.run(ctx, params, client)
+}
+
+// resolveNames parses the arguments again once names in them are replaced by IDs
+func (t *GenericTool[T]) resolveNames(args json.RawMessage, params *T, client webex.HTTPClient) (*T, error) {
+	if len(t.lookups) == 0 {
+		return params, nil
+	}
+	resolved, err := resolveNamesJSON(t.lookups, args, client)
+	if err != nil {
+		return nil, fmt.Errorf("%s failed: %w", t.name, err)
+	}
+	return t.parseArgs(resolved)
+}
+
+func (t *GenericTool[T]) parseArgs(args json.RawMessage) (*T, error) {
 	var params T
 	if err := json.Unmarshal(args, &params); err != nil {
 		// Provide more helpful error message
 		return nil, fmt.Errorf("invalid arguments format: %w. Please check the tool schema for required fields", err)
 	}
+	return &params, nil
+}
 
-	if err := t.ensureClient(); err != nil {
-		return nil, fmt.Errorf("service initialization failed: %w. Please check your API credentials", err)
+func (t *GenericTool[T]) run(ctx context.Context, params *T, client webex.HTTPClient) (interface{}, error) {
+	var result interface{}
+	var err error
+	if t.contextExecutor != nil {
+		result, err = t.contextExecutor(ctx, params, client)
+	} else {
+		result, err = t.executor(params, client)
 	}
-
-	result, err := t.executor(&params, t.client)
 	if err != nil {
 		// Wrap errors with more context
 		return nil, fmt.Errorf("%s failed: %w", t.name, err)
@@ -53,29 +143,57 @@ func (t *GenericTool[T]) ExecuteWithMap(args map[string]interface{}) (interface{
 type SimpleTool struct {
 	ToolBase
 	executor func(map[string]interface{}, webex.HTTPClient) (interface{}, error)
+	lookups  []nameLookup
 }
 
-// NewSimpleTool creates a new simple tool
+// NewSimpleTool creates a new simple tool. Like NewGenericTool, it accepts names
+// in place of its ID arguments.
 func NewSimpleTool(name, description string, schema *jsonschema.Schema, executor func(map[string]interface{}, webex.HTTPClient) (interface{}, error)) *SimpleTool {
+	schema, lookups := withNameLookups(schema)
 	return &SimpleTool{
 		ToolBase: NewToolBase(name, description, schema),
 		executor: executor,
+		lookups:  lookups,
 	}
 }
 
 // Execute implements the Tool interface
 func (t *SimpleTool) Execute(args json.RawMessage) (interface{}, error) {
+	params, err := t.parseArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := t.ensureClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize client: %w", err)
+	}
+	if err := resolveNames(t.lookups, params, client); err != nil {
+		return nil, err
+	}
+	return t.executor(params, client)
+}
+
+// ExecuteWithClient runs the tool with the given client instead of its own
+func (t *SimpleTool) ExecuteWithClient(args json.RawMessage, client webex.HTTPClient) (interface{}, error) {
+	params, err := t.parseArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	if err := resolveNames(t.lookups, params, client); err != nil {
+		return nil, err
+	}
+	return t.executor(params, client)
+}
+
+func (t *SimpleTool) parseArgs(args json.RawMessage) (map[string]interface{}, error) {
 	var params map[string]interface{}
 	if len(args) > 0 {
 		if err := json.Unmarshal(args, &params); err != nil {
 			return nil, fmt.Errorf("failed to parse arguments: %w", err)
 		}
 	}
-
-	if err := t.ensureClient(); err != nil {
-		return nil, fmt.Errorf("failed to initialize client: %w", err)
-	}
-	return t.executor(params, t.client)
+	return params, nil
 }
 
 // ExecuteWithMap implements the Tool interface
@@ -260,7 +378,8 @@ func NewDeleteTool(name, description, endpoint, idField, idDescription string) T
 		idField: StringProperty(idDescription),
 	}, []string{idField})
 
-	return NewSimpleTool(name, description, schema, func(params map[string]interface{}, client webex.HTTPClient) (interface{}, error) {
+	// Deletes take IDs only, so a loose name match can never delete the wrong item
+	return &SimpleTool{ToolBase: NewToolBase(name, description, schema), executor: func(params map[string]interface{}, client webex.HTTPClient) (interface{}, error) {
 		id, ok := params[idField]
 		if !ok || id == nil {
 			return nil, fmt.Errorf("%s is required", idField)
@@ -276,5 +395,5 @@ func NewDeleteTool(name, description, endpoint, idField, idDescription string) T
 			return nil, err
 		}
 		return map[string]interface{}{"success": true}, nil
-	})
+	}}
 }
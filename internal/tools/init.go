@@ -8,24 +8,29 @@ import (
 	"github.com/raja-aiml/webex-mcp-server/internal/webex"
 )
 
+// clientMu guards the default client and the error building it, which tool calls
+// read while a reload replaces them
 var (
+	clientMu      sync.Mutex
 	defaultClient webex.HTTPClient
-	clientOnce    sync.Once
 	clientErr     error
+	clientBuilt   bool // Whether defaultClient and clientErr hold a build result
 )
 
 // InitializeDefaultClient initializes the default HTTP client
 // This should be called at application startup
 func InitializeDefaultClient() error {
-	clientOnce.Do(func() {
-		cfg, err := config.Load()
-		if err != nil {
-			clientErr = err
-			return
-		}
-		defaultClient, clientErr = webex.NewClientWithConfig(cfg)
-	})
-	return clientErr
+	_, err := defaultClientState()
+	return err
+}
+
+// ResetDefaultClient discards the default and per-profile clients so the next use builds
+// them from the current configuration. Called after a configuration reload.
+func ResetDefaultClient() {
+	clientMu.Lock()
+	defaultClient, clientErr, clientBuilt = nil, nil, false
+	clientMu.Unlock()
+	resetProfileClients()
 }
 
 // MustInitializeDefaultClient initializes the default client and panics on error
@@ -37,10 +42,26 @@ func MustInitializeDefaultClient() {
 
 // getDefaultClient returns the default client or an error if not initialized
 func getDefaultClient() (webex.HTTPClient, error) {
-	if defaultClient == nil {
-		if err := InitializeDefaultClient(); err != nil {
-			return nil, fmt.Errorf("failed to initialize client: %w", err)
+	client, err := defaultClientState()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize client: %w", err)
+	}
+	return client, nil
+}
+
+// defaultClientState returns the default client and the error building it, both
+// read in one step, building the client first if a reload discarded it
+func defaultClientState() (webex.HTTPClient, error) {
+	clientMu.Lock()
+	defer clientMu.Unlock()
+	if !clientBuilt {
+		cfg, err := config.Load()
+		if err == nil {
+			defaultClient, clientErr = webex.NewClientWithConfig(cfg)
+		} else {
+			defaultClient, clientErr = nil, err
 		}
+		clientBuilt = true
 	}
-	return defaultClient, nil
+	return defaultClient, clientErr
 }
@@ -1,12 +1,15 @@
 package tools
 
 import (
+	"encoding/json"
 	"os"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/raja-aiml/webex-mcp-server/internal/config"
 	"github.com/raja-aiml/webex-mcp-server/internal/testutil"
+	"github.com/raja-aiml/webex-mcp-server/internal/webex"
 )
 
 func TestMustInitializeDefaultClient(t *testing.T) {
@@ -35,11 +38,7 @@ func TestMustInitializeDefaultClient_Success(t *testing.T) {
 
 	// Reset config and client
 	config.ResetForTesting()
-	defaultClient = nil
-	clientOnce = sync.Once{}
-	clientErr = nil
-	clientOnce = sync.Once{}
-	clientErr = nil
+	ResetDefaultClient()
 
 	// Set up valid environment
 	os.Setenv("WEBEX_PUBLIC_WORKSPACE_API_KEY", "test-key")
@@ -51,13 +50,7 @@ func TestMustInitializeDefaultClient_Success(t *testing.T) {
 			os.Unsetenv("WEBEX_PUBLIC_WORKSPACE_API_KEY")
 		}
 		config.ResetForTesting()
-		defaultClient = nil
-		clientOnce = sync.Once{}
-		clientErr = nil
-		clientOnce = sync.Once{}
-		clientErr = nil
-		clientOnce = sync.Once{}
-		clientErr = nil
+		ResetDefaultClient()
 	}()
 
 	// Should not panic with valid config
@@ -69,7 +62,7 @@ func TestMustInitializeDefaultClient_Success(t *testing.T) {
 
 	MustInitializeDefaultClient()
 
-	if defaultClient == nil {
+	if client, _ := getDefaultClient(); client == nil {
 		t.Error("MustInitializeDefaultClient() did not initialize default client")
 	}
 }
@@ -77,20 +70,14 @@ func TestMustInitializeDefaultClient_Success(t *testing.T) {
 func TestInitializeDefaultClient_Error(t *testing.T) {
 	// Reset config and client
 	config.ResetForTesting()
-	defaultClient = nil
-	clientOnce = sync.Once{}
-	clientErr = nil
+	ResetDefaultClient()
 
 	// Test without API key
 	cleanup := testutil.SetEnv(t, "WEBEX_PUBLIC_WORKSPACE_API_KEY", "")
 	defer func() {
 		cleanup()
 		config.ResetForTesting()
-		defaultClient = nil
-		clientOnce = sync.Once{}
-		clientErr = nil
-		clientOnce = sync.Once{}
-		clientErr = nil
+		ResetDefaultClient()
 	}()
 
 	err := InitializeDefaultClient()
@@ -101,9 +88,7 @@ func TestInitializeDefaultClient_Error(t *testing.T) {
 
 func TestGetDefaultClient_Error(t *testing.T) {
 	// Reset client
-	defaultClient = nil
-	clientOnce = sync.Once{}
-	clientErr = nil
+	ResetDefaultClient()
 	config.ResetForTesting()
 
 	// Test without API key
@@ -111,11 +96,7 @@ func TestGetDefaultClient_Error(t *testing.T) {
 	defer func() {
 		cleanup()
 		config.ResetForTesting()
-		defaultClient = nil
-		clientOnce = sync.Once{}
-		clientErr = nil
-		clientOnce = sync.Once{}
-		clientErr = nil
+		ResetDefaultClient()
 	}()
 
 	_, err := getDefaultClient()
@@ -123,3 +104,68 @@ func TestGetDefaultClient_Error(t *testing.T) {
 		t.Error("getDefaultClient() should return error when client not initialized")
 	}
 }
+
+func TestResetDefaultClientDuringCalls(t *testing.T) {
+	_, baseURL := testutil.NewFakeWebex(t)
+	cleanups := []func(){
+		testutil.SetEnv(t, "WEBEX_PUBLIC_WORKSPACE_API_KEY", "token"),
+		testutil.SetEnv(t, "WEBEX_API_BASE_URL", baseURL),
+	}
+	defer func() {
+		for _, cleanup := range cleanups {
+			cleanup()
+		}
+		config.ResetForTesting()
+		ResetDefaultClient()
+	}()
+	config.ResetForTesting()
+	ResetDefaultClient()
+
+	tool := NewGenericTool("list_rooms", "List rooms.", SimpleSchema("List rooms.", nil, nil),
+		func(params *struct{}, client webex.HTTPClient) (interface{}, error) {
+			return client.Get("/rooms", nil)
+		})
+
+	// Reloads keep discarding the clients while calls are using them
+	stop := make(chan struct{})
+	reloaded := make(chan struct{})
+	go func() {
+		defer close(reloaded)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				if _, err := config.Reload(); err != nil {
+					t.Errorf("Reload() error = %v", err)
+				}
+				ResetDefaultClient()
+				time.Sleep(time.Millisecond)
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 25; i++ {
+				client, err := ClientFor("")
+				if err != nil || client == nil {
+					t.Errorf("ClientFor() = %v, %v", client, err)
+					return
+				}
+				if _, err := client.Get("/rooms", nil); err != nil {
+					t.Errorf("Get() error = %v", err)
+				}
+				if _, err := tool.Execute(json.RawMessage(`{}`)); err != nil {
+					t.Errorf("Execute() error = %v", err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(stop)
+	<-reloaded
+}
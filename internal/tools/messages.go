@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/raja-aiml/webex-mcp-server/internal/cards"
 	"github.com/raja-aiml/webex-mcp-server/internal/webex"
 )
 
@@ -63,8 +64,8 @@ func NewCreateMessageTool() Tool {
 	attachmentSchema := &jsonschema.Schema{
 		Type: "object",
 		Properties: map[string]*jsonschema.Schema{
-			"contentType": StringProperty("The content type of the attachment."),
-			"content":     ObjectProperty("The content of the attachment.", map[string]*jsonschema.Schema{}),
+			"contentType": StringProperty("The content type of the attachment: " + cards.ContentType + "."),
+			"content":     ObjectProperty("The content of the attachment: an Adaptive Card up to version "+cards.Version+". send_card builds and checks cards.", map[string]*jsonschema.Schema{}),
 		},
 	}
 
@@ -123,11 +124,37 @@ func NewCreateMessageTool() Tool {
 			if !hasText && !hasMarkdown && !hasHtml && !hasFiles && !hasAttachments {
 				return nil, fmt.Errorf("at least one of text, markdown, html, files, or attachments is required")
 			}
+			if hasAttachments {
+				if err := validateAttachments((*params)["attachments"]); err != nil {
+					return nil, err
+				}
+			}
 
 			return client.Post("/messages", *params)
 		})
 }
 
+// validateAttachments rejects Adaptive Cards that Webex would not render
+func validateAttachments(value interface{}) error {
+	attachments, ok := value.([]interface{})
+	if !ok {
+		return fmt.Errorf("attachments must be an array")
+	}
+	for i, item := range attachments {
+		attachment, ok := item.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("attachments[%d] must be an object", i)
+		}
+		if attachment["contentType"] != cards.ContentType {
+			continue
+		}
+		if err := cards.Validate(attachment["content"]); err != nil {
+			return fmt.Errorf("attachments[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
 // NewGetMessageDetailsTool gets details of a specific message
 func NewGetMessageDetailsTool() Tool {
 	return NewGetTool(
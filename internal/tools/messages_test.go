@@ -1,10 +1,15 @@
 package tools
 
 import (
+	"encoding/json"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/raja-aiml/webex-mcp-server/internal/config"
+	"github.com/raja-aiml/webex-mcp-server/internal/fakewebex"
 	"github.com/raja-aiml/webex-mcp-server/internal/testutil"
+	"github.com/raja-aiml/webex-mcp-server/internal/webex"
 )
 
 func TestNewCreateMessageTool(t *testing.T) {
@@ -14,7 +19,7 @@ func TestNewCreateMessageTool(t *testing.T) {
 	defer func() {
 		cleanup()
 		config.ResetForTesting()
-		defaultClient = nil
+		ResetDefaultClient()
 	}()
 
 	// Initialize default client
@@ -39,7 +44,7 @@ func TestNewListDirectMessagesTool(t *testing.T) {
 	defer func() {
 		cleanup()
 		config.ResetForTesting()
-		defaultClient = nil
+		ResetDefaultClient()
 	}()
 
 	// Initialize default client
@@ -140,3 +145,45 @@ func TestQueryParamsFunction(t *testing.T) {
 	}
 }
 */
+
+func TestCreateMessageValidatesCards(t *testing.T) {
+	fake, baseURL := testutil.NewFakeWebex(t)
+	room, err := fake.Add(fakewebex.Rooms, fakewebex.Item{"title": "Ops"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := webex.NewClientWithConfig(&config.Config{WebexAPIKey: "token", WebexAPIBaseURL: baseURL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tool := NewCreateMessageTool().(ClientExecutor)
+
+	tests := []struct {
+		name       string
+		attachment string
+		wantErr    string
+	}{
+		{name: "valid card", attachment: `{"contentType": "application/vnd.microsoft.card.adaptive", "content": {"type": "AdaptiveCard", "version": "1.3", "body": [{"type": "TextBlock", "text": "Hi"}]}}`},
+		{name: "invalid card", attachment: `{"contentType": "application/vnd.microsoft.card.adaptive", "content": {"type": "AdaptiveCard", "version": "1.6"}}`, wantErr: "attachments[0]: invalid adaptive card: version: 1.6 is not supported"},
+		{name: "other content type", attachment: `{"contentType": "text/plain", "content": {}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := fake.Requests()
+			args := fmt.Sprintf(`{"roomId": %q, "markdown": "fallback", "attachments": [%s]}`, room["id"], tt.attachment)
+			_, err := tool.ExecuteWithClient(json.RawMessage(args), client)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want %q", err, tt.wantErr)
+			}
+			if fake.Requests() != requests {
+				t.Error("an invalid card was sent to Webex")
+			}
+		})
+	}
+}
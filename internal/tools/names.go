@@ -0,0 +1,126 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/raja-aiml/webex-mcp-server/internal/resolver"
+	"github.com/raja-aiml/webex-mcp-server/internal/webex"
+)
+
+// names resolves the name arguments of every tool, sharing one cache
+var names = resolver.New(resolver.DefaultTTL)
+
+// nameArgument is an argument that can be given instead of an ID argument
+type nameArgument struct {
+	idField     string
+	nameField   string
+	description string
+	resolve     func(r *resolver.Resolver, client webex.HTTPClient, name string) (string, error)
+}
+
+var nameArguments = []nameArgument{
+	{"roomId", "roomTitle", "The room title, instead of roomId. Matched ignoring case and punctuation; a title matching several rooms is an error listing them.", (*resolver.Resolver).Room},
+	{"personId", "personEmail", "The person's email address, instead of personId.", (*resolver.Resolver).PersonByEmail},
+	{"personId", "personName", "The person's display name, instead of personId. A name matching several people is an error listing them.", (*resolver.Resolver).PersonByName},
+	{"toPersonId", "toPersonName", "The recipient's display name, instead of toPersonId. A name matching several people is an error listing them.", (*resolver.Resolver).PersonByName},
+}
+
+// nameLookup is a name argument added to one tool
+type nameLookup struct {
+	nameArgument
+	required bool // The tool required the ID argument
+}
+
+// withNameLookups adds the name arguments for the ID arguments in schema. The
+// schema is copied, and IDs become optional since a name can stand in for them.
+func withNameLookups(schema *jsonschema.Schema) (*jsonschema.Schema, []nameLookup) {
+	if schema == nil {
+		return schema, nil
+	}
+	var lookups []nameLookup
+	for _, arg := range nameArguments {
+		_, hasID := schema.Properties[arg.idField]
+		_, hasName := schema.Properties[arg.nameField]
+		if hasID && !hasName {
+			lookups = append(lookups, nameLookup{nameArgument: arg})
+		}
+	}
+	if len(lookups) == 0 {
+		return schema, nil
+	}
+
+	withNames := *schema
+	withNames.Properties = make(map[string]*jsonschema.Schema, len(schema.Properties)+len(lookups))
+	for name, prop := range schema.Properties {
+		withNames.Properties[name] = prop
+	}
+	withNames.Required = nil
+	for _, field := range schema.Required {
+		required := false
+		for i := range lookups {
+			if lookups[i].idField == field {
+				lookups[i].required = true
+				required = true
+			}
+		}
+		if !required {
+			withNames.Required = append(withNames.Required, field)
+		}
+	}
+	for _, lookup := range lookups {
+		withNames.Properties[lookup.nameField] = StringProperty(lookup.description)
+	}
+	return &withNames, lookups
+}
+
+// resolveNames replaces name arguments in params with the IDs they resolve to.
+// An ID given alongside a name wins.
+func resolveNames(lookups []nameLookup, params map[string]interface{}, client webex.HTTPClient) error {
+	for _, lookup := range lookups {
+		name, _ := params[lookup.nameField].(string)
+		delete(params, lookup.nameField)
+		if id, _ := params[lookup.idField].(string); id != "" || name == "" {
+			continue
+		}
+		id, err := lookup.resolve(names, client, name)
+		if err != nil {
+			return fmt.Errorf("%s: %w", lookup.nameField, err)
+		}
+		params[lookup.idField] = id
+	}
+
+	for _, lookup := range lookups {
+		if id, _ := params[lookup.idField].(string); id != "" || !lookup.required {
+			continue
+		}
+		alternatives := []string{lookup.idField}
+		for _, other := range lookups {
+			if other.idField == lookup.idField {
+				alternatives = append(alternatives, other.nameField)
+			}
+		}
+		return fmt.Errorf("%s is required", strings.Join(alternatives, " or "))
+	}
+	return nil
+}
+
+// resolveNamesJSON is resolveNames for raw arguments; it returns args unchanged
+// when the tool has no name arguments
+func resolveNamesJSON(lookups []nameLookup, args json.RawMessage, client webex.HTTPClient) (json.RawMessage, error) {
+	if len(lookups) == 0 {
+		return args, nil
+	}
+	params := map[string]interface{}{}
+	if len(args) > 0 {
+		if err := json.Unmarshal(args, &params); err != nil {
+			return nil, fmt.Errorf("failed to parse arguments: %w", err)
+		}
+	}
+	if err := resolveNames(lookups, params, client); err != nil {
+		return nil, err
+	}
+	return json.Marshal(params)
+}
@@ -0,0 +1,166 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/raja-aiml/webex-mcp-server/internal/config"
+	"github.com/raja-aiml/webex-mcp-server/internal/fakewebex"
+	"github.com/raja-aiml/webex-mcp-server/internal/testutil"
+	"github.com/raja-aiml/webex-mcp-server/internal/webex"
+)
+
+func TestWithNameLookups(t *testing.T) {
+	tests := []struct {
+		name         string
+		schema       *jsonschema.Schema
+		wantNames    []string
+		wantRequired []string
+	}{
+		{
+			name: "required room",
+			schema: SimpleSchema("", map[string]*jsonschema.Schema{
+				"roomId": StringProperty(""),
+				"max":    IntegerProperty(""),
+			}, []string{"roomId", "max"}),
+			wantNames:    []string{"roomTitle"},
+			wantRequired: []string{"max"},
+		},
+		{
+			name: "person with a native email argument",
+			schema: SimpleSchema("", map[string]*jsonschema.Schema{
+				"personId":    StringProperty(""),
+				"personEmail": StringProperty(""),
+			}, nil),
+			wantNames: []string{"personName"},
+		},
+		{
+			name: "recipient",
+			schema: SimpleSchema("", map[string]*jsonschema.Schema{
+				"toPersonId":    StringProperty(""),
+				"toPersonEmail": StringProperty(""),
+				"roomId":        StringProperty(""),
+			}, nil),
+			wantNames: []string{"roomTitle", "toPersonName"},
+		},
+		{
+			name:         "no IDs",
+			schema:       SimpleSchema("", map[string]*jsonschema.Schema{"title": StringProperty("")}, []string{"title"}),
+			wantRequired: []string{"title"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := len(tt.schema.Properties)
+			schema, lookups := withNameLookups(tt.schema)
+			var names []string
+			for _, lookup := range lookups {
+				names = append(names, lookup.nameField)
+				if schema.Properties[lookup.nameField] == nil {
+					t.Errorf("schema has no %s property", lookup.nameField)
+				}
+			}
+			if !slices.Equal(names, tt.wantNames) {
+				t.Errorf("name arguments = %v, want %v", names, tt.wantNames)
+			}
+			if len(tt.wantRequired) > 0 || len(schema.Required) > 0 {
+				if !slices.Equal(schema.Required, tt.wantRequired) {
+					t.Errorf("required = %v, want %v", schema.Required, tt.wantRequired)
+				}
+			}
+			if len(tt.schema.Properties) != original {
+				t.Error("withNameLookups() changed the original schema")
+			}
+		})
+	}
+}
+
+func TestResolveNames(t *testing.T) {
+	fake, baseURL := testutil.NewFakeWebex(t)
+	room, err := fake.Add(fakewebex.Rooms, fakewebex.Item{"title": "Launch Plan"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, title := range []string{"Ops", "Ops Review"} {
+		if _, err := fake.Add(fakewebex.Rooms, fakewebex.Item{"title": title}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_, err = fake.Add(fakewebex.People, fakewebex.Item{"displayName": "Alice Example", "emails": []interface{}{"alice@example.com"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := webex.NewClientWithConfig(&config.Config{WebexAPIKey: "token", WebexAPIBaseURL: baseURL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	getRoom := NewGetTool("get_room", "Get a room.", "/rooms", "roomId", "The room ID.").(ClientExecutor)
+	createMessage := NewCreateMessageTool().(ClientExecutor)
+	deleteRoom := NewDeleteTool("delete_room", "Delete a room.", "/rooms", "roomId", "The room ID.")
+	if _, ok := deleteRoom.GetInputSchema().(*jsonschema.Schema).Properties["roomTitle"]; ok {
+		t.Error("delete tools accept a room title")
+	}
+
+	tests := []struct {
+		name    string
+		tool    ClientExecutor
+		args    string
+		check   func(result map[string]interface{}) bool
+		wantErr string
+	}{
+		{
+			name:  "room title",
+			tool:  getRoom,
+			args:  `{"roomTitle": "launch plan"}`,
+			check: func(result map[string]interface{}) bool { return result["id"] == room["id"] },
+		},
+		{
+			name:  "an ID wins over a title",
+			tool:  getRoom,
+			args:  fmt.Sprintf(`{"roomId": %q, "roomTitle": "Ops"}`, room["id"]),
+			check: func(result map[string]interface{}) bool { return result["title"] == "Launch Plan" },
+		},
+		{
+			name:    "ambiguous title",
+			tool:    getRoom,
+			args:    `{"roomTitle": "op"}`,
+			wantErr: `"Ops Review" (`,
+		},
+		{
+			name:    "neither ID nor title",
+			tool:    getRoom,
+			args:    `{}`,
+			wantErr: "roomId or roomTitle is required",
+		},
+		{
+			name: "recipient name",
+			tool: createMessage,
+			args: `{"toPersonName": "Alice Example", "text": "hello"}`,
+			check: func(result map[string]interface{}) bool {
+				return result["roomType"] == "direct" && result["text"] == "hello"
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.tool.ExecuteWithClient(json.RawMessage(tt.args), client)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if fields, _ := result.(map[string]interface{}); !tt.check(fields) {
+				t.Errorf("result = %v", result)
+			}
+		})
+	}
+}
@@ -14,7 +14,7 @@ func TestCorePluginImplementations(t *testing.T) {
 	defer func() {
 		cleanup()
 		config.ResetForTesting()
-		defaultClient = nil
+		ResetDefaultClient()
 	}()
 
 	// Initialize default client
@@ -69,7 +69,7 @@ func TestCorePluginRegister(t *testing.T) {
 	defer func() {
 		cleanup()
 		config.ResetForTesting()
-		defaultClient = nil
+		ResetDefaultClient()
 	}()
 
 	// Initialize default client
@@ -0,0 +1,191 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/raja-aiml/webex-mcp-server/internal/config"
+	"github.com/raja-aiml/webex-mcp-server/internal/webex"
+)
+
+// ProfileArgument is the argument added to every tool when profiles are configured
+const ProfileArgument = "profile"
+
+var (
+	profileMu      sync.Mutex
+	profileClients = map[string]webex.HTTPClient{}
+)
+
+type profileKey struct{}
+
+// ProfileFromContext returns the profile a call runs as, or "" for the default
+func ProfileFromContext(ctx context.Context) string {
+	profile, _ := ctx.Value(profileKey{}).(string)
+	return profile
+}
+
+// ClientFor returns the shared client of a profile, or the default client for "".
+// Both are rebuilt after a reload, so a rotated token is picked up.
+func ClientFor(profile string) (webex.HTTPClient, error) {
+	if profile == "" {
+		return getDefaultClient()
+	}
+	return ProfileClient(profile)
+}
+
+// ProfileClient returns the shared client for a named profile, creating it on first use
+func ProfileClient(name string) (webex.HTTPClient, error) {
+	profileMu.Lock()
+	defer profileMu.Unlock()
+
+	if client, ok := profileClients[name]; ok {
+		return client, nil
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	profileCfg, err := cfg.Profile(name)
+	if err != nil {
+		return nil, err
+	}
+	client, err := webex.NewClientWithConfig(profileCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize client for profile %s: %w", name, err)
+	}
+	profileClients[name] = client
+	return client, nil
+}
+
+// resetProfileClients discards the per-profile clients
+func resetProfileClients() {
+	profileMu.Lock()
+	profileClients = map[string]webex.HTTPClient{}
+	profileMu.Unlock()
+}
+
+// WithProfiles returns a registry whose tools accept an optional profile argument
+// selecting one of the named profiles. Tools that cannot run with another client are kept as is.
+func WithProfiles(registry *Registry, profiles []string) (*Registry, error) {
+	if len(profiles) == 0 {
+		return registry, nil
+	}
+
+	wrapped := NewRegistry()
+	for _, tool := range registry.GetTools() {
+		if pt, ok := newProfileTool(tool, profiles); ok {
+			tool = pt
+		}
+		if err := wrapped.Register(tool); err != nil {
+			return nil, err
+		}
+	}
+	return wrapped, nil
+}
+
+// profileTool routes a call to the client of the profile named in its arguments
+type profileTool struct {
+	Tool
+	executor ClientExecutor
+	schema   *jsonschema.Schema
+	hasOrgID bool
+}
+
+func newProfileTool(tool Tool, profiles []string) (*profileTool, bool) {
+	executor, ok := tool.(ClientExecutor)
+	if !ok {
+		return nil, false
+	}
+	schema, ok := tool.GetInputSchema().(*jsonschema.Schema)
+	if !ok || schema == nil {
+		return nil, false
+	}
+
+	enum := make([]any, len(profiles))
+	for i, name := range profiles {
+		enum[i] = name
+	}
+
+	// Copy the schema so the tool's own definition is left untouched
+	withProfile := *schema
+	withProfile.Properties = make(map[string]*jsonschema.Schema, len(schema.Properties)+1)
+	for name, prop := range schema.Properties {
+		withProfile.Properties[name] = prop
+	}
+	withProfile.Properties[ProfileArgument] = &jsonschema.Schema{
+		Type:        "string",
+		Description: "Webex profile to act as. Defaults to the server's default profile.",
+		Enum:        enum,
+	}
+
+	_, hasOrgID := schema.Properties["orgId"]
+	return &profileTool{Tool: tool, executor: executor, schema: &withProfile, hasOrgID: hasOrgID}, true
+}
+
+func (t *profileTool) GetInputSchema() interface{} { return t.schema }
+
+// Execute removes the profile argument and runs the tool as that profile
+func (t *profileTool) Execute(args json.RawMessage) (interface{}, error) {
+	return t.ExecuteContext(context.Background(), args)
+}
+
+// ExecuteContext is Execute with the context of the call passed on to the tool
+func (t *profileTool) ExecuteContext(ctx context.Context, args json.RawMessage) (interface{}, error) {
+	var params map[string]interface{}
+	if len(args) > 0 {
+		if err := json.Unmarshal(args, &params); err != nil {
+			return nil, fmt.Errorf("failed to parse arguments: %w", err)
+		}
+	}
+	if params == nil {
+		params = map[string]interface{}{}
+	}
+
+	profile, _ := params[ProfileArgument].(string)
+	delete(params, ProfileArgument)
+
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	var client webex.HTTPClient
+	if profile != "" {
+		if cfg, err = cfg.Profile(profile); err != nil {
+			return nil, err
+		}
+		if client, err = ProfileClient(profile); err != nil {
+			return nil, err
+		}
+		ctx = context.WithValue(ctx, profileKey{}, profile)
+	}
+
+	if t.hasOrgID && cfg.OrgID != "" {
+		if existing, _ := params["orgId"].(string); existing == "" {
+			params["orgId"] = cfg.OrgID
+		}
+	}
+
+	argsJSON, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal arguments: %w", err)
+	}
+	if client == nil {
+		if tool, ok := t.Tool.(ContextExecutor); ok {
+			return tool.ExecuteContext(ctx, argsJSON)
+		}
+		return t.Tool.Execute(argsJSON)
+	}
+	if tool, ok := t.Tool.(clientContextExecutor); ok {
+		return tool.ExecuteWithClientContext(ctx, argsJSON, client)
+	}
+	return t.executor.ExecuteWithClient(argsJSON, client)
+}
+
+// ExecuteWithMap implements the Tool interface
+func (t *profileTool) ExecuteWithMap(args map[string]interface{}) (interface{}, error) {
+	return ExecuteWithMapBase(t, args)
+}
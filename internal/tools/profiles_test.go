@@ -0,0 +1,118 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/raja-aiml/webex-mcp-server/internal/config"
+	"github.com/raja-aiml/webex-mcp-server/internal/testutil"
+	"github.com/raja-aiml/webex-mcp-server/internal/webex"
+)
+
+func TestWithProfiles(t *testing.T) {
+	type request struct {
+		auth  string
+		orgID string
+	}
+	var requests []request
+	server := testutil.MockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, request{auth: r.Header.Get("Authorization"), orgID: r.URL.Query().Get("orgId")})
+		testutil.JSONResponse(w, http.StatusOK, map[string]interface{}{"items": []interface{}{}})
+	})
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := "default_profile: bot\nprofiles:\n" +
+		"  bot:\n    token: bot-token\n    base_url: " + server.URL + "\n" +
+		"  admin:\n    token: admin-token\n    base_url: " + server.URL + "\n    org_id: admin-org\n"
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cleanup := testutil.SetEnv(t, "WEBEX_PUBLIC_WORKSPACE_API_KEY", "")
+	defer func() {
+		cleanup()
+		config.ResetForTesting()
+		ResetDefaultClient()
+	}()
+	config.SetOptions(config.Options{File: path})
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("config.Load() error = %v", err)
+	}
+	ResetDefaultClient()
+
+	registry := NewRegistry()
+	registry.Register(NewSimpleTool("list_people", "List people", SimpleSchema("List people.", map[string]*jsonschema.Schema{
+		"orgId": StringProperty("Organization ID"),
+	}, nil), func(params map[string]interface{}, client webex.HTTPClient) (interface{}, error) {
+		if _, ok := params[ProfileArgument]; ok {
+			t.Error("profile argument was passed through to the tool")
+		}
+		return client.Get("/people", QueryParams(params))
+	}))
+
+	wrapped, err := WithProfiles(registry, cfg.ProfileNames())
+	if err != nil {
+		t.Fatalf("WithProfiles() error = %v", err)
+	}
+	tool, _ := wrapped.GetTool("list_people")
+
+	schema := tool.GetInputSchema().(*jsonschema.Schema)
+	profileProp, ok := schema.Properties[ProfileArgument]
+	if !ok {
+		t.Fatal("profile property not added to the schema")
+	}
+	if len(profileProp.Enum) != 2 || profileProp.Enum[0] != "admin" || profileProp.Enum[1] != "bot" {
+		t.Errorf("profile enum = %v, want [admin bot]", profileProp.Enum)
+	}
+	original, _ := registry.GetTool("list_people")
+	if _, ok := original.GetInputSchema().(*jsonschema.Schema).Properties[ProfileArgument]; ok {
+		t.Error("WithProfiles modified the original tool schema")
+	}
+
+	calls := []struct {
+		args string
+		want request
+	}{
+		{args: `{}`, want: request{auth: "Bearer bot-token"}},
+		{args: `{"profile":"admin"}`, want: request{auth: "Bearer admin-token", orgID: "admin-org"}},
+		{args: `{"profile":"admin","orgId":"explicit"}`, want: request{auth: "Bearer admin-token", orgID: "explicit"}},
+	}
+	for _, call := range calls {
+		requests = nil
+		if _, err := tool.Execute(json.RawMessage(call.args)); err != nil {
+			t.Fatalf("Execute(%s) error = %v", call.args, err)
+		}
+		if len(requests) != 1 || requests[0] != call.want {
+			t.Errorf("Execute(%s) requests = %+v, want %+v", call.args, requests, call.want)
+		}
+	}
+
+	if _, err := tool.Execute(json.RawMessage(`{"profile":"nope"}`)); err == nil || !strings.Contains(err.Error(), "unknown profile") {
+		t.Errorf("Execute() with unknown profile error = %v", err)
+	}
+
+	// Context tools learn the profile they run as, and can get its client again later
+	registry.Register(NewContextTool("whoami", "Who am I", SimpleSchema("Who am I.", map[string]*jsonschema.Schema{}, nil),
+		func(ctx context.Context, params *struct{}, client webex.HTTPClient) (interface{}, error) {
+			return ProfileFromContext(ctx), nil
+		}))
+	wrapped, _ = WithProfiles(registry, cfg.ProfileNames())
+	whoami, _ := wrapped.GetTool("whoami")
+	for args, want := range map[string]string{`{}`: "", `{"profile":"admin"}`: "admin"} {
+		if got, err := whoami.Execute(json.RawMessage(args)); err != nil || got != want {
+			t.Errorf("Execute(%s) = %v, %v; want profile %q", args, got, err, want)
+		}
+	}
+	admin, _ := ProfileClient("admin")
+	if client, err := ClientFor("admin"); err != nil || client != admin {
+		t.Errorf("ClientFor(admin) = %v, %v", client, err)
+	}
+}
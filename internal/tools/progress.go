@@ -0,0 +1,20 @@
+package tools
+
+import "context"
+
+// ProgressFunc reports how far a long-running call has got; total is 0 when unknown
+type ProgressFunc func(progress, total float64, message string)
+
+type progressKey struct{}
+
+// WithProgress returns a context whose tool call reports progress to report
+func WithProgress(ctx context.Context, report ProgressFunc) context.Context {
+	return context.WithValue(ctx, progressKey{}, report)
+}
+
+// ReportProgress sends a progress update for the call in ctx when the caller asked for updates
+func ReportProgress(ctx context.Context, progress, total float64, message string) {
+	if report, ok := ctx.Value(progressKey{}).(ProgressFunc); ok && report != nil {
+		report(progress, total, message)
+	}
+}
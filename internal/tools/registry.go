@@ -3,6 +3,10 @@ package tools
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/raja-aiml/webex-mcp-server/internal/config"
 )
 
 type Tool interface {
@@ -81,6 +85,98 @@ func LoadAllTools() (*Registry, error) {
 	return registry, nil
 }
 
+// LoadSelectedTools creates a registry from the tool selection in the configuration.
+// Include narrows the set to the named tools (advanced ones included) and Exclude removes tools.
+// Unknown tool names are reported as errors so typos in the configuration do not go unnoticed.
+func LoadSelectedTools(selection config.ToolsConfig) (*Registry, error) {
+	var (
+		source *Registry
+		err    error
+	)
+	if selection.All || len(selection.Include) > 0 {
+		source, err = LoadAllTools()
+	} else {
+		source, err = LoadCoreTools()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	known, err := LoadAllTools()
+	if err != nil {
+		return nil, err
+	}
+	var unknown []string
+	for _, name := range append(append([]string{}, selection.Include...), selection.Exclude...) {
+		if _, ok := known.GetTool(name); !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("unknown tools in configuration: %s", strings.Join(unknown, ", "))
+	}
+
+	excluded := make(map[string]bool, len(selection.Exclude))
+	for _, name := range selection.Exclude {
+		excluded[name] = true
+	}
+
+	registry := NewRegistry()
+	for _, tool := range source.GetTools() {
+		if excluded[tool.Name()] {
+			continue
+		}
+		if len(selection.Include) > 0 && !containsName(selection.Include, tool.Name()) {
+			continue
+		}
+		if err := registry.Register(tool); err != nil {
+			return nil, err
+		}
+	}
+	return registry, nil
+}
+
+// LoadConfiguredTools loads the tools selected in cfg, adds the profile argument
+// to them when profiles are configured and gates those that need approval
+func LoadConfiguredTools(cfg *config.Config) (*Registry, error) {
+	registry, err := LoadSelectedTools(cfg.Tools)
+	if err != nil {
+		return nil, err
+	}
+	if registry, err = WithProfiles(registry, cfg.ProfileNames()); err != nil {
+		return nil, err
+	}
+
+	// A misspelled name would leave the tool it meant ungated
+	if len(cfg.Approvals.Tools) > 0 {
+		known, err := LoadAllTools()
+		if err != nil {
+			return nil, err
+		}
+		var unknown []string
+		for _, name := range cfg.Approvals.Tools {
+			if _, ok := known.GetTool(name); !ok {
+				unknown = append(unknown, name)
+			}
+		}
+		if len(unknown) > 0 {
+			sort.Strings(unknown)
+			return nil, fmt.Errorf("unknown tools in approvals.tools: %s", strings.Join(unknown, ", "))
+		}
+	}
+	return WithApprovals(registry, cfg.Approvals)
+}
+
+func containsName(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
 // LoadTools is deprecated, use LoadCoreTools or LoadAllTools instead
 // Kept for backward compatibility
 func LoadTools() (*Registry, error) {
@@ -1,7 +1,11 @@
 package tools
 
 import (
+	"sort"
+	"strings"
 	"testing"
+
+	"github.com/raja-aiml/webex-mcp-server/internal/config"
 )
 
 func TestNewRegistry(t *testing.T) {
@@ -185,3 +189,57 @@ func TestRegistry_CaseSensitivity(t *testing.T) {
 }
 
 // testTool is already defined in plugin_test.go, so we'll reuse it
+
+func TestLoadSelectedTools(t *testing.T) {
+	tests := []struct {
+		name      string
+		selection config.ToolsConfig
+		want      []string
+		wantErr   string
+	}{
+		{
+			name:      "core tools by default",
+			selection: config.ToolsConfig{},
+			want:      []string{"create_a_message", "create_a_webhook", "get_my_own_details", "list_messages", "list_rooms", "list_webhooks"},
+		},
+		{
+			name:      "include narrows the set",
+			selection: config.ToolsConfig{Include: []string{"list_rooms", "create_a_message"}},
+			want:      []string{"create_a_message", "list_rooms"},
+		},
+		{
+			name:      "exclude removes tools",
+			selection: config.ToolsConfig{Exclude: []string{"create_a_webhook", "list_webhooks"}},
+			want:      []string{"create_a_message", "get_my_own_details", "list_messages", "list_rooms"},
+		},
+		{
+			name:      "unknown names are rejected",
+			selection: config.ToolsConfig{Include: []string{"list_rooms", "list_romos"}, Exclude: []string{"nope"}},
+			wantErr:   "unknown tools in configuration: list_romos, nope",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry, err := LoadSelectedTools(tt.selection)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("LoadSelectedTools() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadSelectedTools() error = %v", err)
+			}
+
+			var names []string
+			for _, tool := range registry.GetTools() {
+				names = append(names, tool.Name())
+			}
+			sort.Strings(names)
+			if strings.Join(names, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("tools = %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package tools
 
 import (
+	"sync"
+
 	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/raja-aiml/webex-mcp-server/internal/config"
 	"github.com/raja-aiml/webex-mcp-server/internal/webex"
@@ -31,8 +33,14 @@ func NewToolBaseWithConfig(name, description string, schema *jsonschema.Schema,
 	}
 }
 
-// ensureClient ensures the HTTP client is initialized
-func (t *ToolBase) ensureClient() error {
+// toolClientMu guards the client every ToolBase builds on first use, as calls of
+// one tool run concurrently
+var toolClientMu sync.Mutex
+
+// ensureClient returns the tool's HTTP client, initializing it on first use
+func (t *ToolBase) ensureClient() (webex.HTTPClient, error) {
+	toolClientMu.Lock()
+	defer toolClientMu.Unlock()
 	if t.client == nil {
 		var err error
 		if t.config != nil {
@@ -41,10 +49,10 @@ func (t *ToolBase) ensureClient() error {
 			t.client, err = getDefaultClient()
 		}
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
-	return nil
+	return t.client, nil
 }
 
 func (t *ToolBase) Name() string                { return t.name }
@@ -84,6 +92,19 @@ func RequiredStringProperty(description string) *jsonschema.Schema {
 	}
 }
 
+// EnumProperty creates a string property limited to values
+func EnumProperty(description string, values ...string) *jsonschema.Schema {
+	enum := make([]any, len(values))
+	for i, value := range values {
+		enum[i] = value
+	}
+	return &jsonschema.Schema{
+		Type:        "string",
+		Description: description,
+		Enum:        enum,
+	}
+}
+
 func IntegerProperty(description string) *jsonschema.Schema {
 	min := 0.0
 	return &jsonschema.Schema{
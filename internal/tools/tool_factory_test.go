@@ -1,7 +1,6 @@
 package tools
 
 import (
-	"sync"
 	"testing"
 
 	"github.com/google/jsonschema-go/jsonschema"
@@ -52,7 +51,7 @@ func TestNewToolBaseWithConfig(t *testing.T) {
 	}
 
 	// Test that ensureClient works
-	if err := tool.ensureClient(); err != nil {
+	if _, err := tool.ensureClient(); err != nil {
 		t.Errorf("ensureClient() failed: %v", err)
 	}
 
@@ -256,16 +255,12 @@ func TestSimpleSchema_ComplexExample(t *testing.T) {
 func TestToolBase_WithNilClient(t *testing.T) {
 	// Set up test environment with API key
 	config.ResetForTesting()
-	defaultClient = nil
-	clientOnce = sync.Once{}
-	clientErr = nil
+	ResetDefaultClient()
 	cleanup := testutil.SetEnv(t, "WEBEX_PUBLIC_WORKSPACE_API_KEY", "test-token")
 	defer func() {
 		cleanup()
 		config.ResetForTesting()
-		defaultClient = nil
-		clientOnce = sync.Once{}
-		clientErr = nil
+		ResetDefaultClient()
 	}()
 
 	tool := &ToolBase{
@@ -276,7 +271,7 @@ func TestToolBase_WithNilClient(t *testing.T) {
 	}
 
 	// Ensure client initializes properly
-	err := tool.ensureClient()
+	_, err := tool.ensureClient()
 	if err != nil {
 		t.Errorf("ensureClient() should handle nil client gracefully: %v", err)
 	}
@@ -0,0 +1,240 @@
+package transcript
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Output formats
+const (
+	FormatMarkdown = "markdown"
+	FormatJSONL    = "jsonl"
+	FormatHTML     = "html"
+)
+
+// Formats lists the supported output formats
+var Formats = []string{FormatMarkdown, FormatJSONL, FormatHTML}
+
+// Extension returns the file extension for format, including the dot
+func Extension(format string) string {
+	switch format {
+	case FormatJSONL:
+		return ".jsonl"
+	case FormatHTML:
+		return ".html"
+	default:
+		return ".md"
+	}
+}
+
+// Render writes the transcript in format; an empty format means Markdown
+func Render(w io.Writer, t *Transcript, format string) error {
+	switch format {
+	case FormatMarkdown, "":
+		return renderMarkdown(w, t)
+	case FormatJSONL:
+		return renderJSONL(w, t)
+	case FormatHTML:
+		return htmlTemplate.Execute(w, t)
+	default:
+		return fmt.Errorf("unknown format %q (want %s)", format, strings.Join(Formats, ", "))
+	}
+}
+
+// Body returns the message content, preferring Markdown over plain text
+func (m *Message) Body() string {
+	if m.Markdown != "" {
+		return m.Markdown
+	}
+	return m.Text
+}
+
+// Range describes the exported period for headings
+func (t *Transcript) Range() string {
+	switch {
+	case t.From.IsZero() && t.To.IsZero():
+		return "all messages"
+	case t.From.IsZero():
+		return "messages before " + formatTime(t.To)
+	case t.To.IsZero():
+		return "messages since " + formatTime(t.From)
+	default:
+		return "messages from " + formatTime(t.From) + " to " + formatTime(t.To)
+	}
+}
+
+func renderMarkdown(w io.Writer, t *Transcript) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n_%d %s, %s._\n", titleOf(t), t.Count, plural(t.Count, "message"), t.Range())
+	if t.Truncated {
+		b.WriteString("\n_Older messages were left out; narrow the range to export them._\n")
+	}
+
+	day := ""
+	for _, message := range t.Messages {
+		if d := message.Created.UTC().Format("2006-01-02"); d != day {
+			day = d
+			fmt.Fprintf(&b, "\n## %s\n", day)
+		}
+		b.WriteString("\n")
+		writeMarkdownMessage(&b, message, "")
+		for _, reply := range message.Replies {
+			b.WriteString(">\n")
+			writeMarkdownMessage(&b, reply, "> ")
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeMarkdownMessage(b *strings.Builder, m *Message, prefix string) {
+	fmt.Fprintf(b, "%s**%s** · %s\n", prefix, m.Sender, m.Created.UTC().Format("15:04 MST"))
+	for _, line := range strings.Split(m.Body(), "\n") {
+		fmt.Fprintf(b, "%s%s\n", prefix, line)
+	}
+	for _, file := range m.Files {
+		fmt.Fprintf(b, "%s- Attachment: %s\n", prefix, file)
+	}
+}
+
+// renderJSONL writes one message per line, each thread's replies after their parent
+func renderJSONL(w io.Writer, t *Transcript) error {
+	encoder := json.NewEncoder(w)
+	for _, message := range t.Messages {
+		for _, m := range append([]*Message{message}, message.Replies...) {
+			if err := encoder.Encode(m); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func titleOf(t *Transcript) string {
+	if t.RoomTitle != "" {
+		return t.RoomTitle
+	}
+	return t.RoomID
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02 15:04 MST")
+}
+
+func plural(n int, word string) string {
+	if n == 1 {
+		return word
+	}
+	return word + "s"
+}
+
+var htmlTemplate = template.Must(template.New("transcript").Funcs(template.FuncMap{
+	"title":  titleOf,
+	"time":   formatTime,
+	"plural": plural,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{title .}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; max-width: 48rem; margin: 2rem auto; padding: 0 1rem; color: #1d1d1f; }
+header p, .meta { color: #6e6e73; font-size: 0.875rem; }
+.message { margin: 1rem 0; }
+.body { white-space: pre-wrap; margin: 0.25rem 0; }
+.thread { margin-left: 1.5rem; padding-left: 1rem; border-left: 3px solid #d2d2d7; }
+</style>
+</head>
+<body>
+<header>
+<h1>{{title .}}</h1>
+<p>{{.Count}} {{plural .Count "message"}}, {{.Range}}.{{if .Truncated}} Older messages were left out; narrow the range to export them.{{end}}</p>
+</header>
+<main>
+{{- range .Messages}}
+{{template "message" .}}
+{{- if .Replies}}
+<div class="thread">
+{{- range .Replies}}
+{{template "message" .}}
+{{- end}}
+</div>
+{{- end}}
+{{- end}}
+</main>
+</body>
+</html>
+{{define "message"}}<div class="message" id="{{.ID}}">
+<div class="meta"><strong>{{.Sender}}</strong> · <time datetime="{{.Created.UTC.Format "2006-01-02T15:04:05Z07:00"}}">{{time .Created}}</time></div>
+<div class="body">{{.Body}}</div>
+{{- range .Files}}
+<div class="file"><a href="{{.}}">Attachment</a></div>
+{{- end}}
+</div>{{end}}
+`))
+
+// ParseTime accepts an RFC 3339 timestamp or a date, which means midnight UTC
+func ParseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q: use RFC 3339 (2026-01-02T15:04:05Z) or a date (2026-01-02)", value)
+	}
+	return t, nil
+}
+
+// WriteFile renders the transcript into a new file in dir and returns its path.
+// The name comes from the room title and the export time, never from user input.
+func WriteFile(dir string, t *Transcript, format string, now time.Time) (string, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", fmt.Errorf("failed to create export directory: %w", err)
+	}
+	name := slug(titleOf(t)) + "-" + now.UTC().Format("20060102-150405") + Extension(format)
+	path := filepath.Join(dir, name)
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return "", fmt.Errorf("failed to create transcript file: %w", err)
+	}
+	if err := Render(file, t, format); err != nil {
+		file.Close()
+		os.Remove(path)
+		return "", err
+	}
+	return path, file.Close()
+}
+
+// slug reduces a title to lowercase letters, digits and dashes
+func slug(title string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(title) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+		if b.Len() >= 60 {
+			break
+		}
+	}
+	s := strings.TrimSuffix(b.String(), "-")
+	if s == "" {
+		return "transcript"
+	}
+	return s
+}
@@ -0,0 +1,174 @@
+package transcript
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/raja-aiml/webex-mcp-server/internal/webex"
+)
+
+// DefaultMaxThreads bounds ListThreads when ThreadOptions.MaxThreads is not set
+const DefaultMaxThreads = 20
+
+// DefaultScanMessages bounds how far back ListThreads looks for thread activity
+const DefaultScanMessages = 1000
+
+// Thread is a parent message with its replies, oldest first
+type Thread struct {
+	RoomID       string     `json:"roomId"`
+	Parent       *Message   `json:"parent"`
+	Replies      []*Message `json:"replies,omitempty"`
+	ReplyCount   int        `json:"replyCount"`
+	Participants []string   `json:"participants"` // Senders in order of their first message
+	LastActivity time.Time  `json:"lastActivity"`
+}
+
+// ThreadList is the threads of a room with the most recent activity first
+type ThreadList struct {
+	RoomID    string    `json:"roomId"`
+	Threads   []*Thread `json:"threads"`
+	Scanned   int       `json:"scannedMessages"`
+	Truncated bool      `json:"truncated"` // The scan stopped before the start of the range
+}
+
+// ThreadOptions selects the threads ListThreads returns
+type ThreadOptions struct {
+	From           time.Time // Only threads with a reply at or after From
+	To             time.Time // Only replies created before To select threads, which are still returned whole
+	MaxThreads     int       // 0 means DefaultMaxThreads
+	ScanMessages   int       // Messages to look through for replies; 0 means DefaultScanMessages
+	IncludeReplies bool      // Return each thread's replies rather than only the counts
+}
+
+// GetThread fetches the thread a message belongs to. Given a reply it returns
+// the whole thread around it, starting from the parent.
+func GetThread(client webex.HTTPClient, messageID string) (*Thread, error) {
+	if messageID == "" {
+		return nil, fmt.Errorf("messageId is required")
+	}
+	fields, err := client.Get("/messages/"+messageID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get message: %w", err)
+	}
+	if parentID := stringField(fields, "parentId"); parentID != "" {
+		if fields, err = client.Get("/messages/"+parentID, nil); err != nil {
+			return nil, fmt.Errorf("failed to get parent message: %w", err)
+		}
+	}
+
+	thread, err := fetchThread(client, stringField(fields, "roomId"), newMessage(fields))
+	if err != nil {
+		return nil, err
+	}
+	finishThreads(client, []*Thread{thread})
+	return thread, nil
+}
+
+// ListThreads finds the threads with recent replies in a room. Replies within
+// the scanned messages select the threads; each thread is then fetched in full,
+// so reply counts include replies older than the scan.
+func ListThreads(client webex.HTTPClient, roomID string, opts ThreadOptions) (*ThreadList, error) {
+	if roomID == "" {
+		return nil, fmt.Errorf("roomId is required")
+	}
+	if !opts.From.IsZero() && !opts.To.IsZero() && !opts.From.Before(opts.To) {
+		return nil, fmt.Errorf("from must be before to")
+	}
+	maxThreads := opts.MaxThreads
+	if maxThreads <= 0 {
+		maxThreads = DefaultMaxThreads
+	}
+	scan := opts.ScanMessages
+	if scan <= 0 {
+		scan = DefaultScanMessages
+	}
+
+	params := map[string]string{"roomId": roomID}
+	if !opts.To.IsZero() {
+		params["before"] = opts.To.UTC().Format(time.RFC3339)
+	}
+	messages, truncated, err := ListMessages(client, params, opts.From, scan)
+	if err != nil {
+		return nil, err
+	}
+
+	// Messages are newest first, so the first reply seen in a thread is its latest
+	byID := make(map[string]*Message, len(messages))
+	var parentIDs []string
+	seen := map[string]bool{}
+	for _, message := range messages {
+		byID[message.ID] = message
+		if message.ParentID != "" && !seen[message.ParentID] {
+			seen[message.ParentID] = true
+			parentIDs = append(parentIDs, message.ParentID)
+		}
+	}
+	if len(parentIDs) > maxThreads {
+		parentIDs = parentIDs[:maxThreads]
+	}
+
+	list := &ThreadList{RoomID: roomID, Threads: []*Thread{}, Scanned: len(messages), Truncated: truncated}
+	for _, parentID := range parentIDs {
+		parent := byID[parentID]
+		if parent == nil {
+			fields, err := client.Get("/messages/"+parentID, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get parent message: %w", err)
+			}
+			parent = newMessage(fields)
+		}
+		thread, err := fetchThread(client, roomID, parent)
+		if err != nil {
+			return nil, err
+		}
+		list.Threads = append(list.Threads, thread)
+	}
+	finishThreads(client, list.Threads)
+
+	sort.SliceStable(list.Threads, func(i, j int) bool {
+		return list.Threads[i].LastActivity.After(list.Threads[j].LastActivity)
+	})
+	if !opts.IncludeReplies {
+		for _, thread := range list.Threads {
+			thread.Replies = nil
+		}
+	}
+	return list, nil
+}
+
+// fetchThread lists every reply to parent, oldest first
+func fetchThread(client webex.HTTPClient, roomID string, parent *Message) (*Thread, error) {
+	replies, _, err := ListMessages(client, map[string]string{"roomId": roomID, "parentId": parent.ID}, time.Time{}, DefaultMaxMessages)
+	if err != nil {
+		return nil, err
+	}
+	oldestFirst(replies)
+	return &Thread{RoomID: roomID, Parent: parent, Replies: replies, ReplyCount: len(replies)}, nil
+}
+
+// finishThreads resolves senders across all threads at once, then fills in
+// participants and last activity
+func finishThreads(client webex.HTTPClient, threads []*Thread) {
+	var all []*Message
+	for _, thread := range threads {
+		all = append(all, thread.Parent)
+		all = append(all, thread.Replies...)
+	}
+	ResolveSenders(client, all)
+
+	for _, thread := range threads {
+		thread.Participants = []string{}
+		seen := map[string]bool{}
+		thread.LastActivity = thread.Parent.Created
+		for _, message := range append([]*Message{thread.Parent}, thread.Replies...) {
+			if !seen[message.Sender] {
+				seen[message.Sender] = true
+				thread.Participants = append(thread.Participants, message.Sender)
+			}
+			if message.Created.After(thread.LastActivity) {
+				thread.LastActivity = message.Created
+			}
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package transcript
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/raja-aiml/webex-mcp-server/internal/config"
+	"github.com/raja-aiml/webex-mcp-server/internal/fakewebex"
+	"github.com/raja-aiml/webex-mcp-server/internal/testutil"
+	"github.com/raja-aiml/webex-mcp-server/internal/webex"
+)
+
+// newThreadedRoom seeds two threads whose replies are interleaved with other
+// messages, oldest first: alpha, beta, alpha reply, filler, beta reply, alpha reply
+func newThreadedRoom(t *testing.T) (webex.HTTPClient, string, map[string]string) {
+	t.Helper()
+	fake, baseURL := testutil.NewFakeWebex(t)
+	clock := start
+	fake.SetClock(func() time.Time {
+		clock = clock.Add(time.Minute)
+		return clock
+	})
+
+	alice, err := fake.Add(fakewebex.People, fakewebex.Item{"displayName": "Alice Example", "emails": []interface{}{"alice@example.com"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	room, err := fake.Add(fakewebex.Rooms, fakewebex.Item{"title": "Threads"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	roomID := room["id"].(string)
+
+	ids := map[string]string{}
+	post := func(name, personID, parent string) {
+		fields := fakewebex.Item{"roomId": roomID, "text": name}
+		if parent != "" {
+			fields["parentId"] = ids[parent]
+		}
+		message, err := fake.PostAs(personID, fields)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids[name] = message["id"].(string)
+	}
+	me := fake.Me()["id"].(string)
+	post("alpha", me, "")
+	post("beta", me, "")
+	post("alpha-1", alice["id"].(string), "alpha")
+	post("filler", me, "")
+	post("beta-1", me, "beta")
+	post("alpha-2", me, "alpha")
+
+	client, err := webex.NewClientWithConfig(&config.Config{WebexAPIKey: "token", WebexAPIBaseURL: baseURL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, roomID, ids
+}
+
+func TestGetThread(t *testing.T) {
+	client, _, ids := newThreadedRoom(t)
+
+	for _, id := range []string{ids["alpha"], ids["alpha-1"]} {
+		thread, err := GetThread(client, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if thread.Parent.Text != "alpha" || thread.ReplyCount != 2 || len(thread.Replies) != 2 {
+			t.Fatalf("GetThread(%s) = %+v", id, thread)
+		}
+		if thread.Replies[0].Text != "alpha-1" || thread.Replies[0].Sender != "Alice Example" || thread.Replies[1].Text != "alpha-2" {
+			t.Errorf("replies = %+v, %+v", thread.Replies[0], thread.Replies[1])
+		}
+		if !slices.Equal(thread.Participants, []string{"Fake User", "Alice Example"}) {
+			t.Errorf("participants = %v", thread.Participants)
+		}
+		if !thread.LastActivity.Equal(thread.Replies[1].Created) {
+			t.Errorf("last activity = %v, want %v", thread.LastActivity, thread.Replies[1].Created)
+		}
+	}
+
+	if _, err := GetThread(client, "missing"); err == nil {
+		t.Error("GetThread() of an unknown message succeeded")
+	}
+}
+
+func TestListThreads(t *testing.T) {
+	client, roomID, ids := newThreadedRoom(t)
+	betaReply, err := GetThread(client, ids["beta-1"])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		opts      ThreadOptions
+		threads   []string
+		truncated bool
+	}{
+		{name: "all", threads: []string{"alpha", "beta"}},
+		{name: "max", opts: ThreadOptions{MaxThreads: 1}, threads: []string{"alpha"}},
+		{name: "scan reaches a parent outside it", opts: ThreadOptions{ScanMessages: 2}, threads: []string{"alpha", "beta"}, truncated: true},
+		{name: "scan", opts: ThreadOptions{ScanMessages: 1}, threads: []string{"alpha"}, truncated: true},
+		{name: "to", opts: ThreadOptions{To: betaReply.Replies[0].Created}, threads: []string{"alpha"}},
+		{name: "from", opts: ThreadOptions{From: betaReply.Replies[0].Created}, threads: []string{"alpha", "beta"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list, err := ListThreads(client, roomID, tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var parents []string
+			for _, thread := range list.Threads {
+				parents = append(parents, thread.Parent.Text)
+				if thread.Replies != nil {
+					t.Errorf("thread %s has replies without IncludeReplies", thread.Parent.Text)
+				}
+			}
+			if !slices.Equal(parents, tt.threads) || list.Truncated != tt.truncated {
+				t.Errorf("threads = %v, truncated %v; want %v, %v", parents, list.Truncated, tt.threads, tt.truncated)
+			}
+			// Counts cover the whole thread, not only the scanned replies
+			if list.Threads[0].ReplyCount != 2 {
+				t.Errorf("alpha reply count = %d, want 2", list.Threads[0].ReplyCount)
+			}
+		})
+	}
+
+	list, err := ListThreads(client, roomID, ThreadOptions{IncludeReplies: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if beta := list.Threads[1]; len(beta.Replies) != 1 || beta.Replies[0].Text != "beta-1" || !beta.LastActivity.Equal(beta.Replies[0].Created) {
+		t.Errorf("beta thread = %+v", beta)
+	}
+	if _, err := ListThreads(client, "", ThreadOptions{}); err == nil {
+		t.Error("ListThreads() without a room succeeded")
+	}
+}
@@ -0,0 +1,200 @@
+// Package transcript walks the message history of a Webex room, groups it into
+// threads and renders it as Markdown, JSON Lines or a self-contained HTML page.
+package transcript
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/raja-aiml/webex-mcp-server/internal/webex"
+)
+
+// PageSize is the number of messages requested per page
+const PageSize = 100
+
+// DefaultMaxMessages bounds a transcript when Options.MaxMessages is not set
+const DefaultMaxMessages = 10000
+
+// Message is one message of a transcript, with its thread replies
+type Message struct {
+	ID          string     `json:"id"`
+	ParentID    string     `json:"parentId,omitempty"`
+	PersonID    string     `json:"personId,omitempty"`
+	PersonEmail string     `json:"personEmail,omitempty"`
+	Sender      string     `json:"sender"` // Display name, or the email when it cannot be resolved
+	Text        string     `json:"text,omitempty"`
+	Markdown    string     `json:"markdown,omitempty"`
+	Files       []string   `json:"files,omitempty"`
+	Created     time.Time  `json:"created"`
+	Replies     []*Message `json:"-"`
+}
+
+// Transcript is the history of one room, oldest message first
+type Transcript struct {
+	RoomID    string
+	RoomTitle string
+	From      time.Time  // Zero when unbounded
+	To        time.Time  // Zero when unbounded
+	Messages  []*Message // Top-level messages; replies are nested under their parents
+	Count     int        // Messages including replies
+	Truncated bool       // MaxMessages was reached before the start of the range
+}
+
+// Options selects the part of the history to fetch
+type Options struct {
+	From        time.Time // Only messages created at or after From
+	To          time.Time // Only messages created before To
+	MaxMessages int       // Stop after this many messages, newest kept; 0 means DefaultMaxMessages
+}
+
+// Fetch pages through the room's messages, newest first as Webex returns them,
+// resolves sender names and nests thread replies under their parents
+func Fetch(client webex.HTTPClient, roomID string, opts Options) (*Transcript, error) {
+	if roomID == "" {
+		return nil, fmt.Errorf("roomId is required")
+	}
+	if !opts.From.IsZero() && !opts.To.IsZero() && !opts.From.Before(opts.To) {
+		return nil, fmt.Errorf("from must be before to")
+	}
+	limit := opts.MaxMessages
+	if limit <= 0 {
+		limit = DefaultMaxMessages
+	}
+
+	room, err := client.Get("/rooms/"+roomID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get room: %w", err)
+	}
+	t := &Transcript{RoomID: roomID, RoomTitle: stringField(room, "title"), From: opts.From, To: opts.To}
+
+	params := map[string]string{"roomId": roomID}
+	if !opts.To.IsZero() {
+		params["before"] = opts.To.UTC().Format(time.RFC3339)
+	}
+	messages, truncated, err := ListMessages(client, params, opts.From, limit)
+	if err != nil {
+		return nil, err
+	}
+	t.Truncated = truncated
+
+	ResolveSenders(client, messages)
+	t.Count = len(messages)
+	t.Messages = nest(messages)
+	return t, nil
+}
+
+// ListMessages pages backwards through /messages with params, newest first, until a message older
+// than from, or until limit messages; truncated reports that the limit stopped it
+func ListMessages(client webex.HTTPClient, params map[string]string, from time.Time, limit int) ([]*Message, bool, error) {
+	var messages []*Message
+	query := map[string]string{"max": fmt.Sprint(PageSize)}
+	for key, value := range params {
+		query[key] = value
+	}
+	for {
+		page, err := client.Get("/messages", query)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to list messages: %w", err)
+		}
+		items, _ := page["items"].([]interface{})
+		for _, item := range items {
+			fields, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			message := newMessage(fields)
+			if !from.IsZero() && message.Created.Before(from) {
+				return messages, false, nil
+			}
+			if len(messages) == limit {
+				return messages, true, nil
+			}
+			messages = append(messages, message)
+		}
+		if len(items) < PageSize {
+			return messages, false, nil
+		}
+
+		// Later pages continue before the oldest message so far instead of a time
+		last, _ := items[len(items)-1].(map[string]interface{})
+		delete(query, "before")
+		query["beforeMessage"] = stringField(last, "id")
+	}
+}
+
+func newMessage(fields map[string]interface{}) *Message {
+	message := &Message{
+		ID:          stringField(fields, "id"),
+		ParentID:    stringField(fields, "parentId"),
+		PersonID:    stringField(fields, "personId"),
+		PersonEmail: stringField(fields, "personEmail"),
+		Text:        stringField(fields, "text"),
+		Markdown:    stringField(fields, "markdown"),
+	}
+	message.Created, _ = time.Parse(time.RFC3339Nano, stringField(fields, "created"))
+	if files, ok := fields["files"].([]interface{}); ok {
+		for _, file := range files {
+			if url, ok := file.(string); ok {
+				message.Files = append(message.Files, url)
+			}
+		}
+	}
+	return message
+}
+
+// ResolveSenders looks up each sender's display name once, falling back to the email
+func ResolveSenders(client webex.HTTPClient, messages []*Message) {
+	names := map[string]string{}
+	for _, message := range messages {
+		name, ok := names[message.PersonID]
+		if !ok && message.PersonID != "" {
+			if person, err := client.Get("/people/"+message.PersonID, nil); err == nil {
+				name = stringField(person, "displayName")
+			}
+			names[message.PersonID] = name
+		}
+		if name == "" {
+			name = message.PersonEmail
+		}
+		if name == "" {
+			name = "Unknown"
+		}
+		message.Sender = name
+	}
+}
+
+// nest orders messages oldest first and moves replies under their parents.
+// A reply whose parent is outside the range stays at the top level.
+func nest(messages []*Message) []*Message {
+	oldestFirst(messages)
+	byID := make(map[string]*Message, len(messages))
+	for _, message := range messages {
+		byID[message.ID] = message
+	}
+	var top []*Message
+	for _, message := range messages {
+		if parent := byID[message.ParentID]; parent != nil && message.ParentID != "" {
+			parent.Replies = append(parent.Replies, message)
+			continue
+		}
+		top = append(top, message)
+	}
+	return top
+}
+
+// oldestFirst sorts messages listed newest first, as Webex returns them, by creation time
+func oldestFirst(messages []*Message) {
+	// Reverse before sorting so equal timestamps keep their order
+	for i, j := 0, len(messages)-1; i < j; i, j = i+1, j-1 {
+		messages[i], messages[j] = messages[j], messages[i]
+	}
+	sort.SliceStable(messages, func(i, j int) bool {
+		return messages[i].Created.Before(messages[j].Created)
+	})
+}
+
+func stringField(fields map[string]interface{}, key string) string {
+	value, _ := fields[key].(string)
+	return value
+}
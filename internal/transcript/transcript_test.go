@@ -0,0 +1,203 @@
+package transcript
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/raja-aiml/webex-mcp-server/internal/config"
+	"github.com/raja-aiml/webex-mcp-server/internal/fakewebex"
+	"github.com/raja-aiml/webex-mcp-server/internal/testutil"
+	"github.com/raja-aiml/webex-mcp-server/internal/webex"
+)
+
+var start = time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+// newRoom seeds a room with a thread, a message from another person and filler
+// messages one minute apart, so the history spans more than one page
+func newRoom(t *testing.T, filler int) (webex.HTTPClient, string) {
+	t.Helper()
+	fake, baseURL := testutil.NewFakeWebex(t)
+	clock := start
+	fake.SetClock(func() time.Time {
+		clock = clock.Add(time.Minute)
+		return clock
+	})
+
+	alice, err := fake.Add(fakewebex.People, fakewebex.Item{"displayName": "Alice Example", "emails": []interface{}{"alice@example.com"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	room, err := fake.Add(fakewebex.Rooms, fakewebex.Item{"title": "Launch Plan"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	roomID := room["id"].(string)
+	kickoff, err := fake.Add(fakewebex.Messages, fakewebex.Item{"roomId": roomID, "text": "Kickoff <script>alert(1)</script>"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fake.PostAs(alice["id"].(string), fakewebex.Item{"roomId": roomID, "text": "Sounds good\nsee you there", "parentId": kickoff["id"]}); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < filler; i++ {
+		if _, err := fake.Add(fakewebex.Messages, fakewebex.Item{"roomId": roomID, "markdown": fmt.Sprintf("update **%d**", i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	client, err := webex.NewClientWithConfig(&config.Config{WebexAPIKey: "token", WebexAPIBaseURL: baseURL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, roomID
+}
+
+func TestFetch(t *testing.T) {
+	client, roomID := newRoom(t, 150)
+
+	transcript, err := Fetch(client, roomID, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if transcript.RoomTitle != "Launch Plan" || transcript.Count != 152 || len(transcript.Messages) != 151 || transcript.Truncated {
+		t.Fatalf("transcript of %q has %d messages, %d top-level, truncated %v", transcript.RoomTitle, transcript.Count, len(transcript.Messages), transcript.Truncated)
+	}
+	kickoff := transcript.Messages[0]
+	if !strings.HasPrefix(kickoff.Text, "Kickoff") || kickoff.Sender != "Fake User" {
+		t.Errorf("first message = %+v", kickoff)
+	}
+	if len(kickoff.Replies) != 1 || kickoff.Replies[0].Sender != "Alice Example" {
+		t.Errorf("kickoff replies = %+v", kickoff.Replies)
+	}
+	if last := transcript.Messages[150]; last.Markdown != "update **149**" {
+		t.Errorf("last message = %+v", last)
+	}
+
+	// Ranges are taken from the fetched history: Messages[i] for i > 0 is "update **i-1**"
+	created := func(i int) time.Time { return transcript.Messages[i].Created }
+	tests := []struct {
+		name      string
+		opts      Options
+		count     int
+		first     string
+		truncated bool
+	}{
+		{name: "from", opts: Options{From: created(146)}, count: 5, first: "update **145**"},
+		{name: "to", opts: Options{To: created(2)}, count: 3, first: "Kickoff <script>alert(1)</script>"},
+		{name: "range", opts: Options{From: created(10), To: created(20)}, count: 10, first: "update **9**"},
+		{name: "max keeps the newest", opts: Options{MaxMessages: 3}, count: 3, first: "update **147**", truncated: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transcript, err := Fetch(client, roomID, tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if transcript.Count != tt.count || transcript.Truncated != tt.truncated {
+				t.Errorf("got %d messages, truncated %v; want %d, %v", transcript.Count, transcript.Truncated, tt.count, tt.truncated)
+			}
+			if len(transcript.Messages) > 0 && transcript.Messages[0].Body() != tt.first {
+				t.Errorf("first message = %q, want %q", transcript.Messages[0].Body(), tt.first)
+			}
+		})
+	}
+
+	if _, err := Fetch(client, roomID, Options{From: start.Add(time.Hour), To: start}); err == nil {
+		t.Error("Fetch() with from after to succeeded")
+	}
+	if _, err := Fetch(client, "missing", Options{}); err == nil {
+		t.Error("Fetch() of an unknown room succeeded")
+	}
+}
+
+func TestRender(t *testing.T) {
+	client, roomID := newRoom(t, 1)
+	transcript, err := Fetch(client, roomID, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var markdown bytes.Buffer
+	if err := Render(&markdown, transcript, FormatMarkdown); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"# Launch Plan", "_3 messages, all messages._", "## 2026-03-01", "**Fake User** · 09:", ">\n> **Alice Example** · 09:", "> Sounds good\n> see you there\n", "update **0**"} {
+		if !strings.Contains(markdown.String(), want) {
+			t.Errorf("Markdown does not contain %q:\n%s", want, markdown.String())
+		}
+	}
+
+	var jsonl bytes.Buffer
+	if err := Render(&jsonl, transcript, FormatJSONL); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(jsonl.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("JSONL has %d lines, want 3:\n%s", len(lines), jsonl.String())
+	}
+	var reply Message
+	if err := json.Unmarshal([]byte(lines[1]), &reply); err != nil || reply.ParentID != transcript.Messages[0].ID || reply.Sender != "Alice Example" {
+		t.Errorf("second line = %s, %v", lines[1], err)
+	}
+
+	var html bytes.Buffer
+	if err := Render(&html, transcript, FormatHTML); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(html.String(), "<script>") || !strings.Contains(html.String(), "&lt;script&gt;") {
+		t.Errorf("HTML does not escape message text:\n%s", html.String())
+	}
+	if !strings.Contains(html.String(), `<div class="thread">`) || !strings.Contains(html.String(), "<title>Launch Plan</title>") {
+		t.Errorf("HTML is missing the thread or title:\n%s", html.String())
+	}
+
+	if err := Render(&html, transcript, "pdf"); err == nil {
+		t.Error("Render() with an unknown format succeeded")
+	}
+}
+
+func TestWriteFile(t *testing.T) {
+	transcript := &Transcript{RoomID: "room", RoomTitle: "Q3 Planning / Ops!"}
+	dir := filepath.Join(t.TempDir(), "exports")
+	now := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+
+	path, err := WriteFile(dir, transcript, FormatHTML, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(dir, "q3-planning-ops-20260301-093000.html"); path != want {
+		t.Errorf("path = %s, want %s", path, want)
+	}
+	info, err := os.Stat(path)
+	if err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("file mode = %v, %v, want 0600", info.Mode().Perm(), err)
+	}
+	if _, err := WriteFile(dir, transcript, FormatHTML, now); err == nil {
+		t.Error("WriteFile() overwrote an existing export")
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{value: "", want: time.Time{}},
+		{value: "2026-03-01", want: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{value: "2026-03-01T10:00:00+01:00", want: start},
+		{value: "yesterday", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseTime(tt.value)
+		if (err != nil) != tt.wantErr || !got.Equal(tt.want) {
+			t.Errorf("ParseTime(%q) = %v, %v", tt.value, got, err)
+		}
+	}
+}
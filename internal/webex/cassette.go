@@ -0,0 +1,348 @@
+package webex
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/raja-aiml/webex-mcp-server/internal/config"
+	"github.com/raja-aiml/webex-mcp-server/internal/secrets"
+)
+
+// cassetteVersion is written to cassette files so the format can evolve
+const cassetteVersion = 1
+
+// scrubbedDomain replaces the domain of every email address in a cassette
+const scrubbedDomain = "example.com"
+
+var emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+
+// Interaction is one recorded call through HTTPClient
+type Interaction struct {
+	Method   string                 `json:"method"`
+	Endpoint string                 `json:"endpoint"`
+	Params   map[string]string      `json:"params,omitempty"`
+	Body     interface{}            `json:"body,omitempty"`
+	Response map[string]interface{} `json:"response,omitempty"`
+	Error    *RecordedError         `json:"error,omitempty"`
+}
+
+// RecordedError is an error returned by the recorded call
+type RecordedError struct {
+	StatusCode int    `json:"statusCode,omitempty"` // Set for Webex API errors
+	Body       string `json:"body,omitempty"`       // API error body
+	RetryAfter int    `json:"retryAfter,omitempty"` // Seconds, from the Retry-After header of an API error
+	Message    string `json:"message,omitempty"`    // Other errors, such as network failures
+}
+
+// Cassette is a file of recorded interactions, shared by every client that uses it
+type Cassette struct {
+	path string
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool // Interactions already served by replay
+	end          int64  // Offset of cassetteEnd in the file once this cassette wrote it, otherwise 0
+}
+
+// cassetteEnd closes the interactions list and the file as json.MarshalIndent writes them
+const cassetteEnd = "\n  ]\n}\n"
+
+// cassetteFile is the on-disk format
+type cassetteFile struct {
+	Version      int           `json:"version"`
+	Interactions []Interaction `json:"interactions"`
+}
+
+var (
+	cassettesMu sync.Mutex
+	cassettes   = map[string]*Cassette{}
+)
+
+// LoadCassette reads the cassette at path; a missing file gives an empty cassette
+func LoadCassette(path string) (*Cassette, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cassette := &Cassette{path: abs}
+	data, err := os.ReadFile(abs)
+	if os.IsNotExist(err) {
+		return cassette, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cassette: %w", err)
+	}
+
+	var file cassetteFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse cassette %s: %w", path, err)
+	}
+	if file.Version != cassetteVersion {
+		return nil, fmt.Errorf("cassette %s has version %d, want %d", path, file.Version, cassetteVersion)
+	}
+	cassette.interactions = file.Interactions
+	cassette.used = make([]bool, len(file.Interactions))
+	return cassette, nil
+}
+
+// sharedCassette returns the process-wide cassette for path, so every client
+// built from the configuration records into, or replays from, the same sequence
+func sharedCassette(path string) (*Cassette, error) {
+	cassettesMu.Lock()
+	defer cassettesMu.Unlock()
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	if cassette, ok := cassettes[abs]; ok {
+		return cassette, nil
+	}
+	cassette, err := LoadCassette(abs)
+	if err != nil {
+		return nil, err
+	}
+	cassettes[abs] = cassette
+	return cassette, nil
+}
+
+// Unused returns the recorded interactions that replay has not served yet
+func (c *Cassette) Unused() []Interaction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var unused []Interaction
+	for i, interaction := range c.interactions {
+		if !c.used[i] {
+			unused = append(unused, interaction)
+		}
+	}
+	return unused
+}
+
+// record appends an interaction to the file, so a crash loses nothing. The first
+// recording writes the whole file; later ones overwrite only its closing brackets.
+func (c *Cassette) record(interaction Interaction) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.interactions = append(c.interactions, interaction)
+	c.used = append(c.used, true)
+
+	if c.end == 0 {
+		return c.writeAll()
+	}
+	data, err := json.MarshalIndent(interaction, "    ", "  ")
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(c.path, os.O_WRONLY, 0600)
+	if err != nil {
+		c.end = 0
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+	defer file.Close()
+	tail := ",\n    " + string(data)
+	if _, err := file.WriteAt([]byte(tail+cassetteEnd), c.end); err != nil {
+		c.end = 0
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+	c.end += int64(len(tail))
+	return nil
+}
+
+// writeAll replaces the file with every interaction
+func (c *Cassette) writeAll() error {
+	data, err := json.MarshalIndent(cassetteFile{Version: cassetteVersion, Interactions: c.interactions}, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+	if err := os.Rename(tmp, c.path); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+	c.end = int64(len(data) - len(cassetteEnd))
+	return nil
+}
+
+// take returns the first unused interaction matching the request
+func (c *Cassette) take(request Interaction) (Interaction, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, interaction := range c.interactions {
+		if c.used[i] || interaction.Method != request.Method || interaction.Endpoint != request.Endpoint {
+			continue
+		}
+		if !reflect.DeepEqual(normalizeParams(interaction.Params), normalizeParams(request.Params)) ||
+			!reflect.DeepEqual(interaction.Body, request.Body) {
+			continue
+		}
+		c.used[i] = true
+		return interaction, true
+	}
+	return Interaction{}, false
+}
+
+// CassetteClient records calls made through another client, or replays them from a cassette
+type CassetteClient struct {
+	next     HTTPClient // nil when replaying
+	cassette *Cassette
+}
+
+// NewCassetteClient returns a client for mode: recording wraps next, replaying never calls it
+func NewCassetteClient(next HTTPClient, cassette *Cassette, mode string) (*CassetteClient, error) {
+	switch mode {
+	case config.CassetteRecord:
+		return &CassetteClient{next: next, cassette: cassette}, nil
+	case config.CassetteReplay:
+		return &CassetteClient{cassette: cassette}, nil
+	default:
+		return nil, fmt.Errorf("unknown cassette mode %q (want %s or %s)", mode, config.CassetteRecord, config.CassetteReplay)
+	}
+}
+
+// Cassette returns the cassette the client reads or writes
+func (c *CassetteClient) Cassette() *Cassette {
+	return c.cassette
+}
+
+// Get performs or replays a GET request
+func (c *CassetteClient) Get(endpoint string, params map[string]string) (map[string]interface{}, error) {
+	return c.do(Interaction{Method: "GET", Endpoint: endpoint, Params: params}, func() (map[string]interface{}, error) {
+		return c.next.Get(endpoint, params)
+	})
+}
+
+// Post performs or replays a POST request
+func (c *CassetteClient) Post(endpoint string, data interface{}) (map[string]interface{}, error) {
+	return c.do(Interaction{Method: "POST", Endpoint: endpoint, Body: data}, func() (map[string]interface{}, error) {
+		return c.next.Post(endpoint, data)
+	})
+}
+
+// Put performs or replays a PUT request
+func (c *CassetteClient) Put(endpoint string, data interface{}) (map[string]interface{}, error) {
+	return c.do(Interaction{Method: "PUT", Endpoint: endpoint, Body: data}, func() (map[string]interface{}, error) {
+		return c.next.Put(endpoint, data)
+	})
+}
+
+// Delete performs or replays a DELETE request
+func (c *CassetteClient) Delete(endpoint string) error {
+	_, err := c.do(Interaction{Method: "DELETE", Endpoint: endpoint}, func() (map[string]interface{}, error) {
+		return nil, c.next.Delete(endpoint)
+	})
+	return err
+}
+
+func (c *CassetteClient) do(request Interaction, call func() (map[string]interface{}, error)) (map[string]interface{}, error) {
+	request, err := scrubInteraction(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.next == nil {
+		interaction, ok := c.cassette.take(request)
+		if !ok {
+			return nil, fmt.Errorf("cassette %s has no unused recording of %s %s", c.cassette.path, request.Method, request.Endpoint)
+		}
+		return interaction.Response, interaction.Error.err()
+	}
+
+	response, callErr := call()
+	request.Response = response
+	if callErr != nil {
+		request.Error = newRecordedError(callErr)
+	}
+	// The call has been made, so its result is returned even when it cannot be recorded
+	recorded, err := scrubInteraction(request)
+	if err == nil {
+		err = c.cassette.record(recorded)
+	}
+	if err != nil {
+		log.Printf("[Cassette] Failed to record %s %s in %s: %v", request.Method, request.Endpoint, c.cassette.path, err)
+	}
+	return response, callErr
+}
+
+func newRecordedError(err error) *RecordedError {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return &RecordedError{StatusCode: apiErr.StatusCode, Body: string(apiErr.Body), RetryAfter: int(apiErr.RetryAfter / time.Second)}
+	}
+	return &RecordedError{Message: err.Error()}
+}
+
+// err rebuilds the recorded error
+func (e *RecordedError) err() error {
+	if e == nil {
+		return nil
+	}
+	if e.StatusCode != 0 {
+		return &APIError{StatusCode: e.StatusCode, Body: []byte(e.Body), RetryAfter: time.Duration(e.RetryAfter) * time.Second}
+	}
+	return errors.New(e.Message)
+}
+
+// scrubInteraction removes registered secrets and replaces email addresses with stable
+// placeholders. Requests are scrubbed the same way on replay, so they still match.
+func scrubInteraction(interaction Interaction) (Interaction, error) {
+	data, err := json.Marshal(interaction)
+	if err != nil {
+		return interaction, fmt.Errorf("failed to encode interaction: %w", err)
+	}
+	var scrubbed Interaction
+	if err := json.Unmarshal([]byte(scrubString(string(data))), &scrubbed); err != nil {
+		return interaction, fmt.Errorf("failed to scrub interaction: %w", err)
+	}
+	return scrubbed, nil
+}
+
+// scrubString redacts secrets and maps each email to user-<hash>@example.com
+func scrubString(s string) string {
+	s = secrets.Redact(s)
+	return emailPattern.ReplaceAllStringFunc(s, func(email string) string {
+		if strings.HasSuffix(strings.ToLower(email), "@"+scrubbedDomain) {
+			return email
+		}
+		sum := sha256.Sum256([]byte(strings.ToLower(email)))
+		return "user-" + hex.EncodeToString(sum[:4]) + "@" + scrubbedDomain
+	})
+}
+
+// normalizeParams drops empty values, which the client does not send
+func normalizeParams(params map[string]string) map[string]string {
+	normalized := map[string]string{}
+	for key, value := range params {
+		if value != "" {
+			normalized[key] = value
+		}
+	}
+	return normalized
+}
+
+// withCassette wraps client when the configuration names a cassette
+func withCassette(client HTTPClient, cfg config.HTTPConfig) (HTTPClient, error) {
+	if cfg.Cassette == "" {
+		return client, nil
+	}
+	cassette, err := sharedCassette(cfg.Cassette)
+	if err != nil {
+		return nil, err
+	}
+	return NewCassetteClient(client, cassette, cfg.CassetteMode)
+}
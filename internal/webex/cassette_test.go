@@ -0,0 +1,170 @@
+package webex
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/raja-aiml/webex-mcp-server/internal/config"
+	"github.com/raja-aiml/webex-mcp-server/internal/fakewebex"
+	"github.com/raja-aiml/webex-mcp-server/internal/secrets"
+)
+
+func TestCassetteClient_RecordAndReplay(t *testing.T) {
+	fake := fakewebex.New()
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	path := filepath.Join(t.TempDir(), "session.json")
+	secrets.Register("webhook-secret-value")
+
+	// Record against the fake
+	real, err := NewClientWithConfig(&config.Config{WebexAPIKey: "token", WebexAPIBaseURL: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cassette, err := LoadCassette(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorder, err := NewCassetteClient(real, cassette, config.CassetteRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	room, err := recorder.Post("/rooms", map[string]interface{}{"title": "Launch"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	roomID := room["id"].(string)
+	if _, err := recorder.Post("/memberships", map[string]interface{}{"roomId": roomID, "personEmail": "jane.doe@corp.example.org"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := recorder.Post("/webhooks", map[string]interface{}{"name": "hook", "targetUrl": "https://example.com/hook", "resource": "messages", "event": "created", "secret": "webhook-secret-value"}); err != nil {
+		t.Fatal(err)
+	}
+	members, err := recorder.Get("/memberships", map[string]string{"roomId": roomID, "max": ""})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, getErr := recorder.Get("/rooms/missing", nil)
+	if getErr == nil {
+		t.Fatal("expected an error for a missing room")
+	}
+	fake.InjectFault(fakewebex.Fault{Path: "/people", Status: http.StatusTooManyRequests, RetryAfter: 3 * time.Second})
+	if _, err := recorder.Get("/people", nil); err == nil {
+		t.Fatal("expected a rate limit error")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Appending keeps the file as it would be if written at once
+	whole, _ := json.MarshalIndent(cassetteFile{Version: cassetteVersion, Interactions: cassette.interactions}, "", "  ")
+	if string(data) != string(whole)+"\n" {
+		t.Errorf("cassette file = %s, want %s", data, whole)
+	}
+	for _, leaked := range []string{"jane.doe@corp.example.org", "webhook-secret-value"} {
+		if strings.Contains(string(data), leaked) {
+			t.Errorf("cassette contains %q", leaked)
+		}
+	}
+
+	// Replay from a fresh load without the server
+	server.Close()
+	cassette, err = LoadCassette(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	player, err := NewCassetteClient(nil, cassette, config.CassetteReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	replayedRoom, err := player.Post("/rooms", map[string]interface{}{"title": "Launch"})
+	if err != nil || replayedRoom["id"] != roomID {
+		t.Fatalf("replayed room = %v, %v", replayedRoom, err)
+	}
+	// The original email matches its scrubbed recording
+	if _, err := player.Post("/memberships", map[string]interface{}{"roomId": roomID, "personEmail": "jane.doe@corp.example.org"}); err != nil {
+		t.Errorf("replay membership error = %v", err)
+	}
+	if _, err := player.Post("/webhooks", map[string]interface{}{"name": "hook", "targetUrl": "https://example.com/hook", "resource": "messages", "event": "created", "secret": "webhook-secret-value"}); err != nil {
+		t.Errorf("replay webhook error = %v", err)
+	}
+	replayedMembers, err := player.Get("/memberships", map[string]string{"roomId": roomID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(replayedMembers["items"].([]interface{})), len(members["items"].([]interface{})); got != want {
+		t.Errorf("replayed %d memberships, want %d", got, want)
+	}
+	var apiErr *APIError
+	if _, err := player.Get("/rooms/missing", nil); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("replayed error = %v, want a 404 APIError", err)
+	}
+	if _, err := player.Get("/people", nil); !errors.As(err, &apiErr) || apiErr.RetryAfter != 3*time.Second {
+		t.Errorf("replayed error = %v, want a 429 APIError with its Retry-After", err)
+	}
+
+	if unused := cassette.Unused(); len(unused) != 0 {
+		t.Errorf("unused interactions: %v", unused)
+	}
+	if err := player.Delete("/rooms/" + roomID); err == nil || !strings.Contains(err.Error(), "no unused recording of DELETE") {
+		t.Errorf("unrecorded request error = %v", err)
+	}
+}
+
+func TestCassetteClient_RecordFailure(t *testing.T) {
+	fake := fakewebex.New()
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	real, err := NewClientWithConfig(&config.Config{WebexAPIKey: "token", WebexAPIBaseURL: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cassette, err := LoadCassette(filepath.Join(t.TempDir(), "missing", "session.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorder, _ := NewCassetteClient(real, cassette, config.CassetteRecord)
+
+	// The room was created, so the caller learns about it even though it was not recorded
+	room, err := recorder.Post("/rooms", map[string]interface{}{"title": "Launch"})
+	if err != nil || room["id"] == nil {
+		t.Errorf("Post() = %v, %v, want the created room", room, err)
+	}
+}
+
+func TestNewClientWithConfig_Cassette(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "replay.json")
+	content := `{"version": 1, "interactions": [{"method": "GET", "endpoint": "/people/me", "response": {"displayName": "Replayed"}}]}`
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &config.Config{WebexAPIKey: "token", WebexAPIBaseURL: "http://127.0.0.1:1"}
+	cfg.HTTP.Cassette = path
+	cfg.HTTP.CassetteMode = config.CassetteReplay
+	client, err := NewClientWithConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	me, err := client.Get("/people/me", nil)
+	if err != nil || me["displayName"] != "Replayed" {
+		t.Errorf("Get(/people/me) = %v, %v", me, err)
+	}
+
+	cfg.HTTP.Cassette = filepath.Join(t.TempDir(), "broken.json")
+	os.WriteFile(cfg.HTTP.Cassette, []byte(`{"version": 99}`), 0600)
+	if _, err := NewClientWithConfig(cfg); err == nil || !strings.Contains(err.Error(), "version 99") {
+		t.Errorf("NewClientWithConfig() with a future cassette error = %v", err)
+	}
+}
@@ -7,18 +7,34 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/raja-aiml/webex-mcp-server/internal/config"
 )
 
+// APIError is an error response from the Webex API
+type APIError struct {
+	StatusCode int
+	Body       []byte
+	RetryAfter time.Duration // From the Retry-After header, sent with 429 Too Many Requests
+}
+
+func (e *APIError) Error() string {
+	var errorData map[string]interface{}
+	if err := json.Unmarshal(e.Body, &errorData); err != nil {
+		return fmt.Sprintf("HTTP %d: %s", e.StatusCode, string(e.Body))
+	}
+	return fmt.Sprintf("webex API error: %v", errorData)
+}
+
 // handleHTTPError processes HTTP error responses in a consistent way
 func handleHTTPError(resp *http.Response, body []byte) error {
-	var errorData map[string]interface{}
-	if err := json.Unmarshal(body, &errorData); err != nil {
-		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+	apiErr := &APIError{StatusCode: resp.StatusCode, Body: body}
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		apiErr.RetryAfter = time.Duration(seconds) * time.Second
 	}
-	return fmt.Errorf("webex API error: %v", errorData)
+	return apiErr
 }
 
 // Client provides a simple HTTP client for Webex API calls
@@ -40,13 +56,16 @@ func NewClient() (HTTPClient, error) {
 		return nil, fmt.Errorf("failed to get headers: %w", err)
 	}
 
-	return &Client{
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-		baseURL: cfg.WebexAPIBaseURL,
-		headers: headers,
-	}, nil
+	httpClient, err := NewHTTPClient(cfg.HTTP)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure HTTP transport: %w", err)
+	}
+
+	return withCassette(&Client{
+		httpClient: httpClient,
+		baseURL:    cfg.WebexAPIBaseURL,
+		headers:    headers,
+	}, cfg.HTTP)
 }
 
 // NewClientWithConfig creates a client with provided configuration
@@ -60,13 +79,16 @@ func NewClientWithConfig(cfg *config.Config) (HTTPClient, error) {
 		"Authorization": fmt.Sprintf("Bearer %s", cfg.WebexAPIKey),
 	}
 
-	return &Client{
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-		baseURL: cfg.WebexAPIBaseURL,
-		headers: headers,
-	}, nil
+	httpClient, err := NewHTTPClient(cfg.HTTP)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure HTTP transport: %w", err)
+	}
+
+	return withCassette(&Client{
+		httpClient: httpClient,
+		baseURL:    cfg.WebexAPIBaseURL,
+		headers:    headers,
+	}, cfg.HTTP)
 }
 
 // Get performs a GET request
@@ -151,6 +173,12 @@ func (c *Client) doRequest(method, url string, data interface{}) (map[string]int
 		req.Header.Set("Content-Type", "application/json")
 	}
 
+	release, err := acquireSlot(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, err
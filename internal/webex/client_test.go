@@ -2,8 +2,10 @@ package webex
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/raja-aiml/webex-mcp-server/internal/config"
 	"github.com/raja-aiml/webex-mcp-server/internal/testutil"
@@ -208,10 +210,12 @@ func TestClient_Delete(t *testing.T) {
 
 func TestClient_ErrorHandling(t *testing.T) {
 	tests := []struct {
-		name       string
-		statusCode int
-		response   interface{}
-		wantErr    bool
+		name           string
+		statusCode     int
+		retryAfter     string
+		response       interface{}
+		wantErr        bool
+		wantRetryAfter time.Duration
 	}{
 		{
 			name:       "handles 400 error",
@@ -231,11 +235,22 @@ func TestClient_ErrorHandling(t *testing.T) {
 			response:   map[string]interface{}{"error": "internal server error"},
 			wantErr:    true,
 		},
+		{
+			name:           "handles 429 error with Retry-After",
+			statusCode:     429,
+			retryAfter:     "7",
+			response:       map[string]interface{}{"message": "too many requests"},
+			wantErr:        true,
+			wantRetryAfter: 7 * time.Second,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			server := testutil.MockHTTPServer(t, func(w http.ResponseWriter, r *http.Request) {
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
 				w.WriteHeader(tt.statusCode)
 				json.NewEncoder(w).Encode(tt.response)
 			})
@@ -255,6 +270,10 @@ func TestClient_ErrorHandling(t *testing.T) {
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Get() error = %v, wantErr %v", err, tt.wantErr)
 			}
+			var apiErr *APIError
+			if errors.As(err, &apiErr) && apiErr.RetryAfter != tt.wantRetryAfter {
+				t.Errorf("RetryAfter = %v, want %v", apiErr.RetryAfter, tt.wantRetryAfter)
+			}
 		})
 	}
 }
@@ -0,0 +1,59 @@
+package webex
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/raja-aiml/webex-mcp-server/internal/ratelimit"
+)
+
+// BusyRetryAfter is the retry hint given when the concurrency cap is reached
+const BusyRetryAfter = time.Second
+
+// BusyError is returned when every concurrent request slot stayed busy for the configured wait
+type BusyError struct {
+	RetryAfter time.Duration
+}
+
+func (e *BusyError) Error() string {
+	return fmt.Sprintf("too many concurrent Webex requests, retry after %s", e.RetryAfter)
+}
+
+var (
+	inFlightMu   sync.RWMutex
+	inFlight     *ratelimit.Semaphore
+	inFlightMax  int
+	inFlightWait time.Duration
+)
+
+// SetMaxInFlight caps concurrent Webex API requests across every client and profile.
+// A request waits up to wait for a free slot before failing with a BusyError; max <= 0 removes the cap.
+// The slots are kept while max is unchanged, so requests in flight still count after a reload.
+func SetMaxInFlight(max int, wait time.Duration) {
+	inFlightMu.Lock()
+	defer inFlightMu.Unlock()
+	inFlightWait = wait
+	if max <= 0 {
+		max = 0
+	}
+	if max == inFlightMax {
+		return
+	}
+	inFlight = ratelimit.NewSemaphore(max)
+	inFlightMax = max
+}
+
+// acquireSlot reserves a concurrent request slot and returns its release function
+func acquireSlot(ctx context.Context) (func(), error) {
+	inFlightMu.RLock()
+	sem, wait := inFlight, inFlightWait
+	inFlightMu.RUnlock()
+
+	release := sem.Acquire(ctx, wait)
+	if release == nil {
+		return nil, &BusyError{RetryAfter: BusyRetryAfter}
+	}
+	return release, nil
+}
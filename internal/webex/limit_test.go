@@ -0,0 +1,51 @@
+package webex
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/raja-aiml/webex-mcp-server/internal/config"
+)
+
+func TestSetMaxInFlight(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	SetMaxInFlight(1, 20*time.Millisecond)
+	defer SetMaxInFlight(0, 0)
+
+	client, err := NewClientWithConfig(&config.Config{WebexAPIKey: "token", WebexAPIBaseURL: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first := make(chan error, 1)
+	go func() {
+		_, err := client.Get("/rooms", nil)
+		first <- err
+	}()
+	time.Sleep(20 * time.Millisecond) // Let the first request take the only slot
+
+	// Applying the same cap again, as a reload does, keeps the slot taken
+	SetMaxInFlight(1, 10*time.Millisecond)
+	_, err = client.Get("/rooms", nil)
+	var busy *BusyError
+	if !errors.As(err, &busy) || busy.RetryAfter != BusyRetryAfter {
+		t.Errorf("second request error = %v, want BusyError", err)
+	}
+
+	close(release)
+	if err := <-first; err != nil {
+		t.Errorf("first request error = %v", err)
+	}
+	if _, err := client.Get("/rooms", nil); err != nil {
+		t.Errorf("request after the slot was released error = %v", err)
+	}
+}
@@ -0,0 +1,135 @@
+package webex
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/raja-aiml/webex-mcp-server/internal/config"
+)
+
+func TestNewHTTPClient_Defaults(t *testing.T) {
+	client, err := NewHTTPClient(config.HTTPConfig{})
+	if err != nil {
+		t.Fatalf("NewHTTPClient() error = %v", err)
+	}
+
+	if client.Timeout != 30*time.Second {
+		t.Errorf("Timeout = %v, want 30s", client.Timeout)
+	}
+
+	transport, ok := client.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("Transport type = %T, want *http.Transport", client.Transport)
+	}
+	if transport.MaxIdleConns != 10 {
+		t.Errorf("MaxIdleConns = %d, want 10", transport.MaxIdleConns)
+	}
+	if transport.MaxIdleConnsPerHost != 2 {
+		t.Errorf("MaxIdleConnsPerHost = %d, want 2", transport.MaxIdleConnsPerHost)
+	}
+	if transport.Proxy == nil {
+		t.Error("Proxy should default to the environment proxy")
+	}
+}
+
+func TestNewHTTPClient_Settings(t *testing.T) {
+	client, err := NewHTTPClient(config.HTTPConfig{
+		Timeout:             5 * time.Second,
+		MaxIdleConns:        50,
+		MaxIdleConnsPerHost: 8,
+		MaxConnsPerHost:     16,
+		ProxyURL:            "http://proxy.internal:3128",
+	})
+	if err != nil {
+		t.Fatalf("NewHTTPClient() error = %v", err)
+	}
+
+	transport := client.Transport.(*http.Transport)
+	if transport.MaxIdleConns != 50 || transport.MaxIdleConnsPerHost != 8 || transport.MaxConnsPerHost != 16 {
+		t.Errorf("pool settings = %d/%d/%d, want 50/8/16",
+			transport.MaxIdleConns, transport.MaxIdleConnsPerHost, transport.MaxConnsPerHost)
+	}
+
+	req, _ := http.NewRequest("GET", "https://webexapis.com/v1/people/me", nil)
+	proxyURL, err := transport.Proxy(req)
+	if err != nil {
+		t.Fatalf("Proxy() error = %v", err)
+	}
+	if proxyURL == nil || proxyURL.Host != "proxy.internal:3128" {
+		t.Errorf("Proxy() = %v, want proxy.internal:3128", proxyURL)
+	}
+}
+
+func TestNewHTTPClient_Errors(t *testing.T) {
+	dir := t.TempDir()
+	emptyPEM := filepath.Join(dir, "empty.pem")
+	if err := os.WriteFile(emptyPEM, []byte("not a certificate"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		cfg  config.HTTPConfig
+	}{
+		{name: "invalid proxy", cfg: config.HTTPConfig{ProxyURL: "://bad"}},
+		{name: "missing CA bundle", cfg: config.HTTPConfig{CACertFile: filepath.Join(dir, "missing.pem")}},
+		{name: "CA bundle without certificates", cfg: config.HTTPConfig{CACertFile: emptyPEM}},
+		{name: "client cert without key", cfg: config.HTTPConfig{ClientCertFile: emptyPEM}},
+		{name: "unreadable client key pair", cfg: config.HTTPConfig{ClientCertFile: emptyPEM, ClientKeyFile: emptyPEM}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewHTTPClient(tt.cfg); err == nil {
+				t.Error("NewHTTPClient() expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestNewHTTPClient_CustomCA(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"me"}`))
+	}))
+	defer server.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caFile, certPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("rejects server without the CA bundle", func(t *testing.T) {
+		client, err := NewClientWithConfig(&config.Config{WebexAPIKey: "token", WebexAPIBaseURL: server.URL})
+		if err != nil {
+			t.Fatalf("NewClientWithConfig() error = %v", err)
+		}
+		if _, err := client.Get("/people/me", nil); err == nil {
+			t.Error("Get() expected certificate error, got nil")
+		}
+	})
+
+	t.Run("trusts server with the CA bundle", func(t *testing.T) {
+		client, err := NewClientWithConfig(&config.Config{
+			WebexAPIKey:     "token",
+			WebexAPIBaseURL: server.URL,
+			HTTP:            config.HTTPConfig{CACertFile: caFile},
+		})
+		if err != nil {
+			t.Fatalf("NewClientWithConfig() error = %v", err)
+		}
+		result, err := client.Get("/people/me", nil)
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if result["id"] != "me" {
+			t.Errorf("Get() id = %v, want me", result["id"])
+		}
+	})
+}
@@ -0,0 +1,195 @@
+// Package webhooks receives Webex webhook notifications on the server's HTTP
+// listener and passes them to waiters in the same process, such as
+// await_card_response. It also registers the server's own webhooks with Webex.
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+
+	"github.com/raja-aiml/webex-mcp-server/internal/webex"
+)
+
+// SignatureHeader carries the HMAC-SHA1 of the body, keyed with the webhook's secret
+const SignatureHeader = "X-Spark-Signature"
+
+// maxBody bounds the size of a notification; Webex sends a few kilobytes at most
+const maxBody = 1 << 20
+
+// keepRecent is how many notifications a hub replays to a new subscriber
+const keepRecent = 256
+
+// Notification is the body Webex posts to a webhook's target URL
+type Notification struct {
+	ID        string                 `json:"id"`
+	Name      string                 `json:"name"`
+	Resource  string                 `json:"resource"`
+	Event     string                 `json:"event"`
+	Filter    string                 `json:"filter,omitempty"`
+	OrgID     string                 `json:"orgId,omitempty"`
+	CreatedBy string                 `json:"createdBy,omitempty"`
+	AppID     string                 `json:"appId,omitempty"`
+	OwnedBy   string                 `json:"ownedBy,omitempty"`
+	ActorID   string                 `json:"actorId,omitempty"`
+	Data      map[string]interface{} `json:"data"`
+}
+
+// Hub passes notifications to subscribers. It keeps the most recent ones so a
+// waiter that subscribes late still sees what arrived just before.
+type Hub struct {
+	mu     sync.Mutex
+	recent []Notification
+	subs   map[int]chan Notification
+	next   int
+}
+
+// Default is the hub the receiver publishes to and tools wait on
+var Default = NewHub()
+
+// NewHub creates an empty hub
+func NewHub() *Hub {
+	return &Hub{subs: make(map[int]chan Notification)}
+}
+
+// Publish passes n to every subscriber; a subscriber that is not keeping up misses it
+func (h *Hub) Publish(n Notification) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.recent = append(h.recent, n)
+	if len(h.recent) > keepRecent {
+		h.recent = h.recent[len(h.recent)-keepRecent:]
+	}
+	for _, ch := range h.subs {
+		select {
+		case ch <- n:
+		default:
+		}
+	}
+}
+
+// Subscribe returns the notifications kept so far and a channel of later ones.
+// cancel stops delivery and must be called when the subscriber is done.
+func (h *Hub) Subscribe() (recent []Notification, notifications <-chan Notification, cancel func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	id := h.next
+	h.next++
+	ch := make(chan Notification, 64)
+	h.subs[id] = ch
+	recent = append([]Notification(nil), h.recent...)
+	return recent, ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		delete(h.subs, id)
+	}
+}
+
+// Receiver is the HTTP handler Webex posts notifications to
+type Receiver struct {
+	hub    *Hub
+	secret func() string
+}
+
+// NewReceiver publishes notifications to hub. secret is read on every request,
+// so a reloaded secret takes effect at once; when it returns "" every
+// notification is refused, since none can be verified.
+func NewReceiver(hub *Hub, secret func() string) *Receiver {
+	return &Receiver{hub: hub, secret: secret}
+}
+
+// ServeHTTP implements http.Handler
+func (r *Receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, req.Body, maxBody))
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+	secret := r.secret()
+	if secret == "" {
+		log.Printf("[Webhooks] Rejected a notification from %s: webhooks.secret is not set", req.RemoteAddr)
+		http.Error(w, "webhook secret not configured", http.StatusServiceUnavailable)
+		return
+	}
+	if !hmac.Equal([]byte(Sign(secret, body)), []byte(req.Header.Get(SignatureHeader))) {
+		log.Printf("[Webhooks] Rejected a notification with an invalid signature from %s", req.RemoteAddr)
+		http.Error(w, "invalid signature", http.StatusForbidden)
+		return
+	}
+
+	var n Notification
+	if err := json.Unmarshal(body, &n); err != nil {
+		http.Error(w, "invalid notification", http.StatusBadRequest)
+		return
+	}
+	r.hub.Publish(n)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Sign returns the signature Webex sends for body when the webhook has secret
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha1.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Spec describes a webhook to register
+type Spec struct {
+	Name      string
+	TargetURL string
+	Resource  string
+	Event     string
+	Secret    string
+}
+
+// Ensure registers the webhook unless one with the same target, resource and
+// event exists, in which case it is reactivated and given the secret. It returns
+// the webhook's ID and whether it was created.
+func Ensure(client webex.HTTPClient, spec Spec) (string, bool, error) {
+	list, err := client.Get("/webhooks", map[string]string{"max": "100"})
+	if err != nil {
+		return "", false, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+
+	fields := map[string]interface{}{
+		"name":      spec.Name,
+		"targetUrl": spec.TargetURL,
+	}
+	if spec.Secret != "" {
+		fields["secret"] = spec.Secret
+	}
+
+	items, _ := list["items"].([]interface{})
+	for _, item := range items {
+		hook, _ := item.(map[string]interface{})
+		if hook["targetUrl"] != spec.TargetURL || hook["resource"] != spec.Resource || hook["event"] != spec.Event {
+			continue
+		}
+		id, _ := hook["id"].(string)
+		// Webex disables a webhook whose target keeps failing
+		fields["status"] = "active"
+		if _, err := client.Put("/webhooks/"+id, fields); err != nil {
+			return "", false, fmt.Errorf("failed to update webhook: %w", err)
+		}
+		return id, false, nil
+	}
+
+	fields["resource"] = spec.Resource
+	fields["event"] = spec.Event
+	hook, err := client.Post("/webhooks", fields)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to create webhook: %w", err)
+	}
+	id, _ := hook["id"].(string)
+	return id, true, nil
+}
@@ -0,0 +1,119 @@
+package webhooks
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/raja-aiml/webex-mcp-server/internal/config"
+	"github.com/raja-aiml/webex-mcp-server/internal/fakewebex"
+	"github.com/raja-aiml/webex-mcp-server/internal/testutil"
+	"github.com/raja-aiml/webex-mcp-server/internal/webex"
+)
+
+func TestReceiver(t *testing.T) {
+	hub := NewHub()
+	secret := "s3cret"
+	receiver := NewReceiver(hub, func() string { return secret })
+	body := `{"id":"W1","resource":"attachmentActions","event":"created","data":{"id":"A1","messageId":"M1"}}`
+
+	tests := []struct {
+		name       string
+		method     string
+		signature  string
+		body       string
+		wantStatus int
+	}{
+		{name: "signed", method: http.MethodPost, signature: Sign(secret, []byte(body)), body: body, wantStatus: http.StatusNoContent},
+		{name: "wrong signature", method: http.MethodPost, signature: Sign("other", []byte(body)), body: body, wantStatus: http.StatusForbidden},
+		{name: "unsigned", method: http.MethodPost, body: body, wantStatus: http.StatusForbidden},
+		{name: "not JSON", method: http.MethodPost, signature: Sign(secret, []byte("nope")), body: "nope", wantStatus: http.StatusBadRequest},
+		{name: "GET", method: http.MethodGet, wantStatus: http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/webhooks/webex", strings.NewReader(tt.body))
+			if tt.signature != "" {
+				req.Header.Set(SignatureHeader, tt.signature)
+			}
+			rec := httptest.NewRecorder()
+			receiver.ServeHTTP(rec, req)
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+
+	// Only the signed notification was published
+	recent, _, cancel := hub.Subscribe()
+	defer cancel()
+	if len(recent) != 1 || recent[0].Data["messageId"] != "M1" {
+		t.Fatalf("recent = %+v", recent)
+	}
+
+	// Without a secret nothing can be verified, so nothing is accepted
+	secret = ""
+	rec := httptest.NewRecorder()
+	receiver.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("unsigned status without a secret = %d", rec.Code)
+	}
+}
+
+func TestHub(t *testing.T) {
+	hub := NewHub()
+	for i := 0; i < keepRecent+5; i++ {
+		hub.Publish(Notification{ID: "old"})
+	}
+	recent, notifications, cancel := hub.Subscribe()
+	if len(recent) != keepRecent {
+		t.Errorf("kept %d notifications, want %d", len(recent), keepRecent)
+	}
+
+	hub.Publish(Notification{ID: "new"})
+	if n := <-notifications; n.ID != "new" {
+		t.Errorf("received %q", n.ID)
+	}
+	cancel()
+	hub.Publish(Notification{ID: "after cancel"})
+	select {
+	case n := <-notifications:
+		t.Errorf("received %q after cancel", n.ID)
+	default:
+	}
+}
+
+func TestEnsure(t *testing.T) {
+	fake, baseURL := testutil.NewFakeWebex(t)
+	client, err := webex.NewClientWithConfig(&config.Config{WebexAPIKey: "token", WebexAPIBaseURL: baseURL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec := Spec{Name: "cards", TargetURL: "https://mcp.example.com/webhooks/webex", Resource: "attachmentActions", Event: "created", Secret: "s1"}
+
+	id, created, err := Ensure(client, spec)
+	if err != nil || !created || id == "" {
+		t.Fatalf("first Ensure() = %q, %v, %v", id, created, err)
+	}
+
+	// A second run finds the webhook, reactivates it and updates its secret
+	if _, err := fake.Add(fakewebex.Webhooks, fakewebex.Item{"name": "other", "targetUrl": spec.TargetURL, "resource": "messages", "event": "created"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Put("/webhooks/"+id, map[string]interface{}{"status": "inactive"}); err != nil {
+		t.Fatal(err)
+	}
+	spec.Secret = "s2"
+	again, created, err := Ensure(client, spec)
+	if err != nil || created || again != id {
+		t.Fatalf("second Ensure() = %q, %v, %v", again, created, err)
+	}
+	hook, _ := fake.Get(fakewebex.Webhooks, id)
+	if hook["status"] != "active" || hook["secret"] != "s2" {
+		t.Errorf("webhook = %v", hook)
+	}
+	if n := len(fake.List(fakewebex.Webhooks)); n != 2 {
+		t.Errorf("%d webhooks, want 2", n)
+	}
+}
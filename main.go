@@ -1,11 +1,15 @@
 package main
 
 import (
+	"context"
 	"flag"
+	"fmt"
 	"log"
+	"os"
 
 	_ "github.com/raja-aiml/webex-mcp-server/internal/advanced_tools"
 	"github.com/raja-aiml/webex-mcp-server/internal/app"
+	"github.com/raja-aiml/webex-mcp-server/internal/cli"
 )
 
 const (
@@ -14,27 +18,29 @@ const (
 )
 
 func main() {
-	var (
-		httpAddr    string
-		envPath     string
-		useAllTools bool
-		sseMode     bool
-	)
+	// Subcommands such as "config print" take over when named first
+	if len(os.Args) > 1 {
+		if cmd, ok := cli.Lookup(os.Args[1]); ok {
+			env := &cli.Env{Name: ServerName, Version: ServerVersion, Stdin: os.Stdin, Stdout: os.Stdout, Stderr: os.Stderr}
+			os.Exit(cli.Execute(context.Background(), cmd, env, os.Args[2:]))
+		}
+	}
 
-	flag.StringVar(&httpAddr, "http", "", "if set, use streamable HTTP at this address, instead of stdin/stdout")
-	flag.StringVar(&envPath, "env", "", "path to .env file. If not set, will try to load from current directory")
-	flag.BoolVar(&useAllTools, "all-tools", false, "load all tools including advanced ones")
-	flag.BoolVar(&sseMode, "sse", false, "enable Server-Sent Events mode")
+	cfg := app.Config{
+		Name:    ServerName,
+		Version: ServerVersion,
+	}
+	cli.BindServerFlags(flag.CommandLine, &cfg)
+	flag.Usage = func() {
+		out := flag.CommandLine.Output()
+		fmt.Fprintf(out, "Usage: %s [flags]\n       %s <command> [args]\n\nFlags:\n", ServerName, ServerName)
+		flag.PrintDefaults()
+		fmt.Fprintln(out)
+		cli.PrintCommands(out)
+	}
 	flag.Parse()
 
-	application := app.New(app.Config{
-		Name:        ServerName,
-		Version:     ServerVersion,
-		HTTPAddr:    httpAddr,
-		EnvPath:     envPath,
-		UseAllTools: useAllTools,
-		SSEMode:     sseMode,
-	})
+	application := app.New(cfg)
 
 	if err := application.Run(); err != nil {
 		log.Fatalf("Application error: %v", err)